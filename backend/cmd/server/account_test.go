@@ -0,0 +1,19 @@
+package main
+
+import (
+	"banking-app/backend/internal/account"
+	"testing"
+)
+
+func TestOpenAccountOnlyAtTheCustomersBank(t *testing.T) {
+	a := newTestApp(t)
+	first := openTestAccount(t, a)
+	second := openTestAccount(t, a)
+
+	if _, err := a.accounts.OpenAccount(second.BankID, first.CustomerID, account.TypeChecking, 0); err == nil {
+		t.Errorf("customer %d of bank %d opened an account at bank %d", first.CustomerID, first.BankID, second.BankID)
+	}
+	if _, err := a.accounts.OpenAccount(first.BankID, first.CustomerID, account.TypeChecking, 0); err != nil {
+		t.Errorf("a second account at the customer's own bank: %v", err)
+	}
+}
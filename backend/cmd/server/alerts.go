@@ -0,0 +1,100 @@
+package main
+
+import (
+	"banking-app/backend/internal/aml"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/pkg/money"
+	"fmt"
+)
+
+func runAlertList(a *app, args []string) error {
+	flags := newFlags("alert list")
+	bankID := flags.Int64("bank-id", 0, "bank whose alerts to list")
+	statusStr := flags.String("status", "", "only list alerts with this status")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	var status aml.Status
+	if *statusStr != "" {
+		var err error
+		if status, err = aml.ParseStatus(*statusStr); err != nil {
+			return err
+		}
+	}
+
+	alerts := a.alerts.GetBankAlerts(*bankID, status)
+	if *asJSON {
+		return printJSON(alerts)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tDate\tCustomer ID\tTransaction\tAmount\tRule\tHeld\tStatus\tReason")
+	for _, alert := range alerts {
+		fmt.Fprintf(t, "%d\t%s\t%d\t%d\t%s\t%s\t%t\t%s\t%s\n", alert.ID, alert.CreatedAt.Format("2006-01-02 15:04"), alert.CustomerID,
+			alert.TransactionID, money.Format(alert.Amount), alert.Rule, alert.Held, alert.Status, alert.Reason)
+	}
+	return t.Flush()
+}
+
+func runAlertClear(a *app, args []string) error {
+	flags := newFlags("alert clear")
+	id := flags.Int64("id", 0, "alert ID")
+	note := flags.String("note", "", "note for the case record")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	b, err := alertBank(a, *id)
+	if err != nil {
+		return err
+	}
+	alert, err := a.alerts.Clear(b.ID, *id, b.UserID, *note)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Alert %d cleared\n", alert.ID)
+	return nil
+}
+
+func runAlertConfirm(a *app, args []string) error {
+	flags := newFlags("alert confirm")
+	id := flags.Int64("id", 0, "alert ID")
+	note := flags.String("note", "", "why the transaction is suspicious")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "note"); err != nil {
+		return err
+	}
+
+	b, err := alertBank(a, *id)
+	if err != nil {
+		return err
+	}
+	alert, err := a.alerts.Confirm(b.ID, *id, b.UserID, *note)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Alert %d confirmed\n", alert.ID)
+	return nil
+}
+
+// alertBank is the bank an alert belongs to. Reviews from the command line
+// are recorded as made by the bank's operator.
+func alertBank(a *app, alertID int64) (*bank.Bank, error) {
+	alert, err := a.alerts.GetAlert(alertID)
+	if err != nil {
+		return nil, err
+	}
+	return a.banks.GetBank(alert.BankID)
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/aml"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/batch"
+	"banking-app/backend/internal/calendar"
+	"banking-app/backend/internal/card"
+	"banking-app/backend/internal/config"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/eod"
+	"banking-app/backend/internal/fee"
+	"banking-app/backend/internal/gl"
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/internal/interest"
+	"banking-app/backend/internal/limit"
+	"banking-app/backend/internal/loan"
+	"banking-app/backend/internal/notification"
+	"banking-app/backend/internal/payee"
+	"banking-app/backend/internal/reconcile"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/internal/search"
+	"banking-app/backend/internal/standingorder"
+	"banking-app/backend/internal/statement"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/internal/user"
+	"banking-app/backend/pkg/console"
+	"fmt"
+	"os"
+	"time"
+)
+
+// app wires every repository and service to one database file so commands
+// and the interactive menu share the same view of the data.
+type app struct {
+	cfg config.Config
+	con *console.Console
+
+	users         *user.Service
+	banks         *bank.Service
+	customers     *customer.Service
+	accounts      *account.Service
+	transactions  *transactions.Service
+	interest      *interest.Service
+	fees          *fee.Service
+	loans         *loan.Service
+	notifications *notification.Service
+	limits        *limit.Service
+	calendar      *calendar.Service
+	orders        *standingorder.Service
+	payees        *payee.Service
+	alerts        *aml.Service
+	screening     *sanctions.Service
+	holds         *hold.Service
+	cards         *card.Service
+	days          *eod.Service
+	ledger        *gl.Service
+	reconciler    *reconcile.Service
+	statements    *statement.Service
+	batches       *batch.Service
+	search        *search.Service
+}
+
+func newApp(cfg config.Config) (*app, error) {
+	dbPath := cfg.Storage.Path
+
+	userRepo, err := user.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	bankRepo := bank.NewRepository(dbPath)
+
+	customerRepo, err := customer.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	accountRepo, err := account.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	txRepo, err := transactions.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	interestRepo, err := interest.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	feeRepo, err := fee.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	loanRepo, err := loan.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	notificationRepo, err := notification.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	limitRepo, err := limit.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	calendarRepo, err := calendar.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	orderRepo, err := standingorder.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	payeeRepo, err := payee.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	screeningRepo, err := sanctions.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	holdRepo, err := hold.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	cardRepo, err := card.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	dayRepo, err := eod.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	glRepo, err := gl.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	batchRepo, err := batch.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	reconcileRepo, err := reconcile.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	screeningService := sanctions.NewService(screeningRepo, cfg.Sanctions)
+	notificationService := notification.NewService(notificationRepo)
+	customerService := customer.NewService(customerRepo, notificationService, screeningService)
+	amlRepo, err := aml.NewRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database: %w", err)
+	}
+
+	feeService := fee.NewService(feeRepo, cfg.Fees)
+	limitService := limit.NewService(limitRepo)
+	calendarService := calendar.NewService(calendarRepo)
+	glService := gl.NewService(glRepo, accountRepo)
+	dates := eod.NewDates(dayRepo, calendarService)
+	txService := transactions.NewService(txRepo, accountRepo, customerService, cfg.Limits, feeService, notificationService, limitService,
+		aml.NewMonitor(amlRepo, txRepo, accountRepo, payeeRepo, cfg.AML), glService, dates)
+	holdService := hold.NewService(holdRepo, accountRepo, cfg.Holds)
+	interestService := interest.NewService(interestRepo, accountRepo, txService)
+	loanService := loan.NewService(loanRepo, accountRepo, customerService, txService, feeService)
+	orderService := standingorder.NewService(orderRepo, accountRepo, txService, calendarService, notificationService)
+
+	accountService := account.NewService(accountRepo, customerService)
+	bankService := bank.NewService(bankRepo, cfg.Bank)
+	userService := user.NewService(userRepo, cfg.Password)
+
+	// end of day posts through its own services, which can post while the
+	// bank is frozen for it
+	eodTxService := txService.EndOfDay()
+	dayService := eod.NewService(dayRepo, dates, accountRepo, eodTxService,
+		interest.NewService(interestRepo, accountRepo, eodTxService),
+		standingorder.NewService(orderRepo, accountRepo, eodTxService, calendarService, notificationService),
+		loan.NewService(loanRepo, accountRepo, customerService, eodTxService, feeService), glService)
+
+	a := &app{
+		cfg:          cfg,
+		con:          console.New(os.Stdin, os.Stdout),
+		users:        userService,
+		banks:        bankService,
+		customers:    customerService,
+		accounts:     accountService,
+		transactions: txService,
+		interest:     interestService,
+		fees:         feeService,
+		loans:        loanService,
+
+		notifications: notificationService,
+		limits:        limitService,
+		calendar:      calendarService,
+		orders:        orderService,
+		payees:        payee.NewService(payeeRepo, accountRepo, txService, screeningService, cfg.Payees),
+		alerts:        aml.NewService(amlRepo, txService),
+		screening:     screeningService,
+		holds:         holdService,
+		cards:         card.NewService(cardRepo, accountRepo, customerService, holdService, txService),
+		days:          dayService,
+		ledger:        glService,
+		statements:    statement.NewService(accountService, customerService, bankService, txService, holdService, cfg.Statements),
+		batches:       batch.NewService(batchRepo, accountService, customerService, txService, screeningService, cfg.Statements.Currency),
+		reconciler:    reconcile.NewService(reconcileRepo, accountRepo, txService, glService, dates),
+		search:        search.NewService(userService, bankService, customerService, accountService, txService),
+	}
+
+	// banks from before the general ledger get their chart of accounts with
+	// the balances they already have
+	for _, b := range a.banks.GetAllBanks() {
+		if err := a.ledger.Open(b.ID); err != nil {
+			return nil, fmt.Errorf("failed to open general ledger of bank %d: %w", b.ID, err)
+		}
+	}
+
+	return a, nil
+}
+
+// releaseExpiredHolds releases the holds that have run out, so they stop
+// counting against the available balance. Commands that read or spend the
+// available balance call it before they do, serve only reads and leaves
+// them to the next command.
+func (a *app) releaseExpiredHolds() error {
+	if _, err := a.holds.ExpireDue(time.Now()); err != nil {
+		return fmt.Errorf("failed to release expired holds: %w", err)
+	}
+	return nil
+}
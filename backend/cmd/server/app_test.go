@@ -0,0 +1,79 @@
+package main
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/config"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/user"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestApp(t *testing.T) *app {
+	t.Helper()
+	return newTestAppAt(t, filepath.Join(t.TempDir(), "db.json"))
+}
+
+// newTestAppAt loads an app on the database file at path, like another run
+// of the command would.
+func newTestAppAt(t *testing.T, path string) *app {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Storage.Path = path
+	a, err := newApp(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// openTestAccount opens a checking account for a new verified customer of a
+// new bank.
+func openTestAccount(t *testing.T, a *app) *account.Account {
+	t.Helper()
+	n := len(a.users.GetAllUsers())
+
+	operator, err := a.users.Register(fmt.Sprintf("bank%d", n), "S3cret-pass", user.RoleBank)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := a.banks.CreateBank(operator.ID, fmt.Sprintf("Test Bank %d", n))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	holder, err := a.users.Register(fmt.Sprintf("customer%d", n), "S3cret-pass", user.RoleCustomer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := a.customers.CreateCustomer(holder.ID, b.ID, "Ada Lovelace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	details := customer.Details{
+		FullName:    "Ada Lovelace",
+		DateOfBirth: now.AddDate(-30, 0, 0),
+		Address:     "12 Analytical Street, London",
+		Document: customer.Document{
+			Type:      customer.DocumentPassport,
+			Number:    "AB1234567",
+			Country:   "GB",
+			ExpiresAt: now.AddDate(5, 0, 0),
+		},
+	}
+	if _, err := a.customers.SubmitDetails(c.ID, details); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.customers.Approve(b.ID, c.ID, operator.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	acc, err := a.accounts.OpenAccount(b.ID, c.ID, account.TypeChecking, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
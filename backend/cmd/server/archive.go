@@ -0,0 +1,141 @@
+package main
+
+import (
+	"banking-app/backend/pkg/database"
+	"banking-app/backend/pkg/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+func runExport(dbPath string, args []string) error {
+	flags := newFlags("export")
+	out := flags.String("out", "", "archive file to write, e.g. backup.tar.gz")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "out"); err != nil {
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *out, err)
+	}
+	manifest, err := storage.Export(dbPath, f, collections)
+	if err != nil {
+		f.Close()
+		os.Remove(*out)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	records := 0
+	for _, c := range manifest.Collections {
+		records += c.Records
+	}
+	fmt.Printf("Exported %d records in %d collections from %s to %s\n", records, len(manifest.Collections), dbPath, *out)
+	return nil
+}
+
+func runImport(dbPath string, args []string) error {
+	flags := newFlags("import")
+	path := flags.String("file", "", "archive made by export")
+	overwrite := flags.Bool("overwrite", false, "replace a database that already has data")
+	dryRun := flags.Bool("dry-run", false, "only check the archive")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "file"); err != nil {
+		return err
+	}
+
+	f, err := os.Open(*path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", *path, err)
+	}
+	archive, err := storage.ReadArchive(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if err := checkArchive(archive); err != nil {
+		return err
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Collection\tRecords")
+	for _, c := range archive.Manifest.Collections {
+		fmt.Fprintf(t, "%s\t%d\n", c.Name, c.Records)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("Archive version %d made %s is valid\n", archive.Manifest.Version, archive.Manifest.CreatedAt.Format("2006-01-02 15:04"))
+	if *dryRun {
+		return nil
+	}
+
+	if err := storage.Restore(dbPath, archive, *overwrite); err != nil {
+		if errors.Is(err, storage.ErrNotEmpty) {
+			return fmt.Errorf("%s: %w; run with --overwrite to replace it", dbPath, err)
+		}
+		return err
+	}
+	// collections newer than the archive are created empty
+	if _, err := storage.Migrate(dbPath, collections); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %s into %s\n", *path, dbPath)
+	return nil
+}
+
+// checkArchive makes sure an archive only holds collections this build knows,
+// that every record decodes as the type of its collection, and that no two
+// records of a collection share an ID.
+func checkArchive(archive *storage.Archive) error {
+	whole := map[string]json.RawMessage{}
+	for name, records := range archive.Collections {
+		if !slices.Contains(collections, name) {
+			return fmt.Errorf("archive has unknown collection %q", name)
+		}
+
+		seen := map[string]int{}
+		for i, record := range records {
+			var keyed struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if err := json.Unmarshal(record, &keyed); err != nil {
+				return fmt.Errorf("%s record %d: %w", name, i+1, err)
+			}
+			if id := strings.TrimSpace(string(keyed.ID)); id != "" {
+				if first, ok := seen[id]; ok {
+					return fmt.Errorf("%s records %d and %d have the same ID %s", name, first, i+1, id)
+				}
+				seen[id] = i + 1
+			}
+		}
+
+		raw, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		whole[name] = raw
+	}
+
+	data, err := json.Marshal(whole)
+	if err != nil {
+		return err
+	}
+	var db database.Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		return fmt.Errorf("archive does not match the database layout: %w", err)
+	}
+	return nil
+}
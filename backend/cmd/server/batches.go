@@ -0,0 +1,184 @@
+package main
+
+import (
+	"banking-app/backend/internal/batch"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func runBatchImport(a *app, args []string) error {
+	flags := newFlags("batch import")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	fromNumber := flags.String("from", "", "account number to pay from")
+	path := flags.String("file", "", "payment file")
+	formatStr := flags.String("format", "", "csv or pain001 (default by file extension)")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id", "from", "file"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	if *formatStr == "" {
+		*formatStr = string(batch.FormatCSV)
+		if strings.EqualFold(filepath.Ext(*path), ".xml") {
+			*formatStr = string(batch.FormatPain001)
+		}
+	}
+	format, err := batch.ParseFormat(*formatStr)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		return fmt.Errorf("failed to read payment file: %w", err)
+	}
+
+	b, existing, err := a.batches.Import(*customerID, *fromNumber, filepath.Base(*path), data, format)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(b)
+	}
+
+	if existing {
+		fmt.Printf("This file was imported before as batch %d, nothing new was imported.\n\n", b.ID)
+	} else {
+		fmt.Printf("Imported batch %d from %s.\n\n", b.ID, b.FileName)
+	}
+	if err := printBatch(a, b); err != nil {
+		return err
+	}
+	if b.Totals().Payable > 0 {
+		fmt.Printf("\nRun batch execute --customer-id %d --id %d to pay it.\n", b.CustomerID, b.ID)
+	}
+	return nil
+}
+
+func runBatchExecute(a *app, args []string) error {
+	flags := newFlags("batch execute")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	id := flags.Int64("id", 0, "batch ID")
+	yes := flags.Bool("yes", false, "do not ask to confirm the fees")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id", "id"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	b, err := a.batches.GetBatch(*customerID, *id)
+	if err != nil {
+		return err
+	}
+	if b.Totals().Payable == 0 {
+		return fmt.Errorf("batch %d has nothing left to pay", b.ID)
+	}
+
+	charge, err := a.batches.Quote(*customerID, *id)
+	if err != nil {
+		return err
+	}
+	if ok, err := confirmFee(a, charge, *yes); !ok || err != nil {
+		return err
+	}
+
+	b, err = a.batches.Execute(*customerID, *id)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(b)
+	}
+	return printBatch(a, b)
+}
+
+func runBatchShow(a *app, args []string) error {
+	flags := newFlags("batch show")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	id := flags.Int64("id", 0, "batch ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id", "id"); err != nil {
+		return err
+	}
+
+	b, err := a.batches.GetBatch(*customerID, *id)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(b)
+	}
+	return printBatch(a, b)
+}
+
+func runBatchList(a *app, args []string) error {
+	flags := newFlags("batch list")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id"); err != nil {
+		return err
+	}
+
+	batches := a.batches.GetCustomerBatches(*customerID)
+	if *asJSON {
+		return printJSON(batches)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tImported\tFile\tAccount\tLines\tPaid\tTotal paid\tStatus")
+	for _, b := range batches {
+		totals := b.Totals()
+		fmt.Fprintf(t, "%d\t%s\t%s\t%d\t%d\t%d\t%s\t%s\n", b.ID, b.CreatedAt.Format("2006-01-02 15:04"), b.FileName, b.AccountID,
+			totals.Lines, totals.Paid, money.Format(totals.PaidTotal), b.Status)
+	}
+	return t.Flush()
+}
+
+func printBatch(a *app, b *batch.Batch) error {
+	from := fmt.Sprint(b.AccountID)
+	if acc, err := a.accounts.GetAccount(b.AccountID); err == nil {
+		from = accountno.Group(acc.Number)
+		defer fmt.Printf("Available on %s: %s\n", from, money.Format(acc.Available()))
+	}
+	fmt.Printf("Batch %d from account %s, %s\n", b.ID, from, b.Status)
+
+	t := newTable()
+	fmt.Fprintln(t, "Line\tReference\tName\tAccount\tAmount\tStatus\tNote")
+	for _, l := range b.Lines {
+		note := l.Error
+		if l.TransactionID != 0 {
+			note = fmt.Sprintf("transaction %d", l.TransactionID)
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", l.Number, l.Reference, l.Name, l.Account, money.Format(l.Amount), l.Status, note)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	totals := b.Totals()
+	fmt.Printf("\n%d line(s): %d to pay totalling %s, %d paid totalling %s, %d rejected\n", totals.Lines, totals.Payable,
+		money.Format(totals.ToPay), totals.Paid, money.Format(totals.PaidTotal), totals.Rejected)
+	return nil
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"banking-app/backend/internal/card"
+	"banking-app/backend/pkg/money"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func runCardIssue(a *app, args []string) error {
+	flags := newFlags("card issue")
+	accountID := flags.Int64("account-id", 0, "account the card pays out of")
+	pin := flags.String("pin", "", "4-digit PIN, visible to other local users: prefer the prompt or stdin")
+	perPurchase := flags.String("per-purchase", "0", "most a single purchase may be, 0 for no limit")
+	daily := flags.String("daily", "0", "most that may be spent in a day, 0 for no limit")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "account-id"); err != nil {
+		return err
+	}
+
+	limits, err := parseCardLimits(*perPurchase, *daily)
+	if err != nil {
+		return err
+	}
+
+	if *pin == "" {
+		if a.con.Interactive() {
+			*pin, err = a.con.PromptNewSecret("PIN: ", "Confirm PIN: ")
+		} else {
+			// scripts pipe the PIN in on the first line of stdin
+			*pin, err = a.con.Prompt("")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read PIN: %w", err)
+		}
+	}
+
+	c, err := a.cards.Issue(*accountID, *pin, limits)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Card issued: ID %d, number %s, expires %s, on account %d\n", c.ID, c.PAN, c.Expiry(), c.AccountID)
+	return nil
+}
+
+// cardView is a card without its PIN hash and with the number masked, for
+// listing.
+type cardView struct {
+	ID         int64       `json:"id"`
+	BankID     int64       `json:"bankid"`
+	CustomerID int64       `json:"customerid"`
+	AccountID  int64       `json:"accountid"`
+	Number     string      `json:"number"`
+	Expiry     string      `json:"expiry"`
+	Status     card.Status `json:"status"`
+	Limits     card.Limits `json:"limits"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+func runCardList(a *app, args []string) error {
+	flags := newFlags("card list")
+	bankID := flags.Int64("bank-id", 0, "list the cards of this bank")
+	customerID := flags.Int64("customer-id", 0, "list the cards of this customer")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var cards []*card.Card
+	switch {
+	case *customerID != 0:
+		cards = a.cards.GetCustomerCards(*customerID)
+	case *bankID != 0:
+		cards = a.cards.GetBankCards(*bankID)
+	default:
+		return fmt.Errorf("card list: --bank-id or --customer-id is required")
+	}
+
+	views := make([]cardView, len(cards))
+	for i, c := range cards {
+		views[i] = cardView{c.ID, c.BankID, c.CustomerID, c.AccountID, c.Masked(), c.Expiry(), c.Status, c.Limits, c.CreatedAt}
+	}
+
+	if *asJSON {
+		return printJSON(views)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tNumber\tExpiry\tCustomer ID\tAccount ID\tStatus\tPer purchase\tDaily")
+	for _, v := range views {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n", v.ID, v.Number, v.Expiry, v.CustomerID, v.AccountID, v.Status,
+			limitLabel(v.Limits.PerPurchase), limitLabel(v.Limits.Daily))
+	}
+	return t.Flush()
+}
+
+func runCardLimits(a *app, args []string) error {
+	flags := newFlags("card limits")
+	id := flags.Int64("id", 0, "card ID")
+	perPurchase := flags.String("per-purchase", "", "most a single purchase may be, 0 for no limit")
+	daily := flags.String("daily", "", "most that may be spent in a day, 0 for no limit")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	c, err := a.cards.GetCard(*id)
+	if err != nil {
+		return err
+	}
+	// limits left out keep their current value
+	current := c.Limits
+	if *perPurchase != "" {
+		if current.PerPurchase, err = money.Parse(*perPurchase); err != nil {
+			return fmt.Errorf("invalid per-purchase limit: %w", err)
+		}
+	}
+	if *daily != "" {
+		if current.Daily, err = money.Parse(*daily); err != nil {
+			return fmt.Errorf("invalid daily limit: %w", err)
+		}
+	}
+
+	c, err = a.cards.SetLimits(*id, current)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Card %d limits: %s per purchase, %s a day\n", c.ID, limitLabel(c.Limits.PerPurchase), limitLabel(c.Limits.Daily))
+	return nil
+}
+
+func runCardFreeze(a *app, args []string) error {
+	return runCardStatus(a, args, "card freeze", a.cards.Freeze)
+}
+
+func runCardUnfreeze(a *app, args []string) error {
+	return runCardStatus(a, args, "card unfreeze", a.cards.Unfreeze)
+}
+
+func runCardCancel(a *app, args []string) error {
+	return runCardStatus(a, args, "card cancel", a.cards.Cancel)
+}
+
+func runCardStatus(a *app, args []string, name string, change func(int64) (*card.Card, error)) error {
+	flags := newFlags(name)
+	id := flags.Int64("id", 0, "card ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	c, err := change(*id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Card %d (%s) is now %s\n", c.ID, c.Masked(), c.Status)
+	return nil
+}
+
+func runCardPayments(a *app, args []string) error {
+	flags := newFlags("card payments")
+	id := flags.Int64("id", 0, "card ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	if _, err := a.cards.GetCard(*id); err != nil {
+		return err
+	}
+	auths, err := a.cards.GetCardAuthorisations(*id)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(auths)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tDate\tMerchant\tAmount\tStatus\tCaptured\tNote")
+	for _, auth := range auths {
+		captured := ""
+		if auth.Status == card.AuthCaptured {
+			captured = money.Format(auth.CapturedAmount)
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", auth.ID, auth.CreatedAt.Format("2006-01-02 15:04"), auth.Merchant,
+			money.Format(auth.Amount), auth.Status, captured, auth.DeclineReason)
+	}
+	return t.Flush()
+}
+
+// The merchant commands simulate a shop's card terminal, to drive the
+// authorisation engine by hand or from scripts.
+
+func runMerchantAuthorise(a *app, args []string) error {
+	flags := newFlags("merchant authorise")
+	pan := flags.String("pan", "", "card number")
+	expiry := flags.String("expiry", "", "expiry date on the card, MM/YY")
+	amountStr := flags.String("amount", "", "amount to authorise")
+	merchant := flags.String("merchant", "", "shop name")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "pan", "expiry", "amount", "merchant"); err != nil {
+		return err
+	}
+
+	amount, err := money.Parse(*amountStr)
+	if err != nil {
+		return err
+	}
+
+	// the PIN is never a flag, where other local users could see it
+	var pin string
+	if a.con.Interactive() {
+		pin, err = a.con.PromptSecret("PIN: ")
+	} else {
+		// scripts pipe the PIN in on the first line of stdin
+		pin, err = a.con.Prompt("")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read PIN: %w", err)
+	}
+
+	auth, err := a.cards.Authorise(*pan, *expiry, pin, amount, *merchant)
+	if errors.Is(err, card.ErrDeclined) {
+		fmt.Printf("Authorisation %d declined: %s\n", auth.ID, auth.DeclineReason)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Authorisation %d approved: %s held for %s\n", auth.ID, money.Format(auth.Amount), auth.Merchant)
+	return nil
+}
+
+func runMerchantCapture(a *app, args []string) error {
+	flags := newFlags("merchant capture")
+	authID := flags.Int64("auth-id", 0, "authorisation ID")
+	amountStr := flags.String("amount", "", "amount to take, defaults to all that was authorised")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "auth-id"); err != nil {
+		return err
+	}
+
+	var amount int64
+	if *amountStr != "" {
+		var err error
+		if amount, err = money.Parse(*amountStr); err != nil {
+			return err
+		}
+	}
+
+	auth, err := a.cards.Capture(*authID, amount)
+	if auth == nil {
+		return err
+	}
+
+	fmt.Printf("Authorisation %d captured: %s taken in transaction %d\n", auth.ID, money.Format(auth.CapturedAmount), auth.TransactionID)
+	return err
+}
+
+func runMerchantRelease(a *app, args []string) error {
+	flags := newFlags("merchant release")
+	authID := flags.Int64("auth-id", 0, "authorisation ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "auth-id"); err != nil {
+		return err
+	}
+
+	auth, err := a.cards.Release(*authID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Authorisation %d released: %s is available again\n", auth.ID, money.Format(auth.Amount))
+	return nil
+}
+
+func parseCardLimits(perPurchase, daily string) (card.Limits, error) {
+	var limits card.Limits
+	var err error
+	if limits.PerPurchase, err = money.Parse(perPurchase); err != nil {
+		return limits, fmt.Errorf("invalid per-purchase limit: %w", err)
+	}
+	if limits.Daily, err = money.Parse(daily); err != nil {
+		return limits, fmt.Errorf("invalid daily limit: %w", err)
+	}
+	return limits, nil
+}
+
+func limitLabel(amount int64) string {
+	if amount == 0 {
+		return "no limit"
+	}
+	return money.Format(amount)
+}
@@ -0,0 +1,1126 @@
+package main
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/api"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/config"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/internal/user"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"banking-app/backend/pkg/storage"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type command struct {
+	name  string // one or two words, e.g. "transfer" or "bank list"
+	usage string
+	run   func(a *app, args []string) error
+}
+
+var commands = []command{
+	{"menu", "start the interactive menu (default)", runMenu},
+	{"script", "[--file PATH] run the menu with a line of input per prompt, one JSON step per prompt", runScript},
+	{"user create", "--username NAME [--role bank|customer] (password is prompted for or read from stdin)", runUserCreate},
+	{"user list", "[--json]", runUserList},
+	{"bank create", "--user-id ID --name NAME", runBankCreate},
+	{"bank list", "[--json]", runBankList},
+	{"bank update", "--id ID --name NAME", runBankUpdate},
+	{"bank delete", "--id ID", runBankDelete},
+	{"customer create", "--user-id ID --bank-id ID --name NAME", runCustomerCreate},
+	{"customer list", "[--bank-id ID] [--status unverified|pending|verified|rejected] [--json]", runCustomerList},
+	{"customer show", "--id ID [--json] identity details and verification history", runCustomerShow},
+	{"customer submit", "--id ID --name NAME --dob YYYY-MM-DD --address TEXT --document passport|id-card|driving-licence --document-number TEXT --country CODE --expires YYYY-MM-DD", runCustomerSubmit},
+	{"customer queue", "--bank-id ID [--json] customers waiting for verification", runCustomerQueue},
+	{"customer approve", "--id ID [--note TEXT]", runCustomerApprove},
+	{"customer reject", "--id ID --reason TEXT", runCustomerReject},
+	{"account open", "--customer-id ID [--type checking|savings] [--product-id ID]", runAccountOpen},
+	{"account list", "[--customer-id ID] [--json]", runAccountList},
+	{"account deposit", "--id ID --amount AMOUNT [--memo TEXT] [--date YYYY-MM-DD [--adjustment]]", runAccountDeposit},
+	{"account withdraw", "--id ID --amount AMOUNT [--memo TEXT] [--yes] [--date YYYY-MM-DD [--adjustment]]", runAccountWithdraw},
+	{"account overdraft", "--id ID --limit AMOUNT [--rate PERCENT]", runAccountOverdraft},
+	{"account history", "--id ID [--json]", runAccountHistory},
+	{"account statement", "--id ID [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--format text|csv|ofx|camt053] [--out FILE]", runAccountStatement},
+	{"hold list", "--account-id ID [--all] [--json] money set aside on an account", runHoldList},
+	{"alert list", "--bank-id ID [--status open|cleared|confirmed] [--json] anti-money-laundering alerts", runAlertList},
+	{"alert clear", "--id ID [--note TEXT] a false positive, releases a held transaction", runAlertClear},
+	{"alert confirm", "--id ID --note TEXT suspicious, returns a held transaction to the payer", runAlertConfirm},
+	{"watchlist show", "[--json] the loaded sanctions watchlist", runWatchlistShow},
+	{"watchlist check", "--name NAME [--json] watchlist entries similar to a name", runWatchlistCheck},
+	{"screening list", "--bank-id ID [--status open|cleared|confirmed] [--json] watchlist hits", runScreeningList},
+	{"screening clear", "--id ID [--note TEXT] a different person, lets them go ahead", runScreeningClear},
+	{"screening confirm", "--id ID --note TEXT a true match, keeps them blocked", runScreeningConfirm},
+	{"card issue", "--account-id ID [--per-purchase AMOUNT] [--daily AMOUNT] (PIN is prompted for or read from stdin)", runCardIssue},
+	{"card list", "(--bank-id ID | --customer-id ID) [--json]", runCardList},
+	{"card limits", "--id ID [--per-purchase AMOUNT] [--daily AMOUNT] (0 for no limit)", runCardLimits},
+	{"card freeze", "--id ID", runCardFreeze},
+	{"card unfreeze", "--id ID", runCardUnfreeze},
+	{"card cancel", "--id ID", runCardCancel},
+	{"card payments", "--id ID [--json] authorisations made with a card", runCardPayments},
+	{"merchant authorise", "--pan NUMBER --expiry MM/YY --amount AMOUNT --merchant NAME simulate a card payment (PIN is prompted for or read from stdin)", runMerchantAuthorise},
+	{"merchant capture", "--auth-id ID [--amount AMOUNT] take an authorised payment", runMerchantCapture},
+	{"merchant release", "--auth-id ID cancel an authorised payment", runMerchantRelease},
+	{"transfer", "--from NUMBER (--to NUMBER | --payee ID) --amount AMOUNT [--memo TEXT] [--yes] [--date YYYY-MM-DD [--adjustment]]", runTransfer},
+	{"eod status", "--bank-id ID the bank's business date and last closed day", runEODStatus},
+	{"eod close", "--bank-id ID [--json] run end of day and move to the next business day", runEODClose},
+	{"eod history", "--bank-id ID [--json] closed business days", runEODHistory},
+	{"eod trial-balance", "--bank-id ID [--json] balances by account type against the ledger", runEODTrialBalance},
+	{"gl chart", "--bank-id ID [--json] the bank's general ledger accounts", runGLChart},
+	{"gl journal", "--bank-id ID [--json] journal entries booked in the general ledger", runGLJournal},
+	{"gl trial-balance", "--bank-id ID [--json] debits and credits per general ledger account", runGLTrialBalance},
+	{"gl balance-sheet", "--bank-id ID [--json] assets, liabilities and equity", runGLBalanceSheet},
+	{"gl pnl", "--bank-id ID [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--json] income and expenses over business days", runGLProfitAndLoss},
+	{"reconcile run", "--bank-id ID [--repair] [--json] check balances against the ledger and general ledger, --repair corrects them", runReconcile},
+	{"reconcile history", "--bank-id ID [--json] past reconciliations", runReconcileHistory},
+	{"search", "--user-id ID --query TEXT [--kind KIND] [--limit N] [--json] find banks, customers, users, accounts and transactions the user may see", runSearch},
+	{"batch import", "--customer-id ID --from NUMBER --file PATH [--format csv|pain001] [--json] check a payment file and preview it", runBatchImport},
+	{"batch execute", "--customer-id ID --id ID [--yes] [--json] pay the lines of an imported batch", runBatchExecute},
+	{"batch show", "--customer-id ID --id ID [--json] the lines of a batch and their status", runBatchShow},
+	{"batch list", "--customer-id ID [--json]", runBatchList},
+	{"payee add", "--customer-id ID --name NAME [--nickname NAME] --account NUMBER", runPayeeAdd},
+	{"payee list", "--customer-id ID [--json]", runPayeeList},
+	{"payee remove", "--customer-id ID --id ID", runPayeeRemove},
+	{"standing-order create", "--from NUMBER --to NUMBER --amount AMOUNT --frequency daily|weekly|monthly|end-of-month [--day N] --start YYYY-MM-DD [--end YYYY-MM-DD] [--memo TEXT]", runOrderCreate},
+	{"standing-order list", "[--customer-id ID] [--json]", runOrderList},
+	{"standing-order pause", "--id ID", runOrderPause},
+	{"standing-order resume", "--id ID", runOrderResume},
+	{"standing-order cancel", "--id ID", runOrderCancel},
+	{"standing-order run", "[--date YYYY-MM-DD] [--json] make the payments that are due", runOrderRun},
+	{"holiday add", "--bank-id ID --date YYYY-MM-DD --name NAME", runHolidayAdd},
+	{"holiday list", "--bank-id ID [--json]", runHolidayList},
+	{"holiday remove", "--bank-id ID --date YYYY-MM-DD", runHolidayRemove},
+	{"product create", "--bank-id ID --name NAME --rate PERCENT [--day-count actual/365|30/360]", runProductCreate},
+	{"product list", "[--bank-id ID] [--json]", runProductList},
+	{"fee set", "--bank-id ID --type TYPE [--flat AMOUNT] [--percent PERCENT] [--min AMOUNT] [--max AMOUNT]", runFeeSet},
+	{"fee remove", "--bank-id ID --type TYPE (fall back to the default fee)", runFeeRemove},
+	{"fee list", "--bank-id ID [--json]", runFeeList},
+	{"fee charge-maintenance", "[--month YYYY-MM] [--json] bill the monthly maintenance fee", runFeeChargeMaintenance},
+	{"limit set", "--bank-id ID [--customer-id ID] [--max-transaction AMOUNT] [--daily AMOUNT] [--monthly AMOUNT] [--transfers-per-hour N] [--for DURATION]", runLimitSet},
+	{"limit remove", "--bank-id ID [--customer-id ID] [--temporary]", runLimitRemove},
+	{"limit list", "--bank-id ID [--json]", runLimitList},
+	{"limit show", "--customer-id ID [--json] the limits in force and what has been used", runLimitShow},
+	{"loan apply", "--customer-id ID --account-id ID --amount AMOUNT --months N [--method annuity|flat]", runLoanApply},
+	{"loan approve", "--id ID --rate PERCENT (pays out the loan)", runLoanApprove},
+	{"loan reject", "--id ID --reason TEXT", runLoanReject},
+	{"loan list", "[--bank-id ID] [--customer-id ID] [--status STATUS] [--json]", runLoanList},
+	{"loan schedule", "--id ID [--json]", runLoanSchedule},
+	{"loan collect", "[--date YYYY-MM-DD] [--json] collect the installments due", runLoanCollect},
+	{"notification list", "--customer-id ID [--unread] [--json]", runNotificationList},
+	{"interest accrue", "[--dry-run] [--json] accrue savings interest through yesterday", runInterestAccrue},
+	{"interest backfill", "--through YYYY-MM-DD [--dry-run] [--json] accrue every missed day", runInterestBackfill},
+	{"serve", "[--addr HOST:PORT]", runServe},
+	{"migrate", "upgrade the database file to the current layout", nil},
+	{"export", "--out FILE write every collection to a portable archive (.tar.gz)", nil},
+	{"import", "--file FILE [--overwrite] [--dry-run] check an archive and load it into the database", nil},
+	{"seed", "[--preset small|medium|large] [--banks N] [--customers N] [--days N] [--seed N] [--end YYYY-MM-DD] [--overwrite] fill the database with generated data (password is prompted for or read from stdin)", nil},
+	{"config show", "[--json] print the effective configuration", runConfigShow},
+}
+
+// collections lists every top-level key of the database file, used by migrate.
+var collections = []string{"accounts", "accruals", "alerts", "authorisations", "banks", "batches", "cards", "closedDays", "closings", "customers", "fees", "glAccounts", "holds", "holidays", "journal", "limits", "loans", "maintenanceCharges", "notifications", "payees", "products", "reconciliations", "screeningCases", "standingOrders", "transactions", "users"}
+
+func printUsage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintln(out, "Usage: banking [--config FILE] [--db PATH] [--set key=value] <command> [flags]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-18s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Global flags:")
+	flags.PrintDefaults()
+}
+
+// fileCommands work on the database file without loading the app: migrate
+// because the file may still be in a legacy layout, the others so the
+// startup sweeps loading the app makes do not touch the data.
+var fileCommands = map[string]func(dbPath string, args []string) error{
+	"migrate": runMigrate,
+	"export":  runExport,
+	"import":  runImport,
+	"seed":    runSeed,
+}
+
+func runCommand(cfg config.Config, args []string) error {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != cmd.name {
+			continue
+		}
+		rest := args[len(words):]
+
+		if run, ok := fileCommands[cmd.name]; ok {
+			return run(cfg.Storage.Path, rest)
+		}
+
+		a, err := newApp(cfg)
+		if err != nil {
+			return err
+		}
+		return cmd.run(a, rest)
+	}
+
+	return fmt.Errorf("unknown command %q, run with --help for usage", strings.Join(args, " "))
+}
+
+func newFlags(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+func required(flags *flag.FlagSet, names ...string) error {
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	for _, name := range names {
+		if !set[name] {
+			return fmt.Errorf("%s: --%s is required", flags.Name(), name)
+		}
+	}
+	return nil
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+}
+
+func runUserCreate(a *app, args []string) error {
+	flags := newFlags("user create")
+	username := flags.String("username", "", "login name")
+	roleStr := flags.String("role", string(user.RoleCustomer), "bank or customer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "username"); err != nil {
+		return err
+	}
+
+	role, err := user.ParseRole(*roleStr)
+	if err != nil {
+		return err
+	}
+
+	// the password is never a flag, where other local users could see it
+	var password string
+	if a.con.Interactive() {
+		password, err = a.con.PromptNewSecret("Password: ", "Confirm password: ")
+	} else {
+		// scripts pipe the password in on the first line of stdin
+		password, err = a.con.Prompt("")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	u, err := a.users.Register(*username, password, role)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("User created: ID %d, username %s, role %s\n", u.ID, u.Username, u.Role)
+	return nil
+}
+
+// userView is a user without its password, for listing.
+type userView struct {
+	ID       int64     `json:"id"`
+	Username string    `json:"username"`
+	Role     user.Role `json:"role"`
+}
+
+func runUserList(a *app, args []string) error {
+	flags := newFlags("user list")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	users := []userView{}
+	for _, u := range a.users.GetAllUsers() {
+		users = append(users, userView{ID: u.ID, Username: u.Username, Role: u.Role})
+	}
+
+	if *asJSON {
+		return printJSON(users)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tUsername\tRole")
+	for _, u := range users {
+		fmt.Fprintf(t, "%d\t%s\t%s\n", u.ID, u.Username, u.Role)
+	}
+	return t.Flush()
+}
+
+func runBankCreate(a *app, args []string) error {
+	flags := newFlags("bank create")
+	userID := flags.Int64("user-id", 0, "ID of the bank operator user")
+	name := flags.String("name", "", "bank name")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "user-id", "name"); err != nil {
+		return err
+	}
+
+	u, err := a.users.GetUser(*userID)
+	if err != nil {
+		return fmt.Errorf("user %d: %w", *userID, err)
+	}
+	if u.Role != user.RoleBank {
+		return fmt.Errorf("user %d has role %s, a bank needs a %s user", u.ID, u.Role, user.RoleBank)
+	}
+
+	b, err := a.banks.CreateBank(u.ID, *name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank created: ID %d, name %s\n", b.ID, b.Name)
+	return nil
+}
+
+func runBankList(a *app, args []string) error {
+	flags := newFlags("bank list")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	banks := a.banks.GetAllBanks()
+	if *asJSON {
+		return printJSON(banks)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tUser ID\tName")
+	for _, b := range banks {
+		fmt.Fprintf(t, "%d\t%d\t%s\n", b.ID, b.UserID, b.Name)
+	}
+	return t.Flush()
+}
+
+func runBankUpdate(a *app, args []string) error {
+	flags := newFlags("bank update")
+	id := flags.Int64("id", 0, "bank ID")
+	name := flags.String("name", "", "new bank name")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "name"); err != nil {
+		return err
+	}
+
+	b, err := a.banks.UpdateBank(*id, *name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank updated: ID %d, name %s\n", b.ID, b.Name)
+	return nil
+}
+
+func runBankDelete(a *app, args []string) error {
+	flags := newFlags("bank delete")
+	id := flags.Int64("id", 0, "bank ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	if err := a.banks.DeleteBank(*id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank with ID %d deleted\n", *id)
+	return nil
+}
+
+func runCustomerCreate(a *app, args []string) error {
+	flags := newFlags("customer create")
+	userID := flags.Int64("user-id", 0, "ID of the customer's login user")
+	bankID := flags.Int64("bank-id", 0, "ID of the customer's bank")
+	name := flags.String("name", "", "customer name")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "user-id", "bank-id", "name"); err != nil {
+		return err
+	}
+
+	u, err := a.users.GetUser(*userID)
+	if err != nil {
+		return fmt.Errorf("user %d: %w", *userID, err)
+	}
+	if u.Role != user.RoleCustomer {
+		return fmt.Errorf("user %d has role %s, a customer needs a %s user", u.ID, u.Role, user.RoleCustomer)
+	}
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+
+	c, err := a.customers.CreateCustomer(u.ID, *bankID, *name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Customer created: ID %d, name %s, bank %d\n", c.ID, c.Name, c.BankID)
+	return nil
+}
+
+func runCustomerList(a *app, args []string) error {
+	flags := newFlags("customer list")
+	bankID := flags.Int64("bank-id", 0, "only list customers of this bank")
+	statusStr := flags.String("status", "", "only list customers with this verification status")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	customers := a.customers.GetAllCustomers()
+	if *bankID != 0 {
+		customers = a.customers.GetBankCustomers(*bankID)
+	}
+	if *statusStr != "" {
+		status, err := customer.ParseStatus(*statusStr)
+		if err != nil {
+			return err
+		}
+		filtered := []*customer.Customer{}
+		for _, c := range customers {
+			if c.Status == status {
+				filtered = append(filtered, c)
+			}
+		}
+		customers = filtered
+	}
+
+	if *asJSON {
+		return printJSON(customers)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tUser ID\tBank ID\tName\tStatus")
+	for _, c := range customers {
+		fmt.Fprintf(t, "%d\t%d\t%d\t%s\t%s\n", c.ID, c.UserID, c.BankID, c.Name, c.Status)
+	}
+	return t.Flush()
+}
+
+func runCustomerShow(a *app, args []string) error {
+	flags := newFlags("customer show")
+	id := flags.Int64("id", 0, "customer ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	c, err := a.customers.GetCustomer(*id)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(c)
+	}
+
+	fmt.Printf("Customer %d, %s, bank %d: %s\n", c.ID, c.Name, c.BankID, c.Status)
+	if d := c.Details; d != nil {
+		fmt.Printf("  Date of birth: %s\n", d.DateOfBirth.Format(time.DateOnly))
+		fmt.Printf("  Address:       %s\n", d.Address)
+		fmt.Printf("  Document:      %s %s (%s), expires %s\n", d.Document.Type, d.Document.Number, d.Document.Country, d.Document.ExpiresAt.Format(time.DateOnly))
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "\nAt\tStatus\tBy user\tNote")
+	for _, e := range c.History {
+		at := "-"
+		if !e.At.IsZero() {
+			at = e.At.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(t, "%s\t%s\t%d\t%s\n", at, e.Status, e.By, e.Note)
+	}
+	return t.Flush()
+}
+
+func runCustomerSubmit(a *app, args []string) error {
+	flags := newFlags("customer submit")
+	id := flags.Int64("id", 0, "customer ID")
+	name := flags.String("name", "", "full legal name")
+	dobStr := flags.String("dob", "", "date of birth")
+	address := flags.String("address", "", "home address")
+	docTypeStr := flags.String("document", "", "ID document type")
+	docNumber := flags.String("document-number", "", "ID document number")
+	country := flags.String("country", "", "two-letter code of the country that issued the document")
+	expiresStr := flags.String("expires", "", "expiry date of the document")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "name", "dob", "address", "document", "document-number", "country", "expires"); err != nil {
+		return err
+	}
+
+	dob, err := parseDate(*dobStr)
+	if err != nil {
+		return err
+	}
+	docType, err := customer.ParseDocumentType(*docTypeStr)
+	if err != nil {
+		return err
+	}
+	expires, err := parseDate(*expiresStr)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.customers.SubmitDetails(*id, customer.Details{
+		FullName:    *name,
+		DateOfBirth: dob,
+		Address:     *address,
+		Document: customer.Document{
+			Type:      docType,
+			Number:    *docNumber,
+			Country:   *country,
+			ExpiresAt: expires,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Details of customer %d submitted, waiting for review by bank %d\n", c.ID, c.BankID)
+	return nil
+}
+
+func runCustomerQueue(a *app, args []string) error {
+	flags := newFlags("customer queue")
+	bankID := flags.Int64("bank-id", 0, "bank reviewing the customers")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	queue := a.customers.GetReviewQueue(*bankID)
+	if *asJSON {
+		return printJSON(queue)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tName\tDate of birth\tDocument\tSubmitted")
+	for _, c := range queue {
+		d := c.Details
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s %s (%s)\t%s\n", c.ID, c.Name, d.DateOfBirth.Format(time.DateOnly),
+			d.Document.Type, d.Document.Number, d.Document.Country, c.LastEvent().At.Format("2006-01-02 15:04"))
+	}
+	return t.Flush()
+}
+
+func runCustomerApprove(a *app, args []string) error {
+	flags := newFlags("customer approve")
+	id := flags.Int64("id", 0, "customer ID")
+	note := flags.String("note", "", "note for the verification history")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	b, err := customerBank(a, *id)
+	if err != nil {
+		return err
+	}
+	c, err := a.customers.Approve(b.ID, *id, b.UserID, *note)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Customer %d, %s, verified\n", c.ID, c.Name)
+	return nil
+}
+
+func runCustomerReject(a *app, args []string) error {
+	flags := newFlags("customer reject")
+	id := flags.Int64("id", 0, "customer ID")
+	reason := flags.String("reason", "", "reason, shown to the customer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "reason"); err != nil {
+		return err
+	}
+
+	b, err := customerBank(a, *id)
+	if err != nil {
+		return err
+	}
+	c, err := a.customers.Reject(b.ID, *id, b.UserID, *reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Customer %d, %s, rejected: %s\n", c.ID, c.Name, *reason)
+	return nil
+}
+
+// customerBank is the bank of a customer. Reviews from the command line are
+// recorded as made by the bank's operator.
+func customerBank(a *app, customerID int64) (*bank.Bank, error) {
+	c, err := a.customers.GetCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	return a.banks.GetBank(c.BankID)
+}
+
+func runAccountOpen(a *app, args []string) error {
+	flags := newFlags("account open")
+	customerID := flags.Int64("customer-id", 0, "account holder")
+	typeStr := flags.String("type", string(account.TypeChecking), "checking or savings")
+	productID := flags.Int64("product-id", 0, "savings product, required for savings accounts")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id"); err != nil {
+		return err
+	}
+
+	accountType, err := account.ParseType(*typeStr)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.customers.GetCustomer(*customerID)
+	if err != nil {
+		return err
+	}
+
+	if *productID != 0 {
+		product, err := a.interest.GetProduct(*productID)
+		if err != nil {
+			return err
+		}
+		if product.BankID != c.BankID {
+			return fmt.Errorf("product %d belongs to bank %d, not the customer's bank %d", product.ID, product.BankID, c.BankID)
+		}
+	}
+
+	acc, err := a.accounts.OpenAccount(c.BankID, c.ID, accountType, *productID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Account opened: ID %d, number %s, %s account for %s at bank %d\n", acc.ID, accountno.Group(acc.Number), acc.Type, c.Name, acc.BankID)
+	return nil
+}
+
+func runAccountList(a *app, args []string) error {
+	flags := newFlags("account list")
+	customerID := flags.Int64("customer-id", 0, "only list accounts of this customer")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	accounts := a.accounts.GetAllAccounts()
+	if *customerID != 0 {
+		accounts = a.accounts.GetCustomerAccounts(*customerID)
+	}
+
+	if *asJSON {
+		return printJSON(accounts)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tNumber\tBank ID\tCustomer ID\tType\tBalance\tOverdraft\tHeld\tAvailable")
+	for _, acc := range accounts {
+		fmt.Fprintf(t, "%d\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n", acc.ID, accountno.Group(acc.Number), acc.BankID, acc.CustomerID, acc.Type,
+			money.Format(acc.Balance), money.Format(acc.OverdraftLimit), money.Format(acc.Held), money.Format(acc.Available()))
+	}
+	return t.Flush()
+}
+
+func runAccountOverdraft(a *app, args []string) error {
+	flags := newFlags("account overdraft")
+	id := flags.Int64("id", 0, "account ID")
+	limitStr := flags.String("limit", "", "how far the balance may go below zero, 0 for none")
+	rateStr := flags.String("rate", "0", "annual interest on a negative balance in percent, e.g. 12.5")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "limit"); err != nil {
+		return err
+	}
+
+	limit, err := money.Parse(*limitStr)
+	if err != nil {
+		return err
+	}
+	// a percentage with two decimals is a whole number of basis points
+	rateBps, err := money.Parse(*rateStr)
+	if err != nil {
+		return fmt.Errorf("invalid rate: %w", err)
+	}
+
+	acc, err := a.accounts.SetOverdraft(*id, limit, rateBps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Account %d can now go %s below zero at %s%% a year\n", acc.ID, money.Format(acc.OverdraftLimit), money.Format(acc.OverdraftRateBps))
+	return nil
+}
+
+func runAccountDeposit(a *app, args []string) error {
+	flags := newFlags("account deposit")
+	id := flags.Int64("id", 0, "account ID")
+	amountStr := flags.String("amount", "", "amount, e.g. 12.50")
+	memo := flags.String("memo", "", "note stored with the transaction")
+	dateStr := flags.String("date", "", "business day to post into, YYYY-MM-DD (default the bank's business date)")
+	adjustment := flags.Bool("adjustment", false, "allow --date to be a closed day")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "amount"); err != nil {
+		return err
+	}
+
+	amount, err := money.Parse(*amountStr)
+	if err != nil {
+		return err
+	}
+	txs, err := postingService(a, *id, *dateStr, *adjustment)
+	if err != nil {
+		return err
+	}
+
+	tx, err := txs.Deposit(*id, amount, *memo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deposited %s into account %d (transaction %d%s)\n", money.Format(tx.Amount), *id, tx.Id, heldNote(tx))
+	return nil
+}
+
+func runAccountWithdraw(a *app, args []string) error {
+	flags := newFlags("account withdraw")
+	id := flags.Int64("id", 0, "account ID")
+	amountStr := flags.String("amount", "", "amount, e.g. 12.50")
+	memo := flags.String("memo", "", "note stored with the transaction")
+	yes := flags.Bool("yes", false, "do not ask to confirm the fee")
+	dateStr := flags.String("date", "", "business day to post into, YYYY-MM-DD (default the bank's business date)")
+	adjustment := flags.Bool("adjustment", false, "allow --date to be a closed day")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "amount"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	amount, err := money.Parse(*amountStr)
+	if err != nil {
+		return err
+	}
+	txs, err := postingService(a, *id, *dateStr, *adjustment)
+	if err != nil {
+		return err
+	}
+
+	charge, err := txs.QuoteWithdrawal(*id, amount)
+	if err != nil {
+		return err
+	}
+	if ok, err := confirmFee(a, charge, *yes); err != nil || !ok {
+		return err
+	}
+
+	tx, err := txs.Withdraw(*id, amount, *memo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Withdrew %s from account %d (transaction %d%s)\n", money.Format(tx.Amount), *id, tx.Id, heldNote(tx))
+	return nil
+}
+
+func runAccountHistory(a *app, args []string) error {
+	flags := newFlags("account history")
+	id := flags.Int64("id", 0, "account ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	acc, err := a.accounts.GetAccount(*id)
+	if err != nil {
+		return err
+	}
+
+	txs := a.transactions.GetAccountTransactions(*id)
+	if *asJSON {
+		return printJSON(txs)
+	}
+
+	fmt.Printf("Ledger balance %s, held %s, available %s\n", money.Format(acc.Balance), money.Format(acc.Held), money.Format(acc.Available()))
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tDate\tType\tPayer\tPayee\tAmount\tMemo")
+	for _, tx := range txs {
+		amount := tx.Amount
+		if tx.FromAccountID == *id {
+			amount = -amount
+		}
+		kind := string(tx.Type)
+		if tx.Status != "" {
+			kind += " (" + string(tx.Status) + ")"
+		}
+		if tx.Adjustment {
+			kind += " (adjustment to " + tx.BusinessDate + ")"
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", tx.Id, tx.CreatedAt.Format("2006-01-02 15:04"), kind, tx.Payer, tx.Payee, money.Format(amount), tx.Memo)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	// holds are not in the ledger yet, so they are listed apart
+	holds := a.holds.GetAccountHolds(*id, true)
+	if len(holds) == 0 {
+		return nil
+	}
+	fmt.Println("\nPending holds:")
+	t = newTable()
+	fmt.Fprintln(t, "Hold\tPlaced\tExpires\tReference\tAmount")
+	for _, h := range holds {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\n", h.ID, h.CreatedAt.Format("2006-01-02 15:04"), holdExpiry(h), h.Reference, money.Format(-h.Amount))
+	}
+	return t.Flush()
+}
+
+// heldNote tells that a transaction was held for review.
+func heldNote(tx *transactions.Transaction) string {
+	if tx.Status == transactions.StatusHeld {
+		return ", held for review"
+	}
+	return ""
+}
+
+func runTransfer(a *app, args []string) error {
+	flags := newFlags("transfer")
+	fromNumber := flags.String("from", "", "source account number")
+	toNumber := flags.String("to", "", "destination account number")
+	payeeID := flags.Int64("payee", 0, "saved payee of the account holder, instead of --to")
+	amountStr := flags.String("amount", "", "amount, e.g. 12.50")
+	memo := flags.String("memo", "", "note stored with the transaction")
+	yes := flags.Bool("yes", false, "do not ask to confirm the fee")
+	dateStr := flags.String("date", "", "business day to post into, YYYY-MM-DD (default the bank's business date)")
+	adjustment := flags.Bool("adjustment", false, "allow --date to be a closed day")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "from", "amount"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+	if (*toNumber == "") == (*payeeID == 0) {
+		return fmt.Errorf("give either --to or --payee")
+	}
+	if *dateStr != "" && *payeeID != 0 {
+		return fmt.Errorf("--date cannot be used with --payee, payee transfers are made on the business date")
+	}
+
+	amount, err := money.Parse(*amountStr)
+	if err != nil {
+		return err
+	}
+
+	from, err := a.accounts.GetAccountByNumber(*fromNumber)
+	if err != nil {
+		return err
+	}
+	txs, err := postingService(a, from.ID, *dateStr, *adjustment)
+	if err != nil {
+		return err
+	}
+
+	charge, err := txs.QuoteTransfer(from.ID, amount)
+	if err != nil {
+		return err
+	}
+	if ok, err := confirmFee(a, charge, *yes); err != nil || !ok {
+		return err
+	}
+
+	if *payeeID != 0 {
+		tx, err := a.payees.Transfer(from.CustomerID, from.ID, *payeeID, amount, *memo)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Transferred %s from account %s to %s (transaction %d%s)\n", money.Format(tx.Amount), accountno.Group(from.Number), tx.Payee, tx.Id, heldNote(tx))
+		return nil
+	}
+
+	to, err := a.accounts.GetAccountByNumber(*toNumber)
+	if err != nil {
+		return err
+	}
+
+	tx, err := txs.Transfer(from.ID, to.ID, amount, *memo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Transferred %s from account %s to account %s (transaction %d%s)\n", money.Format(tx.Amount),
+		accountno.Group(from.Number), accountno.Group(to.Number), tx.Id, heldNote(tx))
+	return nil
+}
+
+func runPayeeAdd(a *app, args []string) error {
+	flags := newFlags("payee add")
+	customerID := flags.Int64("customer-id", 0, "customer saving the payee")
+	name := flags.String("name", "", "account holder's name")
+	nickname := flags.String("nickname", "", "short name shown instead")
+	number := flags.String("account", "", "payee's account number")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id", "name", "account"); err != nil {
+		return err
+	}
+
+	if _, err := a.customers.GetCustomer(*customerID); err != nil {
+		return err
+	}
+
+	p, err := a.payees.AddPayee(*customerID, *name, *nickname, *number)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Payee saved: ID %d, %s, account %s at bank %d\n", p.ID, p.Label(), accountno.Group(p.AccountNumber()), p.BankID)
+	return nil
+}
+
+func runPayeeList(a *app, args []string) error {
+	flags := newFlags("payee list")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id"); err != nil {
+		return err
+	}
+
+	payees := a.payees.GetCustomerPayees(*customerID)
+	if *asJSON {
+		return printJSON(payees)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tName\tNickname\tBank ID\tAccount\tAdded")
+	for _, p := range payees {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%d\t%s\t%s\n", p.ID, p.Name, p.Nickname, p.BankID, accountno.Group(p.AccountNumber()), p.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	return t.Flush()
+}
+
+func runPayeeRemove(a *app, args []string) error {
+	flags := newFlags("payee remove")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	id := flags.Int64("id", 0, "payee ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id", "id"); err != nil {
+		return err
+	}
+
+	if err := a.payees.RemovePayee(*customerID, *id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Payee %d removed\n", *id)
+	return nil
+}
+
+// confirmFee shows the fee a transaction will cost and, when a person is at
+// the terminal, asks whether to go ahead. Scripts and --yes skip the question.
+func confirmFee(a *app, charge int64, yes bool) (bool, error) {
+	if charge == 0 {
+		return true, nil
+	}
+
+	fmt.Printf("This will cost a fee of %s.\n", money.Format(charge))
+	if yes || !a.con.Interactive() {
+		return true, nil
+	}
+
+	answer, err := a.con.Prompt("Proceed? [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	if answer := strings.ToLower(answer); answer != "y" && answer != "yes" {
+		fmt.Println("Cancelled.")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func runNotificationList(a *app, args []string) error {
+	flags := newFlags("notification list")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	unread := flags.Bool("unread", false, "only list notifications the customer has not read")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id"); err != nil {
+		return err
+	}
+
+	notifications := a.notifications.GetCustomerNotifications(*customerID, *unread)
+	if *asJSON {
+		return printJSON(notifications)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tDate\tRead\tMessage")
+	for _, n := range notifications {
+		fmt.Fprintf(t, "%d\t%s\t%t\t%s\n", n.ID, n.CreatedAt.Format("2006-01-02 15:04"), n.Read, n.Message)
+	}
+	return t.Flush()
+}
+
+func runServe(a *app, args []string) error {
+	flags := newFlags("serve")
+	addr := flags.String("addr", a.cfg.Server.Addr(), "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server := api.NewServer(a.banks, a.customers, a.accounts, a.transactions, a.holds)
+
+	fmt.Printf("Serving %s on %s\n", a.cfg.Storage.Path, *addr)
+	return http.ListenAndServe(*addr, server.Routes())
+}
+
+func runMigrate(dbPath string, args []string) error {
+	flags := newFlags("migrate")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	added, err := storage.Migrate(dbPath, collections)
+	if err != nil {
+		return err
+	}
+
+	if len(added) == 0 {
+		fmt.Printf("%s is up to date\n", dbPath)
+		return nil
+	}
+
+	fmt.Printf("Migrated %s, added: %s\n", dbPath, strings.Join(added, ", "))
+	return nil
+}
+
+func runConfigShow(a *app, args []string) error {
+	flags := newFlags("config show")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(a.cfg)
+	}
+
+	c := a.cfg
+	t := newTable()
+	fmt.Fprintln(t, "Setting\tValue\tEnvironment")
+	for _, row := range [][2]string{
+		{"storage.path", c.Storage.Path},
+		{"server.host", c.Server.Host},
+		{"server.port", fmt.Sprint(c.Server.Port)},
+		{"password.min_length", fmt.Sprint(c.Password.MinLength)},
+		{"password.require_digit", fmt.Sprint(c.Password.RequireDigit)},
+		{"password.require_upper", fmt.Sprint(c.Password.RequireUpper)},
+		{"bank.name_min_length", fmt.Sprint(c.Bank.MinLength)},
+		{"bank.name_max_length", fmt.Sprint(c.Bank.MaxLength)},
+		{"limits.max_deposit", money.Format(c.Limits.MaxDeposit)},
+		{"limits.max_withdrawal", money.Format(c.Limits.MaxWithdrawal)},
+		{"limits.max_transfer", money.Format(c.Limits.MaxTransfer)},
+		{"fees.transfer", money.Format(c.Fees.Transfer)},
+		{"fees.withdrawal", money.Format(c.Fees.Withdrawal)},
+		{"fees.maintenance", money.Format(c.Fees.Maintenance)},
+		{"fees.overdraft", money.Format(c.Fees.Overdraft)},
+		{"fees.late_payment", money.Format(c.Fees.LatePayment)},
+		{"payees.cooling_off", c.Payees.CoolingOff.String()},
+		{"payees.large_transfer", money.Format(c.Payees.LargeTransfer)},
+		{"aml.large_cash", money.Format(c.AML.LargeCash.Threshold)},
+		{"aml.large_cash_hold", fmt.Sprint(c.AML.LargeCash.Hold)},
+		{"aml.structuring_margin", money.Format(c.AML.Structuring.Margin)},
+		{"aml.structuring_count", fmt.Sprint(c.AML.Structuring.Count)},
+		{"aml.structuring_window", c.AML.Structuring.Window.String()},
+		{"aml.structuring_hold", fmt.Sprint(c.AML.Structuring.Hold)},
+		{"aml.rapid_movement_min", money.Format(c.AML.RapidMovement.Min)},
+		{"aml.rapid_movement_share", money.Format(c.AML.RapidMovement.ShareBps) + "%"},
+		{"aml.rapid_movement_window", c.AML.RapidMovement.Window.String()},
+		{"aml.rapid_movement_hold", fmt.Sprint(c.AML.RapidMovement.Hold)},
+		{"aml.new_payees_count", fmt.Sprint(c.AML.NewPayees.Count)},
+		{"aml.new_payees_window", c.AML.NewPayees.Window.String()},
+		{"aml.new_payees_hold", fmt.Sprint(c.AML.NewPayees.Hold)},
+		{"sanctions.watchlist", c.Sanctions.Watchlist},
+		{"sanctions.threshold", money.Format(c.Sanctions.ThresholdBps) + "%"},
+		{"holds.expiry", c.Holds.Expiry.String()},
+		{"statements.currency", c.Statements.Currency},
+	} {
+		fmt.Fprintf(t, "%s\t%s\t%s\n", row[0], row[1], config.EnvName(row[0]))
+	}
+	return t.Flush()
+}
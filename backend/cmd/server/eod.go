@@ -0,0 +1,171 @@
+package main
+
+import (
+	"banking-app/backend/internal/eod"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+func runEODStatus(a *app, args []string) error {
+	flags := newFlags("eod status")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Business date: %s\n", a.days.BusinessDate(*bankID).Format(time.DateOnly))
+	closing, err := a.days.Closing(*bankID)
+	if err != nil {
+		return err
+	}
+	if closing != nil {
+		fmt.Printf("End of day running since %s, postings are frozen\n", closing.StartedAt.Format("2006-01-02 15:04"))
+	}
+	days := a.days.GetBankDays(*bankID)
+	if len(days) == 0 {
+		fmt.Println("No day has been closed yet")
+		return nil
+	}
+	last := days[len(days)-1]
+	fmt.Printf("Last closed: %s, at %s\n", last.Date, last.ClosedAt.Format("2006-01-02 15:04"))
+	return nil
+}
+
+func runEODClose(a *app, args []string) error {
+	flags := newFlags("eod close")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	// the close is recorded as made by the bank's operator
+	b, err := a.banks.GetBank(*bankID)
+	if err != nil {
+		return err
+	}
+	day, err := a.days.Close(b.ID, b.UserID)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(day)
+	}
+
+	fmt.Printf("Closed %s for bank %d, the business date is now %s\n\n", day.Date, day.BankID, day.NextDate)
+	t := newTable()
+	fmt.Fprintln(t, "Step\tDone\tSkipped\tNote")
+	for _, step := range day.Steps {
+		fmt.Fprintf(t, "%s\t%d\t%d\t%s\n", step.Name, step.Done, step.Skipped, step.Note)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return printTrialBalance(day.TrialBalance)
+}
+
+func runEODHistory(a *app, args []string) error {
+	flags := newFlags("eod history")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	days := a.days.GetBankDays(*bankID)
+	if *asJSON {
+		return printJSON(days)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Date\tNext date\tClosed at\tDebit\tCredit\tBalanced")
+	for _, d := range days {
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%t\n", d.Date, d.NextDate, d.ClosedAt.Format("2006-01-02 15:04"),
+			money.Format(d.TrialBalance.Debit), money.Format(d.TrialBalance.Credit), d.TrialBalance.Balanced())
+	}
+	return t.Flush()
+}
+
+func runEODTrialBalance(a *app, args []string) error {
+	flags := newFlags("eod trial-balance")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	tb := a.days.TrialBalance(*bankID)
+	if *asJSON {
+		return printJSON(tb)
+	}
+	return printTrialBalance(tb)
+}
+
+func printTrialBalance(tb eod.TrialBalance) error {
+	t := newTable()
+	fmt.Fprintln(t, "Account type\tAccounts\tBalance\tLedger\tDifference")
+	for _, l := range tb.Lines {
+		fmt.Fprintf(t, "%s\t%d\t%s\t%s\t%s\n", l.Type, l.Accounts, money.Format(l.Balance), money.Format(l.Ledger),
+			money.Format(l.Difference()))
+	}
+	fmt.Fprintf(t, "Total\t\t%s\t%s\t\n", money.Format(tb.Balance), money.Format(tb.Ledger))
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nGeneral ledger: debits %s, credits %s\n", money.Format(tb.Debit), money.Format(tb.Credit))
+	if !tb.Balanced() {
+		fmt.Println("⚠️ The balances do not agree with the ledger")
+	}
+	return nil
+}
+
+// postingService is the transactions service posting into the business day
+// given with --date for the bank of an account, the bank's business date
+// when it is empty.
+func postingService(a *app, accountID int64, dateStr string, adjustment bool) (*transactions.Service, error) {
+	if dateStr == "" {
+		if adjustment {
+			return nil, fmt.Errorf("--adjustment needs --date")
+		}
+		return a.transactions, nil
+	}
+
+	day, err := parseDate(dateStr)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := a.accounts.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	return a.transactions.On(acc.BankID, day, adjustment)
+}
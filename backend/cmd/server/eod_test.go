@@ -0,0 +1,64 @@
+package main
+
+import (
+	"banking-app/backend/internal/eod"
+	"banking-app/backend/internal/transactions"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestFreezeStopsPostingsOfOtherProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	a := newTestAppAt(t, path)
+	acc := openTestAccount(t, a)
+
+	// the end of day runs in a process of its own
+	closingRepo, err := eod.NewRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thaw, err := eod.NewDates(closingRepo, nil).Freeze(acc.BankID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the app loaded before the freeze and one loaded during it both see it
+	for name, other := range map[string]*app{"loaded before": a, "loaded during": newTestAppAt(t, path)} {
+		if _, err := other.transactions.Deposit(acc.ID, 1000, "frozen"); !errors.Is(err, transactions.ErrDayFrozen) {
+			t.Errorf("%s: deposit during end of day = %v, want %v", name, err, transactions.ErrDayFrozen)
+		}
+	}
+	if _, err := eod.NewDates(closingRepo, nil).Freeze(acc.BankID); err == nil {
+		t.Error("a second end of day could freeze the bank again")
+	}
+
+	if err := thaw(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.transactions.Deposit(acc.ID, 1000, "thawed"); err != nil {
+		t.Errorf("deposit after end of day: %v", err)
+	}
+}
+
+func TestCloseThawsTheBank(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	a := newTestAppAt(t, path)
+	acc := openTestAccount(t, a)
+	b, err := a.banks.GetBank(acc.BankID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.days.Close(b.ID, b.UserID); err != nil {
+		t.Fatal(err)
+	}
+
+	other := newTestAppAt(t, path)
+	if closing, err := other.days.Closing(b.ID); err != nil || closing != nil {
+		t.Fatalf("closing after end of day = %+v, %v, want none", closing, err)
+	}
+	if _, err := other.transactions.Deposit(acc.ID, 1000, "next day"); err != nil {
+		t.Errorf("deposit after end of day: %v", err)
+	}
+}
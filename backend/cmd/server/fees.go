@@ -0,0 +1,169 @@
+package main
+
+import (
+	"banking-app/backend/internal/fee"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+func runFeeSet(a *app, args []string) error {
+	flags := newFlags("fee set")
+	bankID := flags.Int64("bank-id", 0, "bank charging the fee")
+	typeStr := flags.String("type", "", "transfer, withdrawal, maintenance, overdraft or late-payment")
+	flatStr := flags.String("flat", "0", "flat amount")
+	percentStr := flags.String("percent", "0", "percentage of the transaction amount, e.g. 0.5")
+	minStr := flags.String("min", "0", "minimum fee")
+	maxStr := flags.String("max", "0", "maximum fee, 0 for no maximum")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id", "type"); err != nil {
+		return err
+	}
+
+	feeType, err := fee.ParseType(*typeStr)
+	if err != nil {
+		return err
+	}
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+
+	rule := fee.Rule{BankID: *bankID, Type: feeType}
+	for _, f := range []struct {
+		name  string
+		value string
+		dest  *int64
+	}{
+		{"flat", *flatStr, &rule.Flat},
+		{"percent", *percentStr, &rule.PercentBps},
+		{"min", *minStr, &rule.Min},
+		{"max", *maxStr, &rule.Max},
+	} {
+		// two decimal places, so percentages come out in basis points
+		v, err := money.Parse(f.value)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", f.name, err)
+		}
+		*f.dest = v
+	}
+
+	saved, err := a.fees.SetRule(rule)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank %d now charges %s for %s\n", saved.BankID, describeRule(*saved), saved.Type)
+	return nil
+}
+
+func runFeeRemove(a *app, args []string) error {
+	flags := newFlags("fee remove")
+	bankID := flags.Int64("bank-id", 0, "bank charging the fee")
+	typeStr := flags.String("type", "", "transfer, withdrawal, maintenance, overdraft or late-payment")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id", "type"); err != nil {
+		return err
+	}
+
+	feeType, err := fee.ParseType(*typeStr)
+	if err != nil {
+		return err
+	}
+
+	if err := a.fees.RemoveRule(*bankID, feeType); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank %d uses the default %s fee again\n", *bankID, feeType)
+	return nil
+}
+
+func runFeeList(a *app, args []string) error {
+	flags := newFlags("fee list")
+	bankID := flags.Int64("bank-id", 0, "bank to show the fee schedule of")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	schedule := a.fees.Schedule(*bankID)
+	if *asJSON {
+		return printJSON(schedule)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Type\tFee\tSource")
+	for _, rule := range schedule {
+		source := "bank"
+		if rule.ID == 0 {
+			source = "default"
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\n", rule.Type, describeRule(rule), source)
+	}
+	return t.Flush()
+}
+
+func runFeeChargeMaintenance(a *app, args []string) error {
+	flags := newFlags("fee charge-maintenance")
+	month := flags.String("month", time.Now().Format("2006-01"), "month to bill, YYYY-MM")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	results, err := a.transactions.ChargeMaintenance(0, *month)
+	if err != nil && len(results) == 0 {
+		return err
+	}
+
+	if *asJSON {
+		if jsonErr := printJSON(results); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Account\tFee\tResult")
+	for _, r := range results {
+		result := "charged"
+		if !r.Charged {
+			result = "skipped: " + r.Reason
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\n", r.AccountID, money.Format(r.Fee), result)
+	}
+	if flushErr := t.Flush(); flushErr != nil {
+		return flushErr
+	}
+	return err
+}
+
+// describeRule renders a rule such as "1.00 + 0.50%, min 0.50, max 10.00".
+func describeRule(r fee.Rule) string {
+	desc := money.Format(r.Flat)
+	if r.PercentBps > 0 {
+		if r.Flat > 0 {
+			desc += " + " + money.Format(r.PercentBps) + "%"
+		} else {
+			desc = money.Format(r.PercentBps) + "%"
+		}
+	}
+	if r.Min > 0 {
+		desc += ", min " + money.Format(r.Min)
+	}
+	if r.Max > 0 {
+		desc += ", max " + money.Format(r.Max)
+	}
+	return desc
+}
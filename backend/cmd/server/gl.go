@@ -0,0 +1,213 @@
+package main
+
+import (
+	"banking-app/backend/internal/gl"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func runGLChart(a *app, args []string) error {
+	flags := newFlags("gl chart")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	chart := a.ledger.GetChart(*bankID)
+	if *asJSON {
+		return printJSON(chart)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Code\tName\tCategory")
+	for _, acc := range chart {
+		fmt.Fprintf(t, "%s\t%s\t%s\n", acc.Code, acc.Name, acc.Category)
+	}
+	return t.Flush()
+}
+
+func runGLJournal(a *app, args []string) error {
+	flags := newFlags("gl journal")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	entries := a.ledger.GetJournal(*bankID)
+	if *asJSON {
+		return printJSON(entries)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Entry\tDate\tTransaction\tAccount\tDebit\tCredit\tMemo")
+	for _, e := range entries {
+		for i, l := range e.Lines {
+			if i == 0 {
+				fmt.Fprintf(t, "%d\t%s\t%s\t", e.ID, e.BusinessDate, transactionRef(e.TransactionID))
+			} else {
+				fmt.Fprint(t, "\t\t\t")
+			}
+			fmt.Fprintf(t, "%s\t%s\t%s\t", l.Code, amountOrBlank(l.Debit), amountOrBlank(l.Credit))
+			if i == 0 {
+				fmt.Fprint(t, e.Memo)
+			}
+			fmt.Fprintln(t)
+		}
+	}
+	return t.Flush()
+}
+
+func runGLTrialBalance(a *app, args []string) error {
+	flags := newFlags("gl trial-balance")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	tb := a.ledger.TrialBalance(*bankID)
+	if *asJSON {
+		return printJSON(tb)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Code\tAccount\tDebit\tCredit")
+	for _, b := range tb.Accounts {
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\n", b.Code, b.Name, money.Format(b.Debit), money.Format(b.Credit))
+	}
+	fmt.Fprintf(t, "\tTotal\t%s\t%s\n", money.Format(tb.Debit), money.Format(tb.Credit))
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	if !tb.Balanced() {
+		fmt.Println("⚠️ Debits and credits do not agree")
+	}
+	return nil
+}
+
+func runGLBalanceSheet(a *app, args []string) error {
+	flags := newFlags("gl balance-sheet")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	bs := a.ledger.BalanceSheet(*bankID)
+	if *asJSON {
+		return printJSON(bs)
+	}
+	return printBalanceSheet(bs)
+}
+
+func runGLProfitAndLoss(a *app, args []string) error {
+	flags := newFlags("gl pnl")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	from := flags.String("from", "", "first business day (YYYY-MM-DD)")
+	to := flags.String("to", "", "last business day (YYYY-MM-DD)")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	pl, err := a.ledger.ProfitAndLoss(*bankID, *from, *to)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(pl)
+	}
+	return printProfitAndLoss(pl)
+}
+
+func printBalanceSheet(bs gl.BalanceSheet) error {
+	t := newTable()
+	printGLSection(t, "Assets", bs.Assets, bs.TotalAssets)
+	printGLSection(t, "Liabilities", bs.Liabilities, bs.TotalLiabilities)
+	printGLSection(t, "Equity", bs.Equity, bs.TotalEquity)
+	fmt.Fprintf(t, "\tCurrent earnings\t%s\n", money.Format(bs.Earnings))
+	fmt.Fprintf(t, "\tTotal liabilities and equity\t%s\n", money.Format(bs.TotalLiabilities+bs.TotalEquity+bs.Earnings))
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	if bs.TotalAssets != bs.TotalLiabilities+bs.TotalEquity+bs.Earnings {
+		fmt.Println("⚠️ Assets do not equal liabilities and equity")
+	}
+	return nil
+}
+
+func printProfitAndLoss(pl gl.ProfitAndLoss) error {
+	if pl.From != "" || pl.To != "" {
+		fmt.Printf("Business days %s to %s\n\n", orOpen(pl.From), orOpen(pl.To))
+	}
+	t := newTable()
+	printGLSection(t, "Income", pl.Income, pl.TotalIncome)
+	printGLSection(t, "Expenses", pl.Expenses, pl.TotalExpenses)
+	fmt.Fprintf(t, "\tNet profit\t%s\n", money.Format(pl.NetProfit))
+	return t.Flush()
+}
+
+func printGLSection(w io.Writer, title string, balances []gl.Balance, total int64) {
+	fmt.Fprintf(w, "%s\t\t\n", title)
+	for _, b := range balances {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", b.Code, b.Name, money.Format(b.Net()))
+	}
+	fmt.Fprintf(w, "\tTotal %s\t%s\n\t\t\n", strings.ToLower(title), money.Format(total))
+}
+
+func orOpen(date string) string {
+	if date == "" {
+		return "…"
+	}
+	return date
+}
+
+func transactionRef(id int64) string {
+	if id == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+func amountOrBlank(cents int64) string {
+	if cents == 0 {
+		return ""
+	}
+	return money.Format(cents)
+}
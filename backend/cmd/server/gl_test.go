@@ -0,0 +1,36 @@
+package main
+
+import (
+	"banking-app/backend/internal/gl"
+	"testing"
+)
+
+func TestTransferBetweenBanksJournalsBothBanks(t *testing.T) {
+	a := newTestApp(t)
+	payee := openTestAccount(t, a)
+	payer := openTestAccount(t, a)
+	if _, err := a.transactions.Deposit(payer.ID, 10000, "salary"); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := a.transactions.Transfer(payer.ID, payee.ID, 2500, "rent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the entries are saved together, the lower bank ID first
+	var entries []*gl.Entry
+	for _, bankID := range []int64{payee.BankID, payer.BankID} {
+		for _, e := range a.ledger.GetJournal(bankID) {
+			if e.TransactionID == tx.Id {
+				entries = append(entries, e)
+			}
+		}
+	}
+	if len(entries) != 2 {
+		t.Fatalf("transfer %d has %d journal entries, want one per bank", tx.Id, len(entries))
+	}
+	if entries[1].ID != entries[0].ID+1 {
+		t.Errorf("entry of bank %d is %d, want it right after %d of bank %d", payer.BankID, entries[1].ID, entries[0].ID, payee.BankID)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/pkg/money"
+	"fmt"
+)
+
+func runHoldList(a *app, args []string) error {
+	flags := newFlags("hold list")
+	accountID := flags.Int64("account-id", 0, "account whose holds to list")
+	all := flags.Bool("all", false, "include settled holds")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "account-id"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	if _, err := a.accounts.GetAccount(*accountID); err != nil {
+		return err
+	}
+	holds := a.holds.GetAccountHolds(*accountID, !*all)
+	if *asJSON {
+		return printJSON(holds)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tPlaced\tExpires\tReference\tAmount\tStatus")
+	for _, h := range holds {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\n", h.ID, h.CreatedAt.Format("2006-01-02 15:04"), holdExpiry(h), h.Reference,
+			money.Format(h.Amount), h.Status)
+	}
+	return t.Flush()
+}
+
+func holdExpiry(h *hold.Hold) string {
+	if h.ExpiresAt == nil {
+		return "never"
+	}
+	return h.ExpiresAt.Format("2006-01-02 15:04")
+}
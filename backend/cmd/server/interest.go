@@ -0,0 +1,147 @@
+package main
+
+import (
+	"banking-app/backend/internal/interest"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+func runProductCreate(a *app, args []string) error {
+	flags := newFlags("product create")
+	bankID := flags.Int64("bank-id", 0, "bank offering the product")
+	name := flags.String("name", "", "product name")
+	rateStr := flags.String("rate", "", "annual interest rate in percent, e.g. 2.5")
+	dayCountStr := flags.String("day-count", string(interest.Actual365), "actual/365 or 30/360")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id", "name", "rate"); err != nil {
+		return err
+	}
+
+	// a percentage with two decimals is a whole number of basis points
+	rateBps, err := money.Parse(*rateStr)
+	if err != nil {
+		return fmt.Errorf("invalid rate: %w", err)
+	}
+
+	dayCount, err := interest.ParseDayCount(*dayCountStr)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+
+	p, err := a.interest.CreateProduct(*bankID, *name, rateBps, dayCount)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Product created: ID %d, %s at %s%% (%s)\n", p.ID, p.Name, money.Format(p.RateBps), p.DayCount)
+	return nil
+}
+
+func runProductList(a *app, args []string) error {
+	flags := newFlags("product list")
+	bankID := flags.Int64("bank-id", 0, "only list products of this bank")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	products := a.interest.GetAllProducts()
+	if *bankID != 0 {
+		products = a.interest.GetBankProducts(*bankID)
+	}
+
+	if *asJSON {
+		return printJSON(products)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tBank ID\tName\tRate\tDay count")
+	for _, p := range products {
+		fmt.Fprintf(t, "%d\t%d\t%s\t%s%%\t%s\n", p.ID, p.BankID, p.Name, money.Format(p.RateBps), p.DayCount)
+	}
+	return t.Flush()
+}
+
+func runInterestAccrue(a *app, args []string) error {
+	flags := newFlags("interest accrue")
+	dryRun := flags.Bool("dry-run", false, "show what would be accrued without saving")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	return accrueInterest(a, yesterday, *dryRun, *asJSON)
+}
+
+func runInterestBackfill(a *app, args []string) error {
+	flags := newFlags("interest backfill")
+	throughStr := flags.String("through", "", "last day to accrue, YYYY-MM-DD")
+	dryRun := flags.Bool("dry-run", false, "show the missed days without saving")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "through"); err != nil {
+		return err
+	}
+
+	through, err := parseDate(*throughStr)
+	if err != nil {
+		return err
+	}
+
+	return accrueInterest(a, through, *dryRun, *asJSON)
+}
+
+func accrueInterest(a *app, through time.Time, dryRun, asJSON bool) error {
+	results, err := a.interest.Accrue(through, dryRun)
+	if err != nil && len(results) == 0 {
+		return err
+	}
+	if asJSON {
+		if jsonErr := printJSON(results); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Account\tDays\tFrom\tThrough\tAccrued\tCapitalised\tPending\tOverdraft accrued\tOverdraft charged\tOverdraft pending")
+	for _, r := range results {
+		if r.Skipped != "" {
+			fmt.Fprintf(t, "%d\tskipped: %s\n", r.AccountID, r.Skipped)
+			continue
+		}
+		if r.Days == 0 {
+			fmt.Fprintf(t, "%d\t0\tup to date\n", r.AccountID)
+			continue
+		}
+		fmt.Fprintf(t, "%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.AccountID, r.Days,
+			r.From.Format(time.DateOnly), r.Through.Format(time.DateOnly),
+			formatMicros(r.Accrued), money.Format(r.Capitalised), formatMicros(r.Pending),
+			formatMicros(r.OverdraftAccrued), money.Format(r.OverdraftCharged), formatMicros(r.OverdraftPending))
+	}
+	if flushErr := t.Flush(); flushErr != nil {
+		return flushErr
+	}
+
+	if dryRun {
+		fmt.Println("Dry run, nothing was posted.")
+	}
+	return err
+}
+
+// formatMicros shows an amount in millionths of a cent with four decimals.
+func formatMicros(micros int64) string {
+	cents := micros / interest.MicrosPerCent
+	hundredths := micros % interest.MicrosPerCent / (interest.MicrosPerCent / 100)
+	return fmt.Sprintf("%s%02d", money.Format(cents), hundredths)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"banking-app/backend/internal/limit"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+func runLimitSet(a *app, args []string) error {
+	flags := newFlags("limit set")
+	bankID := flags.Int64("bank-id", 0, "bank the limits apply at")
+	customerID := flags.Int64("customer-id", 0, "only for this customer, otherwise for every customer of the bank")
+	maxStr := flags.String("max-transaction", "0", "largest single withdrawal or transfer")
+	dailyStr := flags.String("daily", "0", "most that can be sent in a day")
+	monthlyStr := flags.String("monthly", "0", "most that can be sent in a calendar month")
+	perHour := flags.Int("transfers-per-hour", 0, "most transfers in any hour")
+	duration := flags.Duration("for", 0, "raise the limits temporarily, e.g. 48h")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	if *customerID != 0 {
+		c, err := a.customers.GetCustomer(*customerID)
+		if err != nil {
+			return err
+		}
+		if c.BankID != *bankID {
+			return fmt.Errorf("customer %d is not a customer of bank %d", c.ID, *bankID)
+		}
+	}
+
+	rule := limit.Rule{BankID: *bankID, CustomerID: *customerID, TransfersPerHour: *perHour}
+	for _, f := range []struct {
+		name  string
+		value string
+		dest  *int64
+	}{
+		{"max-transaction", *maxStr, &rule.MaxTransaction},
+		{"daily", *dailyStr, &rule.DailyOutflow},
+		{"monthly", *monthlyStr, &rule.MonthlyOutflow},
+	} {
+		v, err := money.Parse(f.value)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", f.name, err)
+		}
+		*f.dest = v
+	}
+	if *duration != 0 {
+		expires := time.Now().Add(*duration)
+		rule.ExpiresAt = &expires
+	}
+
+	saved, err := a.limits.SetRule(rule)
+	if err != nil {
+		return err
+	}
+
+	who := fmt.Sprintf("every customer of bank %d", saved.BankID)
+	if saved.CustomerID != 0 {
+		who = fmt.Sprintf("customer %d", saved.CustomerID)
+	}
+	if saved.Temporary() {
+		fmt.Printf("Limits for %s raised until %s\n", who, saved.ExpiresAt.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("Limits for %s set\n", who)
+	}
+	return nil
+}
+
+func runLimitRemove(a *app, args []string) error {
+	flags := newFlags("limit remove")
+	bankID := flags.Int64("bank-id", 0, "bank the limits apply at")
+	customerID := flags.Int64("customer-id", 0, "customer the limits apply to")
+	temporary := flags.Bool("temporary", false, "remove the temporary raise instead")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	if err := a.limits.RemoveRule(*bankID, *customerID, *temporary); err != nil {
+		return err
+	}
+
+	fmt.Println("Limit removed")
+	return nil
+}
+
+func runLimitList(a *app, args []string) error {
+	flags := newFlags("limit list")
+	bankID := flags.Int64("bank-id", 0, "bank to list the limits of")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	rules := a.limits.GetBankRules(*bankID)
+	if *asJSON {
+		return printJSON(rules)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tCustomer\tMax transaction\tDaily\tMonthly\tTransfers/hour\tExpires")
+	for _, r := range rules {
+		customer := "all"
+		if r.CustomerID != 0 {
+			customer = fmt.Sprint(r.CustomerID)
+		}
+		expires := ""
+		if r.Temporary() {
+			expires = r.ExpiresAt.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, customer, formatCap(r.MaxTransaction),
+			formatCap(r.DailyOutflow), formatCap(r.MonthlyOutflow), formatCount(r.TransfersPerHour), expires)
+	}
+	return t.Flush()
+}
+
+func runLimitShow(a *app, args []string) error {
+	flags := newFlags("limit show")
+	customerID := flags.Int64("customer-id", 0, "customer ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id"); err != nil {
+		return err
+	}
+
+	c, err := a.customers.GetCustomer(*customerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	effective := a.limits.Effective(c.BankID, c.ID, now)
+	usage := a.transactions.Usage(c.ID, now)
+
+	if *asJSON {
+		return printJSON(map[string]any{"limits": effective, "usage": usage})
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Limit\tCap\tUsed")
+	fmt.Fprintf(t, "max transaction\t%s\t\n", formatCap(effective.MaxTransaction))
+	fmt.Fprintf(t, "daily\t%s\t%s\n", formatCap(effective.DailyOutflow), money.Format(usage.Today))
+	fmt.Fprintf(t, "monthly\t%s\t%s\n", formatCap(effective.MonthlyOutflow), money.Format(usage.ThisMonth))
+	fmt.Fprintf(t, "transfers per hour\t%s\t%d\n", formatCount(effective.TransfersPerHour), usage.TransfersLastHour)
+	return t.Flush()
+}
+
+func formatCap(cents int64) string {
+	if cents == 0 {
+		return "-"
+	}
+	return money.Format(cents)
+}
+
+func formatCount(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"banking-app/backend/internal/loan"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+func runLoanApply(a *app, args []string) error {
+	flags := newFlags("loan apply")
+	customerID := flags.Int64("customer-id", 0, "borrower")
+	accountID := flags.Int64("account-id", 0, "account the loan is paid into and repaid from")
+	amountStr := flags.String("amount", "", "principal")
+	months := flags.Int("months", 0, "term in months")
+	methodStr := flags.String("method", string(loan.Annuity), "annuity or flat")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "customer-id", "account-id", "amount", "months"); err != nil {
+		return err
+	}
+
+	amount, err := money.Parse(*amountStr)
+	if err != nil {
+		return err
+	}
+	method, err := loan.ParseMethod(*methodStr)
+	if err != nil {
+		return err
+	}
+
+	l, err := a.loans.Apply(*customerID, *accountID, amount, *months, method)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loan application %d: %s over %d months (%s), waiting for approval\n", l.ID, money.Format(l.Principal), l.Months, l.Method)
+	return nil
+}
+
+func runLoanApprove(a *app, args []string) error {
+	flags := newFlags("loan approve")
+	id := flags.Int64("id", 0, "loan ID")
+	rateStr := flags.String("rate", "", "annual interest rate in percent, e.g. 7.5")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "rate"); err != nil {
+		return err
+	}
+
+	// a percentage with two decimals is a whole number of basis points
+	rateBps, err := money.Parse(*rateStr)
+	if err != nil {
+		return fmt.Errorf("invalid rate: %w", err)
+	}
+
+	l, err := a.loans.Approve(*id, rateBps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loan %d approved at %s%%, %s paid into account %d\n", l.ID, money.Format(l.RateBps), money.Format(l.Principal), l.AccountID)
+	return printSchedule(l)
+}
+
+func runLoanReject(a *app, args []string) error {
+	flags := newFlags("loan reject")
+	id := flags.Int64("id", 0, "loan ID")
+	reason := flags.String("reason", "", "told to the customer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "reason"); err != nil {
+		return err
+	}
+
+	l, err := a.loans.Reject(*id, *reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loan %d rejected\n", l.ID)
+	return nil
+}
+
+func runLoanList(a *app, args []string) error {
+	flags := newFlags("loan list")
+	bankID := flags.Int64("bank-id", 0, "only list loans of this bank")
+	customerID := flags.Int64("customer-id", 0, "only list loans of this customer")
+	statusStr := flags.String("status", "", "only list loans with this status")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var status loan.Status
+	if *statusStr != "" {
+		s, err := loan.ParseStatus(*statusStr)
+		if err != nil {
+			return err
+		}
+		status = s
+	}
+
+	loans := []*loan.Loan{}
+	for _, l := range a.loans.GetAllLoans() {
+		if (*bankID == 0 || l.BankID == *bankID) &&
+			(*customerID == 0 || l.CustomerID == *customerID) &&
+			(status == "" || l.Status == status) {
+			loans = append(loans, l)
+		}
+	}
+
+	if *asJSON {
+		return printJSON(loans)
+	}
+
+	now := time.Now()
+	t := newTable()
+	fmt.Fprintln(t, "ID\tBank ID\tCustomer ID\tAccount\tPrincipal\tMonths\tMethod\tRate\tStatus\tOutstanding\tOverdue")
+	for _, l := range loans {
+		_, overdue := l.Arrears(now)
+		fmt.Fprintf(t, "%d\t%d\t%d\t%d\t%s\t%d\t%s\t%s%%\t%s\t%s\t%s\n", l.ID, l.BankID, l.CustomerID, l.AccountID,
+			money.Format(l.Principal), l.Months, l.Method, money.Format(l.RateBps), l.Status,
+			money.Format(l.Outstanding()), money.Format(overdue))
+	}
+	return t.Flush()
+}
+
+func runLoanSchedule(a *app, args []string) error {
+	flags := newFlags("loan schedule")
+	id := flags.Int64("id", 0, "loan ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	l, err := a.loans.GetLoan(*id)
+	if err != nil {
+		return err
+	}
+	if l.Status == loan.StatusPending || l.Status == loan.StatusRejected {
+		return fmt.Errorf("loan %d is %s and has no schedule", l.ID, l.Status)
+	}
+
+	if *asJSON {
+		return printJSON(l.Schedule)
+	}
+	return printSchedule(l)
+}
+
+func printSchedule(l *loan.Loan) error {
+	t := newTable()
+	fmt.Fprintln(t, "#\tDue\tPrincipal\tInterest\tPenalty\tAmount\tPaid")
+	for _, inst := range l.Schedule {
+		paid := ""
+		if inst.Paid() {
+			paid = inst.PaidAt.Format(time.DateOnly)
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", inst.Number, inst.DueDate.Format(time.DateOnly),
+			money.Format(inst.Principal), money.Format(inst.Interest), money.Format(inst.Penalty),
+			money.Format(inst.Amount()), paid)
+	}
+	return t.Flush()
+}
+
+func runLoanCollect(a *app, args []string) error {
+	flags := newFlags("loan collect")
+	dateStr := flags.String("date", "", "collect what is due by the end of this day, YYYY-MM-DD (default now)")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	at := time.Now()
+	if *dateStr != "" {
+		date, err := parseDate(*dateStr)
+		if err != nil {
+			return err
+		}
+		at = date.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	results, err := a.loans.Collect(0, at)
+	if err != nil && len(results) == 0 {
+		return err
+	}
+
+	if *asJSON {
+		if jsonErr := printJSON(results); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Loan\tInstallment\tAmount\tResult")
+	for _, r := range results {
+		result := "collected"
+		if !r.Collected {
+			result = "late: " + r.Reason
+			if r.Penalty > 0 {
+				result += ", penalty " + money.Format(r.Penalty) + " added"
+			}
+		}
+		fmt.Fprintf(t, "%d\t%d\t%s\t%s\n", r.LoanID, r.Installment, money.Format(r.Amount), result)
+	}
+	if flushErr := t.Flush(); flushErr != nil {
+		return flushErr
+	}
+	return err
+}
@@ -1,19 +1,68 @@
 package main
 
 import (
+	"banking-app/backend/internal/aml"
 	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/config"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/gl"
+	"banking-app/backend/internal/loan"
+	"banking-app/backend/internal/payee"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/internal/search"
+	"banking-app/backend/internal/statement"
 	"banking-app/backend/internal/user"
-	"bufio"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/money"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 )
 
 // Database Structure:
-// - Both banks and customers are stored in db/database.json
-// - Banks collection: stores bank information with customer IDs
-// - Customers collection: stores customer information with bank references
-// - Banks can have multiple customers (stored as customer IDs)
+// - All collections are stored in one JSON file, db/database.json by default
+//   (override with --db or the storage.path setting)
+// - Users collection: login credentials and role (bank or customer)
+// - Banks collection: stores bank information with the operator's user ID
+// - Customers collection: stores customer information with bank references,
+//   their identity details and the history of the bank's verification
+// - Accounts collection: customer accounts with their balance in cents
+// - Transactions collection: ledger of deposits, withdrawals and transfers,
+//   each dated with the business day of the bank it was posted into
+// - ClosedDays collection: the business days each bank closed with end of
+//   day, with the trial balance taken at the close; Closings collection: the
+//   banks whose end of day is running, frozen to postings from every process
+// - GLAccounts collection: each bank's general ledger chart of accounts;
+//   Journal collection: the double-entry entries transactions book into it
+// - Reconciliations collection: audit trail of balance checks against the
+//   transactions and the corrections repairs made
+// - Fees collection: per-bank fee rules, fees are posted to the bank's
+//   internal income account
+// - Limits collection: per-bank and per-customer outflow caps, including
+//   temporary raises
+// - StandingOrders collection: recurring transfers, run on the business days
+//   of the Holidays collection
+// - Payees collection: each customer's saved beneficiaries
+// - Batches collection: imported bulk payment files with the status of every
+//   line
+// - Alerts collection: transactions that matched an anti-money-laundering
+//   rule, for the bank to review; held ones wait in the bank's internal
+//   suspense account
+// - ScreeningCases collection: customer and payee names that matched the
+//   sanctions watchlist, blocked until the bank clears them
+// - Cards collection: virtual debit cards on customer accounts, the PIN kept
+//   as a salted hash; Authorisations collection: card payments asked for by
+//   merchants
+// - Holds collection: money set aside on an account, e.g. an authorised card
+//   payment waiting to be captured
+// - Notifications collection: messages for customers, e.g. an account going
+//   overdrawn
+// - Loans collection: loan applications and the repayment schedule of
+//   approved loans, paid out of and back into the bank's internal loan account
 // - Customers can only belong to one bank (stored as bank ID)
 
 // func showUpdateMenu() {
@@ -103,70 +152,328 @@ import (
 // 	}
 // }
 
-func showLoginMenu() {
-	fmt.Println("\n======= Login Menu =======")
-	fmt.Println()
-	fmt.Println("1. Login")
-	fmt.Println("2. Register")
-	fmt.Println()
-	fmt.Println("==========================")
+func showLoginMenu(con *console.Console) {
+	con.Println("\n======= Login Menu =======")
+	con.Println()
+	con.Println("1. Login")
+	con.Println("2. Register")
+	con.Println()
+	con.Println("==========================")
 }
 
-func main() {
-	dataDir := "../../db/database.json"
-	userRepo, err := user.NewRepository(dataDir)
+func showCustomerMenu(con *console.Console) {
+	con.Println("\n====== Customer Menu ======")
+	con.Println()
+	con.Println("1. My accounts")
+	con.Println("2. Apply for a loan")
+	con.Println("3. Loan balance")
+	con.Println("4. Notifications")
+	con.Println("5. Pay a payee")
+	con.Println("6. My payees")
+	con.Println("7. Add a payee")
+	con.Println("8. Remove a payee")
+	con.Println("9. Export a statement")
+	con.Println("10. Search")
+	con.Println("0. Logout")
+	con.Println()
+	con.Println("==========================")
+}
+
+func showBankMenu(con *console.Console, waiting, alerts, hits int) {
+	con.Println("\n======== Bank Menu ========")
+	con.Println()
+	con.Printf("1. Customers to verify (%d)\n", waiting)
+	con.Printf("2. Transaction alerts (%d)\n", alerts)
+	con.Printf("3. Watchlist hits (%d)\n", hits)
+	con.Println("4. Financial reports")
+	con.Println("5. Search")
+	con.Println("0. Logout")
+	con.Println()
+	con.Println("==========================")
+}
+
+// runBankMenu is the menu of a logged in bank operator. Operators without a
+// bank are asked to create one first.
+func runBankMenu(a *app, u *user.User) error {
+	con := a.con
+	bank.NewHandler(a.banks, con).NewBankLogin(u.ID)
+	b, err := a.banks.GetBankByUserID(u.ID)
+	if err != nil {
+		return nil
+	}
+	customerHandler := customer.NewHandler(a.customers, con)
+	alertHandler := aml.NewHandler(a.alerts, con)
+	screeningHandler := sanctions.NewHandler(a.screening, con)
+	reportHandler := gl.NewHandler(a.ledger, con)
+	searchHandler := search.NewHandler(a.search, con)
+
+	for {
+		showBankMenu(con, len(a.customers.GetReviewQueue(b.ID)), len(a.alerts.GetBankAlerts(b.ID, aml.StatusOpen)),
+			len(a.screening.GetBankCases(b.ID, sanctions.StatusOpen)))
+
+		choice, err := con.Prompt("Choose: ")
+		if err != nil {
+			return err // runMenu stops as well
+		}
+
+		switch choice {
+		case "0":
+			return nil
+		case "1":
+			customerHandler.HandleReviewQueue(b.ID, u.ID)
+		case "2":
+			alertHandler.HandleQueue(b.ID, u.ID)
+		case "3":
+			screeningHandler.HandleQueue(b.ID, u.ID)
+		case "4":
+			reportHandler.HandleReports(b.ID)
+		case "5":
+			searchHandler.HandleSearch(u.ID)
+		default:
+			con.Println("❌ Invalid choice. Please select a valid option.")
+		}
+	}
+}
+
+// runCustomerMenu is the menu of a logged in customer. Users without a
+// customer profile are asked to join a bank first.
+func runCustomerMenu(a *app, u *user.User) error {
+	con := a.con
+	loanHandler := loan.NewHandler(a.loans, con)
+	payeeHandler := payee.NewHandler(a.payees, a.accounts, con)
+	statementHandler := statement.NewHandler(a.statements, con)
+	searchHandler := search.NewHandler(a.search, con)
+
+	c, err := a.customers.GetCustomerByUserID(u.ID)
 	if err != nil {
-		panic(fmt.Errorf("failed to load database: %w", err))
+		con.Println("You are not a customer of any bank yet.")
+		bank.NewHandler(a.banks, con).HandleList()
+		bankIDStr, err := con.Prompt("Bank ID to join (empty to log out): ")
+		if err != nil {
+			return err
+		}
+		if bankIDStr == "" {
+			return nil
+		}
+		bankID, err := strconv.ParseInt(bankIDStr, 10, 64)
+		if err != nil {
+			con.Printf("Invalid ID format: %s\n", bankIDStr)
+			return nil
+		}
+		if _, err := a.banks.GetBank(bankID); err != nil {
+			con.Printf("Error joining bank: %v\n", err)
+			return nil
+		}
+		name, err := con.Prompt("Your full name: ")
+		if err != nil {
+			return err
+		}
+		c, err = a.customers.CreateCustomer(u.ID, bankID, name)
+		if err != nil {
+			con.Printf("Error joining bank: %v\n", err)
+			return nil
+		}
+	}
+	con.Printf("Welcome, %s!\n", c.Name)
+
+	// unverified customers can only send their details for review
+	if !c.Verified() {
+		customerHandler := customer.NewHandler(a.customers, con)
+		customerHandler.HandleStatus(c.ID)
+		if c.Status != customer.StatusPending {
+			answer, err := con.Prompt("Submit your details now? (y/N): ")
+			if err != nil {
+				return err
+			}
+			if strings.EqualFold(answer, "y") {
+				customerHandler.HandleSubmit(c.ID)
+			}
+		}
+		return nil
+	}
+
+	if unread := a.notifications.GetCustomerNotifications(c.ID, true); len(unread) > 0 {
+		con.Printf("🔔 You have %d new notification(s).\n", len(unread))
+	}
+
+	for {
+		showCustomerMenu(con)
+
+		choice, err := con.Prompt("Choose: ")
+		if err != nil {
+			return err // runMenu stops as well
+		}
+
+		// the choices that show or spend the available balance first
+		// release the holds that have run out
+		switch choice {
+		case "1", "5", "9":
+			if err := a.releaseExpiredHolds(); err != nil {
+				con.Printf("Error: %v\n", err)
+				continue
+			}
+		}
+
+		switch choice {
+		case "0":
+			return nil
+		case "1":
+			accounts := a.accounts.GetCustomerAccounts(c.ID)
+			if len(accounts) == 0 {
+				con.Println("You have no accounts yet.")
+				continue
+			}
+			con.Println("Account number\tType\tLedger balance\tHeld\tAvailable")
+			for _, acc := range accounts {
+				con.Printf("%s\t%s\t%s\t%s\t%s\n", accountno.Group(acc.Number), acc.Type, money.Format(acc.Balance), money.Format(acc.Held),
+					money.Format(acc.Available()))
+				for _, h := range a.holds.GetAccountHolds(acc.ID, true) {
+					con.Printf("  pending: %s %s\n", h.Reference, money.Format(-h.Amount))
+				}
+			}
+		case "2":
+			loanHandler.HandleApply(c.ID)
+		case "3":
+			loanHandler.HandleBalance(c.ID)
+		case "4":
+			notifications := a.notifications.GetCustomerNotifications(c.ID, false)
+			if len(notifications) == 0 {
+				con.Println("You have no notifications.")
+				continue
+			}
+			for _, n := range notifications {
+				marker := " "
+				if !n.Read {
+					marker = "*"
+				}
+				con.Printf("%s %s  %s\n", marker, n.CreatedAt.Format("2006-01-02 15:04"), n.Message)
+			}
+			if err := a.notifications.MarkRead(c.ID); err != nil {
+				con.Printf("Error: %v\n", err)
+			}
+		case "5":
+			payeeHandler.HandleTransfer(c.ID)
+		case "6":
+			payeeHandler.HandleList(c.ID)
+		case "7":
+			payeeHandler.HandleAdd(c.ID)
+		case "8":
+			payeeHandler.HandleRemove(c.ID)
+		case "9":
+			statementHandler.HandleExport(c.ID)
+		case "10":
+			searchHandler.HandleSearch(u.ID)
+		default:
+			con.Println("❌ Invalid choice. Please select a valid option.")
+		}
+	}
+}
+
+// setFlags collects repeated --set key=value flags.
+type setFlags map[string]string
+
+func (s setFlags) String() string {
+	return ""
+}
+
+func (s setFlags) Set(kv string) error {
+	key, value, ok := strings.Cut(kv, "=")
+	if !ok {
+		return fmt.Errorf("expected key=value, got %q", kv)
+	}
+	s[key] = value
+	return nil
+}
+
+func main() {
+	overrides := setFlags{}
+
+	flags := flag.NewFlagSet("banking", flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv(config.EnvPrefix+"CONFIG"), "path to a YAML or TOML config file (env "+config.EnvPrefix+"CONFIG)")
+	dbPath := flags.String("db", "", "path to the JSON database file, same as --set storage.path=PATH")
+	flags.Var(overrides, "set", "override a config setting, e.g. --set server.port=9090 (repeatable)")
+	flags.Usage = func() { printUsage(flags) }
+	flags.Parse(os.Args[1:])
+
+	if *dbPath != "" {
+		overrides["storage.path"] = *dbPath
 	}
 
-	userService := user.NewService(userRepo)
-	userHandler := user.NewHandler(userService)
+	cfg, err := config.Load(*configPath, os.Environ(), overrides)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
 
-	bankRepo := bank.NewRepository(dataDir)
+	// No command keeps the old behaviour of starting the interactive menu
+	args := flags.Args()
+	if len(args) == 0 {
+		args = []string{"menu"}
+	}
 
-	bankService := bank.NewService(bankRepo)
-	bankHandler := bank.NewHandler(bankService)
+	if err := runCommand(cfg, args); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
 
-	scanner := bufio.NewReader(os.Stdin)
+func runMenu(a *app, args []string) error {
+	con := a.con
+	userHandler := user.NewHandler(a.users, con)
 
-	fmt.Println("Welcome to Banking App!")
+	con.Println("Welcome to Banking App!")
 
 	for {
-		showLoginMenu()
+		showLoginMenu(con)
 
-		fmt.Print("Choose: ")
-		choice, _ := scanner.ReadString('\n')
-		choice = strings.TrimSpace(choice) // important: remove \n
+		choice, err := con.Prompt("Choose: ")
+		if err != nil {
+			return endMenu(con, err)
+		}
 
 		switch choice {
 		case "0":
-			fmt.Println("\n👋 Exiting the application. Goodbye!")
-			return
+			con.Println("\n👋 Exiting the application. Goodbye!")
+			return nil
 
 		case "1":
-			fmt.Println("\n🔓 Login")
+			con.Println("\n🔓 Login")
 			u := userHandler.Login()
 			if u == nil {
-				fmt.Println("❌ Login failed. Please try again.")
+				con.Println("❌ Login failed. Please try again.")
 				continue
 			}
 
 			switch u.Role {
 			case user.RoleBank:
-				bankHandler.NewBankLogin(u.ID)
+				if err := runBankMenu(a, u); err != nil {
+					return endMenu(con, err)
+				}
 			case user.RoleCustomer:
-				fmt.Println("🙋 You are logged in as a Customer!")
-				// TODO: call customer menu handler here
+				con.Println("🙋 You are logged in as a Customer!")
+				if err := runCustomerMenu(a, u); err != nil {
+					return endMenu(con, err)
+				}
 			default:
-				fmt.Println("⚠️ Unknown role. Please contact admin.")
+				con.Println("⚠️ Unknown role. Please contact admin.")
 			}
 
 		case "2":
-			fmt.Println("\n🔑 Register New User")
+			con.Println("\n🔑 Register New User")
 			userHandler.Register()
 
 		default:
-			fmt.Println("❌ Invalid choice. Please select a valid option.")
+			con.Println("❌ Invalid choice. Please select a valid option.")
 		}
 	}
 }
+
+// endMenu stops the menu on an input error. Piped input running out ends it
+// like choosing exit, any other error is returned.
+func endMenu(con *console.Console, err error) error {
+	if err == io.EOF {
+		con.Println()
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"banking-app/backend/internal/reconcile"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"fmt"
+)
+
+func runReconcile(a *app, args []string) error {
+	flags := newFlags("reconcile run")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	repair := flags.Bool("repair", false, "post balance corrections and book general ledger adjustments")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	// the run is recorded as made by the bank's operator
+	b, err := a.banks.GetBank(*bankID)
+	if err != nil {
+		return err
+	}
+	run, err := a.reconciler.Reconcile(b.ID, b.UserID, *repair)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(run)
+	}
+	return printReconciliation(run)
+}
+
+func runReconcileHistory(a *app, args []string) error {
+	flags := newFlags("reconcile history")
+	bankID := flags.Int64("bank-id", 0, "bank ID")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	runs := a.reconciler.GetBankRuns(*bankID)
+	if *asJSON {
+		return printJSON(runs)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tRun at\tAccounts\tBalance differences\tLedger differences\tRepaired")
+	for _, r := range runs {
+		fmt.Fprintf(t, "%d\t%s\t%d\t%d\t%d\t%t\n", r.ID, r.RunAt.Format("2006-01-02 15:04"), r.Accounts,
+			len(r.AccountDifferences), len(r.LedgerDifferences), r.Repaired)
+	}
+	return t.Flush()
+}
+
+func printReconciliation(run *reconcile.Run) error {
+	fmt.Printf("Reconciliation %d of bank %d: %d accounts checked\n", run.ID, run.BankID, run.Accounts)
+	if run.Clean() {
+		fmt.Println("✅ Balances agree with the ledger and the general ledger")
+		return nil
+	}
+
+	if len(run.AccountDifferences) > 0 {
+		fmt.Println()
+		t := newTable()
+		fmt.Fprintln(t, "Account\tNumber\tType\tStored\tLedger\tDifference")
+		for _, d := range run.AccountDifferences {
+			fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\n", d.AccountID, accountno.Group(d.Number), d.Type,
+				money.Format(d.Stored), money.Format(d.Ledger), money.Format(d.Difference))
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(run.LedgerDifferences) > 0 {
+		fmt.Println()
+		t := newTable()
+		fmt.Fprintln(t, "Code\tGeneral ledger account\tExpected\tActual\tDifference")
+		for _, d := range run.LedgerDifferences {
+			fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\n", d.Code, d.Name,
+				money.Format(d.Expected), money.Format(d.Actual), money.Format(d.Difference))
+		}
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	if run.Repaired {
+		fmt.Printf("Repaired: %d correction(s) posted against suspense, %d general ledger adjustment(s) booked\n",
+			len(run.Transactions), len(run.Entries))
+	} else {
+		fmt.Println("Run again with --repair to correct them")
+	}
+	return nil
+}
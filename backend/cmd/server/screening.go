@@ -0,0 +1,165 @@
+package main
+
+import (
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"strings"
+)
+
+func runWatchlistShow(a *app, args []string) error {
+	flags := newFlags("watchlist show")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	w := a.screening.Watchlist()
+	if w == nil {
+		return fmt.Errorf("no watchlist configured, set sanctions.watchlist")
+	}
+	entries, err := w.Entries()
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(entries)
+	}
+
+	fmt.Printf("%s: %d entries, loaded %s\n", w.Path(), len(entries), w.LoadedAt().Format("2006-01-02 15:04:05"))
+	t := newTable()
+	fmt.Fprintln(t, "ID\tName\tAliases\tList")
+	for _, e := range entries {
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\n", e.ID, e.Name, strings.Join(e.Aliases, "; "), e.List)
+	}
+	return t.Flush()
+}
+
+func runWatchlistCheck(a *app, args []string) error {
+	flags := newFlags("watchlist check")
+	name := flags.String("name", "", "name to look up")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "name"); err != nil {
+		return err
+	}
+
+	if a.screening.Watchlist() == nil {
+		return fmt.Errorf("no watchlist configured, set sanctions.watchlist")
+	}
+	matches, err := a.screening.Matches(*name)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(matches)
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No watchlist entry is %s%% or more similar to %q\n", money.Format(a.cfg.Sanctions.ThresholdBps), *name)
+		return nil
+	}
+	t := newTable()
+	fmt.Fprintln(t, "Score\tID\tEntry\tMatched name\tList")
+	for _, m := range matches {
+		fmt.Fprintf(t, "%s%%\t%s\t%s\t%s\t%s\n", money.Format(m.ScoreBps), m.Entry.ID, m.Entry.Name, m.Name, m.Entry.List)
+	}
+	return t.Flush()
+}
+
+func runScreeningList(a *app, args []string) error {
+	flags := newFlags("screening list")
+	bankID := flags.Int64("bank-id", 0, "bank whose cases to list")
+	statusStr := flags.String("status", "", "only list cases with this status")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	var status sanctions.Status
+	if *statusStr != "" {
+		var err error
+		if status, err = sanctions.ParseStatus(*statusStr); err != nil {
+			return err
+		}
+	}
+
+	cases := a.screening.GetBankCases(*bankID, status)
+	if *asJSON {
+		return printJSON(cases)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tDate\tKind\tName\tCustomer ID\tEntry\tList\tScore\tStatus")
+	for _, c := range cases {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%d\t%s\t%s\t%s%%\t%s\n", c.ID, c.CreatedAt.Format("2006-01-02 15:04"), c.Subject.Kind, c.Subject.Name,
+			c.Subject.CustomerID, c.EntryName, c.List, money.Format(c.ScoreBps), c.Status)
+	}
+	return t.Flush()
+}
+
+func runScreeningClear(a *app, args []string) error {
+	flags := newFlags("screening clear")
+	id := flags.Int64("id", 0, "case ID")
+	note := flags.String("note", "", "note for the case record")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	b, err := caseBank(a, *id)
+	if err != nil {
+		return err
+	}
+	c, err := a.screening.Clear(b.ID, *id, b.UserID, *note)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Case %d cleared, %q can go ahead\n", c.ID, c.Subject.Name)
+	return nil
+}
+
+func runScreeningConfirm(a *app, args []string) error {
+	flags := newFlags("screening confirm")
+	id := flags.Int64("id", 0, "case ID")
+	note := flags.String("note", "", "why the name is a true match")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id", "note"); err != nil {
+		return err
+	}
+
+	b, err := caseBank(a, *id)
+	if err != nil {
+		return err
+	}
+	c, err := a.screening.Confirm(b.ID, *id, b.UserID, *note)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Case %d confirmed, %q stays blocked\n", c.ID, c.Subject.Name)
+	return nil
+}
+
+// caseBank is the bank a screening case belongs to. Reviews from the command
+// line are recorded as made by the bank's operator.
+func caseBank(a *app, caseID int64) (*bank.Bank, error) {
+	c, err := a.screening.GetCase(caseID)
+	if err != nil {
+		return nil, err
+	}
+	return a.banks.GetBank(c.Subject.BankID)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"banking-app/backend/pkg/console"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// A script is the non-interactive form of the menu: every line answers the
+// next prompt exactly as it would be typed, and lines starting with # are
+// comments. The menu runs through the same handlers as it does at a
+// terminal. Every prompt produces one JSON object on stdout with what was
+// printed before it, the prompt and the answer, so another program can drive
+// the menu flow and read the results back.
+//
+//	# register a bank operator, log in and create the bank
+//	2
+//	alice
+//	S3cret-pass
+//	S3cret-pass
+//	bank
+//	1
+//	alice
+//	S3cret-pass
+//	Alice Bank
+//	0
+//	0
+func runScript(a *app, args []string) error {
+	flags := newFlags("script")
+	file := flags.String("file", "-", "script to run, - for stdin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	return runScripted(a, in, os.Stdout)
+}
+
+// runScripted runs the menu over a scripted console, writing its steps to w
+// as JSON Lines.
+func runScripted(a *app, script io.Reader, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	var encErr error
+	a.con = console.NewScripted(script, func(step console.Step) {
+		if encErr == nil {
+			encErr = enc.Encode(step)
+		}
+	})
+
+	err := runMenu(a, nil)
+	a.con.Flush()
+	if err != nil {
+		return err
+	}
+	return encErr
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"banking-app/backend/internal/user"
+	"banking-app/backend/pkg/console"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// runTestScript runs the menu over the script lines and returns its steps.
+func runTestScript(t *testing.T, a *app, lines ...string) []console.Step {
+	t.Helper()
+	var out strings.Builder
+	if err := runScripted(a, strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var steps []console.Step
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var step console.Step
+		if err := json.Unmarshal(scanner.Bytes(), &step); err != nil {
+			t.Fatalf("step %q is not JSON: %v", scanner.Text(), err)
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func TestScriptBankOperatorCreatesBank(t *testing.T) {
+	a := newTestApp(t)
+
+	steps := runTestScript(t, a,
+		"# register, log in and create the bank",
+		"2", "alice", "S3cret-pass", "S3cret-pass", "bank",
+		"1", "alice", "S3cret-pass", "Alice Bank",
+		"0", "0",
+	)
+
+	b, err := a.banks.GetBankByUserID(1)
+	if err != nil {
+		t.Fatalf("no bank created: %v", err)
+	}
+	if b.Name != "Alice Bank" {
+		t.Errorf("bank name = %q, want Alice Bank", b.Name)
+	}
+
+	// every script line answered a prompt, the comment was skipped
+	if len(steps) != 12 {
+		t.Fatalf("got %d steps, want 11 prompts and the goodbye", len(steps))
+	}
+	for _, step := range steps {
+		if step.Secret && step.Input != "" {
+			t.Errorf("line %d: the password was written out", step.Line)
+		}
+	}
+	if got := steps[9]; got.Prompt != "Choose: " || !strings.Contains(got.Output, "Bank Menu") {
+		t.Errorf("step after creating the bank = %+v, want the bank menu", got)
+	}
+	if last := steps[len(steps)-1]; !strings.Contains(last.Output, "Goodbye") {
+		t.Errorf("last step = %+v, want the goodbye", last)
+	}
+}
+
+func TestScriptCustomerJoinsBank(t *testing.T) {
+	a := newTestApp(t)
+	operator, err := a.users.Register("alice", "S3cret-pass", user.RoleBank)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.banks.CreateBank(operator.ID, "Alice Bank"); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := runTestScript(t, a,
+		"2", "bob", "S3cret-pass", "S3cret-pass", "customer",
+		"1", "bob", "S3cret-pass",
+		"1", "Bob Smith", // bank to join and full name
+		"n", // not submitting details yet
+		"0",
+	)
+
+	bob, err := a.users.Login("bob", "S3cret-pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := a.customers.GetCustomerByUserID(bob.ID)
+	if err != nil {
+		t.Fatalf("bob did not join the bank: %v", err)
+	}
+	if c.Name != "Bob Smith" || c.BankID != 1 {
+		t.Errorf("customer = %q at bank %d, want Bob Smith at bank 1", c.Name, c.BankID)
+	}
+	if c.Verified() {
+		t.Error("a new customer is verified without a review")
+	}
+
+	var asked bool
+	for _, step := range steps {
+		if step.Prompt == "Submit your details now? (y/N): " {
+			asked = true
+		}
+	}
+	if !asked {
+		t.Error("the unverified customer was not offered to submit their details")
+	}
+}
+
+func TestScriptCustomerCannotJoinMissingBank(t *testing.T) {
+	a := newTestApp(t)
+
+	steps := runTestScript(t, a,
+		"2", "bob", "S3cret-pass", "S3cret-pass", "customer",
+		"1", "bob", "S3cret-pass",
+		"7", // no such bank, the name is not asked for
+		"0",
+	)
+
+	bob, err := a.users.Login("bob", "S3cret-pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, err := a.customers.GetCustomerByUserID(bob.ID); err == nil {
+		t.Errorf("bob joined bank %d, which does not exist", c.BankID)
+	}
+
+	var refused bool
+	for _, step := range steps {
+		if step.Prompt == "Your full name: " {
+			t.Error("the name was asked for a bank that does not exist")
+		}
+		if strings.Contains(step.Output, "Error joining bank") {
+			refused = true
+		}
+	}
+	if !refused {
+		t.Error("joining a bank that does not exist was not refused")
+	}
+}
+
+func TestScriptUnknownLogin(t *testing.T) {
+	a := newTestApp(t)
+
+	steps := runTestScript(t, a, "1", "nobody", "S3cret-pass", "0")
+
+	if len(steps) != 5 || !strings.Contains(steps[3].Output, "Login failed") {
+		t.Errorf("steps = %+v, want the login refused", steps)
+	}
+}
+
+// failingReader fails every read with err.
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestMenuStopsOnInputError(t *testing.T) {
+	a := newTestApp(t)
+	broken := errors.New("input broken")
+	a.con = console.New(failingReader{broken}, &strings.Builder{})
+
+	if err := runMenu(a, nil); !errors.Is(err, broken) {
+		t.Errorf("runMenu = %v, want the input error", err)
+	}
+}
+
+func TestMenuEndsAtEndOfInput(t *testing.T) {
+	a := newTestApp(t)
+	a.con = console.New(strings.NewReader("1\nalice\n"), &strings.Builder{})
+
+	if err := runMenu(a, nil); err != nil {
+		t.Errorf("runMenu = %v, want piped input running out to end it", err)
+	}
+}
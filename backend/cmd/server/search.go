@@ -0,0 +1,45 @@
+package main
+
+import (
+	"banking-app/backend/internal/search"
+	"fmt"
+)
+
+func runSearch(a *app, args []string) error {
+	flags := newFlags("search")
+	userID := flags.Int64("user-id", 0, "user to search as, results are limited to what they may see")
+	query := flags.String("query", "", "words to look for")
+	kindFlag := flags.String("kind", "", "only bank, customer, user, account or transaction results")
+	limit := flags.Int("limit", 20, "most results to show, 0 for all")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "user-id", "query"); err != nil {
+		return err
+	}
+
+	var kind search.Kind
+	if *kindFlag != "" {
+		k, err := search.ParseKind(*kindFlag)
+		if err != nil {
+			return err
+		}
+		kind = k
+	}
+
+	results, err := a.search.Search(*userID, *query, kind, *limit)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(results)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Kind\tID\tMatch\tDetail\tScore")
+	for _, r := range results {
+		fmt.Fprintf(t, "%s\t%d\t%s\t%s\t%d\n", r.Kind, r.ID, r.Title, r.Detail, r.Score)
+	}
+	return t.Flush()
+}
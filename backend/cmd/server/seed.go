@@ -0,0 +1,106 @@
+package main
+
+import (
+	"banking-app/backend/internal/seed"
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+func runSeed(dbPath string, args []string) error {
+	flags := newFlags("seed")
+	preset := flags.String("preset", "small", "size preset: small, medium or large")
+	banks := flags.Int("banks", 0, "number of banks, overrides the preset")
+	customers := flags.Int("customers", 0, "number of customers, overrides the preset")
+	days := flags.Int("days", 0, "days of transaction history, overrides the preset")
+	seedFlag := flags.Uint64("seed", 1, "random seed, the same seed gives the same data")
+	end := flags.String("end", seed.DefaultEnd.Format(time.DateOnly), "last day of history (YYYY-MM-DD)")
+	overwrite := flags.Bool("overwrite", false, "replace a database that already has data")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	size, ok := seed.Presets[*preset]
+	if !ok {
+		names := make([]string, 0, len(seed.Presets))
+		for name := range seed.Presets {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return fmt.Errorf("unknown preset %q (expected %s)", *preset, strings.Join(names, ", "))
+	}
+	if *banks != 0 {
+		size.Banks = *banks
+	}
+	if *customers != 0 {
+		size.Customers = *customers
+	}
+	if *days != 0 {
+		size.Days = *days
+	}
+	endDate, err := parseDate(*end)
+	if err != nil {
+		return err
+	}
+
+	// the password is never a flag, where other local users could see it
+	var password string
+	con := console.New(os.Stdin, os.Stdout)
+	if con.Interactive() {
+		password, err = con.PromptNewSecret("Password of every user: ", "Confirm password: ")
+	} else {
+		// scripts pipe the password in on the first line of stdin
+		password, err = con.Prompt("")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	data, err := seed.Generate(seed.Options{Size: size, Seed: *seedFlag, End: endDate, Password: password})
+	if err != nil {
+		return err
+	}
+
+	archive := &storage.Archive{Collections: map[string][]json.RawMessage{}}
+	for name, records := range map[string]any{
+		"users":        data.Users,
+		"banks":        data.Banks,
+		"customers":    data.Customers,
+		"products":     data.Products,
+		"accounts":     data.Accounts,
+		"accruals":     data.Accruals,
+		"transactions": data.Transactions,
+	} {
+		raw, err := json.Marshal(records)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		var list []json.RawMessage
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return err
+		}
+		archive.Collections[name] = list
+	}
+
+	if err := storage.Restore(dbPath, archive, *overwrite); err != nil {
+		if errors.Is(err, storage.ErrNotEmpty) {
+			return fmt.Errorf("%s: %w; run with --overwrite to replace it", dbPath, err)
+		}
+		return err
+	}
+	// the other collections are created empty
+	if _, err := storage.Migrate(dbPath, collections); err != nil {
+		return err
+	}
+
+	fmt.Printf("Seeded %s with %d banks, %d customers, %d accounts and %d transactions (seed %d)\n",
+		dbPath, len(data.Banks), len(data.Customers), len(data.Accounts), len(data.Transactions), *seedFlag)
+	fmt.Println("Users bank01.. and customer00001.. all have the password you gave")
+	return nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"banking-app/backend/internal/standingorder"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+func runOrderCreate(a *app, args []string) error {
+	flags := newFlags("standing-order create")
+	fromNumber := flags.String("from", "", "account number to pay from")
+	toNumber := flags.String("to", "", "account number to pay to")
+	amountStr := flags.String("amount", "", "amount of every payment")
+	frequencyStr := flags.String("frequency", "", "daily, weekly, monthly or end-of-month")
+	day := flags.Int("day", 0, "day of the month for monthly orders")
+	startStr := flags.String("start", "", "first day, YYYY-MM-DD")
+	endStr := flags.String("end", "", "last day, YYYY-MM-DD (default no end)")
+	memo := flags.String("memo", "", "note stored with every payment")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "from", "to", "amount", "frequency", "start"); err != nil {
+		return err
+	}
+
+	from, err := a.accounts.GetAccountByNumber(*fromNumber)
+	if err != nil {
+		return err
+	}
+	to, err := a.accounts.GetAccountByNumber(*toNumber)
+	if err != nil {
+		return err
+	}
+	amount, err := money.Parse(*amountStr)
+	if err != nil {
+		return err
+	}
+	frequency, err := standingorder.ParseFrequency(*frequencyStr)
+	if err != nil {
+		return err
+	}
+	start, err := parseDate(*startStr)
+	if err != nil {
+		return err
+	}
+	var end *time.Time
+	if *endStr != "" {
+		d, err := parseDate(*endStr)
+		if err != nil {
+			return err
+		}
+		end = &d
+	}
+
+	order, err := a.orders.Create(from.ID, to.ID, amount, *memo, frequency, *day, start, end)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Standing order %d: %s %s from account %s to account %s, first payment %s\n", order.ID,
+		money.Format(order.Amount), order.Frequency, accountno.Group(from.Number), accountno.Group(to.Number), order.NextDue.Format(time.DateOnly))
+	return nil
+}
+
+func runOrderList(a *app, args []string) error {
+	flags := newFlags("standing-order list")
+	customerID := flags.Int64("customer-id", 0, "only list orders of this customer")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	orders := a.orders.GetAllOrders()
+	if *customerID != 0 {
+		orders = a.orders.GetCustomerOrders(*customerID)
+	}
+
+	if *asJSON {
+		return printJSON(orders)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "ID\tCustomer\tFrom\tTo\tAmount\tFrequency\tNext due\tEnds\tStatus\tLast error")
+	for _, o := range orders {
+		frequency := string(o.Frequency)
+		if o.Frequency == standingorder.Monthly {
+			frequency = fmt.Sprintf("monthly on day %d", o.Day)
+		}
+		ends := ""
+		if o.EndDate != nil {
+			ends = o.EndDate.Format(time.DateOnly)
+		}
+		fmt.Fprintf(t, "%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n", o.ID, o.CustomerID, o.FromAccountID, o.ToAccountID,
+			money.Format(o.Amount), frequency, o.NextDue.Format(time.DateOnly), ends, o.Status, o.LastError)
+	}
+	return t.Flush()
+}
+
+func runOrderPause(a *app, args []string) error {
+	return changeOrder(args, "standing-order pause", "paused", a.orders.Pause)
+}
+
+func runOrderResume(a *app, args []string) error {
+	return changeOrder(args, "standing-order resume", "resumed", a.orders.Resume)
+}
+
+func runOrderCancel(a *app, args []string) error {
+	return changeOrder(args, "standing-order cancel", "cancelled", a.orders.Cancel)
+}
+
+func changeOrder(args []string, name, done string, change func(id int64) (*standingorder.Order, error)) error {
+	flags := newFlags(name)
+	id := flags.Int64("id", 0, "standing order ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	order, err := change(*id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Standing order %d %s\n", order.ID, done)
+	return nil
+}
+
+func runOrderRun(a *app, args []string) error {
+	flags := newFlags("standing-order run")
+	dateStr := flags.String("date", "", "make the payments due up to this day, YYYY-MM-DD (default today)")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if *dateStr != "" {
+		d, err := parseDate(*dateStr)
+		if err != nil {
+			return err
+		}
+		today = d
+	}
+
+	results, err := a.orders.Run(0, today)
+	if err != nil && len(results) == 0 {
+		return err
+	}
+
+	if *asJSON {
+		if jsonErr := printJSON(results); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Order\tDue\tOutcome\tTransaction\tReason")
+	for _, r := range results {
+		tx := ""
+		if r.TransactionID != 0 {
+			tx = fmt.Sprint(r.TransactionID)
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\n", r.OrderID, r.Due.Format(time.DateOnly), r.Outcome, tx, r.Reason)
+	}
+	if flushErr := t.Flush(); flushErr != nil {
+		return flushErr
+	}
+	return err
+}
+
+func runHolidayAdd(a *app, args []string) error {
+	flags := newFlags("holiday add")
+	bankID := flags.Int64("bank-id", 0, "bank that is closed")
+	date := flags.String("date", "", "day, YYYY-MM-DD")
+	name := flags.String("name", "", "name of the holiday")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id", "date", "name"); err != nil {
+		return err
+	}
+
+	if _, err := a.banks.GetBank(*bankID); err != nil {
+		return err
+	}
+	if err := a.calendar.AddHoliday(*bankID, *date, *name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank %d is closed on %s (%s)\n", *bankID, *date, *name)
+	return nil
+}
+
+func runHolidayList(a *app, args []string) error {
+	flags := newFlags("holiday list")
+	bankID := flags.Int64("bank-id", 0, "bank to list the holidays of")
+	asJSON := flags.Bool("json", false, "print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id"); err != nil {
+		return err
+	}
+
+	holidays := a.calendar.GetBankHolidays(*bankID)
+	if *asJSON {
+		return printJSON(holidays)
+	}
+
+	t := newTable()
+	fmt.Fprintln(t, "Date\tName")
+	for _, h := range holidays {
+		fmt.Fprintf(t, "%s\t%s\n", h.Date, h.Name)
+	}
+	return t.Flush()
+}
+
+func runHolidayRemove(a *app, args []string) error {
+	flags := newFlags("holiday remove")
+	bankID := flags.Int64("bank-id", 0, "bank")
+	date := flags.String("date", "", "day, YYYY-MM-DD")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "bank-id", "date"); err != nil {
+		return err
+	}
+
+	if err := a.calendar.RemoveHoliday(*bankID, *date); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bank %d is open on %s again\n", *bankID, *date)
+	return nil
+}
+
+// parseDate reads a YYYY-MM-DD flag as midnight local time.
+func parseDate(s string) (time.Time, error) {
+	d, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return d, nil
+}
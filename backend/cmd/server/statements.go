@@ -0,0 +1,66 @@
+package main
+
+import (
+	"banking-app/backend/internal/statement"
+	"fmt"
+	"os"
+	"time"
+)
+
+func runAccountStatement(a *app, args []string) error {
+	flags := newFlags("account statement")
+	id := flags.Int64("id", 0, "account ID")
+	fromStr := flags.String("from", "", "first day (YYYY-MM-DD, default when the account was opened)")
+	toStr := flags.String("to", "", "last day (YYYY-MM-DD, default today)")
+	formatStr := flags.String("format", string(statement.FormatText), "text, csv, ofx or camt053")
+	out := flags.String("out", "", "file to write (default standard output)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := required(flags, "id"); err != nil {
+		return err
+	}
+
+	if err := a.releaseExpiredHolds(); err != nil {
+		return err
+	}
+
+	format, err := statement.ParseFormat(*formatStr)
+	if err != nil {
+		return err
+	}
+	var from, to time.Time
+	if *fromStr != "" {
+		if from, err = parseDate(*fromStr); err != nil {
+			return err
+		}
+	}
+	if *toStr != "" {
+		if to, err = parseDate(*toStr); err != nil {
+			return err
+		}
+	}
+
+	st, err := a.statements.Build(*id, from, to)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		return a.statements.Write(os.Stdout, st, format)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *out, err)
+	}
+	if err := a.statements.Write(f, st, format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Statement of account %d for %s to %s written to %s (%d transactions)\n", st.Account.ID,
+		st.From.Format(time.DateOnly), st.To.Format(time.DateOnly), *out, len(st.Lines))
+	return nil
+}
@@ -0,0 +1,89 @@
+package account
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"fmt"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeChecking Type = "checking"
+	TypeSavings  Type = "savings"
+
+	// TypeIncome is a bank's own account that collects the fees it charges.
+	// It has no customer.
+	TypeIncome Type = "income"
+
+	// TypeLoan is a bank's own account that loans are paid out of and
+	// repaid into. Its balance is minus the principal still lent out.
+	TypeLoan Type = "loan"
+
+	// TypeSuspense is a bank's own account that holds the money of
+	// transactions held for an anti-money-laundering review.
+	TypeSuspense Type = "suspense"
+)
+
+func ParseType(s string) (Type, error) {
+	switch Type(s) {
+	case TypeChecking, TypeSavings:
+		return Type(s), nil
+	}
+	return "", fmt.Errorf("unknown account type %q (expected %q or %q)", s, TypeChecking, TypeSavings)
+}
+
+// Account holds a customer's money at one bank. Balance is the ledger
+// balance in cents, what has been posted. Number
+// is the account number customers use, see package accountno.
+// Savings accounts reference the bank's savings product that sets their
+// interest rate.
+//
+// The bank can let the balance go below zero down to minus OverdraftLimit.
+// A negative balance is charged OverdraftRateBps a year, counted from
+// OverdraftSince, the moment the overdraft was last set.
+//
+// Held is money set aside by holds, such as card authorisations, that has
+// not been taken from the balance yet.
+type Account struct {
+	ID               int64      `json:"id"`
+	Number           string     `json:"number"`
+	BankID           int64      `json:"bankid"`
+	CustomerID       int64      `json:"customerid"`
+	Type             Type       `json:"type"`
+	ProductID        int64      `json:"productid,omitempty"`
+	Balance          int64      `json:"balance"`
+	Held             int64      `json:"held,omitempty"`
+	OverdraftLimit   int64      `json:"overdraftLimit,omitempty"`
+	OverdraftRateBps int64      `json:"overdraftRateBps,omitempty"`
+	OverdraftSince   *time.Time `json:"overdraftSince,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// Available is what can still be spent, the balance plus the overdraft limit
+// less what is held.
+func (a *Account) Available() int64 {
+	return a.Balance + a.OverdraftLimit - a.Held
+}
+
+// Internal reports whether the account belongs to the bank itself rather than
+// to a customer.
+func (a *Account) Internal() bool {
+	return a.CustomerID == 0
+}
+
+func NewAccount(id, bankID, customerID int64, accountType Type, productID int64) (*Account, error) {
+	number, err := accountno.New(bankID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		ID:         id,
+		Number:     number,
+		BankID:     bankID,
+		CustomerID: customerID,
+		Type:       accountType,
+		ProductID:  productID,
+		CreatedAt:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,218 @@
+package account
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	accounts []*Account // Cache for in-memory operations
+
+	// posting is held from checking an account's funds until the money has
+	// moved, see LockPostings
+	posting sync.Mutex
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		accounts: []*Account{},
+	}
+
+	if err := storage.LoadCollection(filePath, "accounts", &repo.accounts); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, account := range repo.accounts {
+		if account.ID >= repo.nextID {
+			repo.nextID = account.ID + 1
+		}
+		// accounts opened before there were account numbers get theirs
+		// here, it is saved with the next change
+		if account.Number == "" {
+			number, err := accountno.New(account.BankID, account.ID)
+			if err != nil {
+				return nil, fmt.Errorf("account %d: %w", account.ID, err)
+			}
+			account.Number = number
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "accounts", r.accounts); err != nil {
+		return fmt.Errorf("failed to save account data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(bankID, customerID int64, accountType Type, productID int64) (*Account, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	account, err := NewAccount(r.nextID, bankID, customerID, accountType, productID)
+	if err != nil {
+		return nil, err
+	}
+	r.accounts = append(r.accounts, account)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetOrCreateInternal returns the bank's internal account of the given type,
+// opening it the first time it is needed.
+func (r *Repository) GetOrCreateInternal(bankID int64, accountType Type) (*Account, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, account := range r.accounts {
+		if account.BankID == bankID && account.Internal() && account.Type == accountType {
+			return account, nil
+		}
+	}
+
+	account, err := NewAccount(r.nextID, bankID, 0, accountType, 0)
+	if err != nil {
+		return nil, err
+	}
+	r.accounts = append(r.accounts, account)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (r *Repository) GetByID(id int64) (*Account, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, account := range r.accounts {
+		if account.ID == id {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account with ID %d not found", id)
+}
+
+func (r *Repository) GetByCustomerID(customerID int64) []*Account {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	accounts := []*Account{}
+	for _, account := range r.accounts {
+		if account.CustomerID == customerID {
+			accounts = append(accounts, account)
+		}
+	}
+
+	return accounts
+}
+
+func (r *Repository) GetAll() []*Account {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Return a copy to avoid external modification
+	accounts := make([]*Account, len(r.accounts))
+	copy(accounts, r.accounts)
+
+	return accounts
+}
+
+// AdjustHeld changes the amount held on an account by delta.
+func (r *Repository) AdjustHeld(id, delta int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, account := range r.accounts {
+		if account.ID == id {
+			account.Held += delta
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("account with ID %d not found", id)
+}
+
+// SetOverdraft changes the overdraft limit and rate of an account.
+func (r *Repository) SetOverdraft(id, limit, rateBps int64) (*Account, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, account := range r.accounts {
+		if account.ID == id {
+			now := time.Now()
+			account.OverdraftLimit = limit
+			account.OverdraftRateBps = rateBps
+			account.OverdraftSince = &now
+
+			if err := r.saveData(); err != nil {
+				return nil, err
+			}
+
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account with ID %d not found", id)
+}
+
+// LockPostings keeps every other posting out until the returned func is
+// called, so two postings cannot both check and then spend the same money.
+func (r *Repository) LockPostings() (unlock func()) {
+	r.posting.Lock()
+	return r.posting.Unlock
+}
+
+// Adjust applies every delta (account ID -> cents) and saves once, so a
+// transfer never leaves one side updated without the other. If saving fails
+// no balance is changed.
+func (r *Repository) Adjust(deltas map[int64]int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var touched []*Account
+	for id := range deltas {
+		found := false
+		for _, account := range r.accounts {
+			if account.ID == id {
+				touched = append(touched, account)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("account with ID %d not found", id)
+		}
+	}
+
+	for _, account := range touched {
+		account.Balance += deltas[account.ID]
+	}
+	if err := r.saveData(); err != nil {
+		for _, account := range touched {
+			account.Balance -= deltas[account.ID]
+		}
+		return err
+	}
+	return nil
+}
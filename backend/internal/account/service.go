@@ -0,0 +1,116 @@
+package account
+
+import (
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/pkg/accountno"
+	"fmt"
+)
+
+type Service struct {
+	repo      *Repository
+	customers *customer.Service
+}
+
+func NewService(repo *Repository, customers *customer.Service) *Service {
+	return &Service{
+		repo:      repo,
+		customers: customers,
+	}
+}
+
+// OpenAccount opens an account for a verified customer, productID is the
+// savings product for savings accounts and must be 0 otherwise.
+func (s *Service) OpenAccount(bankID, customerID int64, accountType Type, productID int64) (*Account, error) {
+	if bankID <= 0 {
+		return nil, fmt.Errorf("invalid bank ID: %d", bankID)
+	}
+	if customerID <= 0 {
+		return nil, fmt.Errorf("invalid customer ID: %d", customerID)
+	}
+	if accountType == TypeSavings && productID <= 0 {
+		return nil, fmt.Errorf("a savings account needs a savings product")
+	}
+	if accountType != TypeSavings && productID != 0 {
+		return nil, fmt.Errorf("only savings accounts have a savings product")
+	}
+	c, err := s.customers.GetCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if c.BankID != bankID {
+		return nil, fmt.Errorf("customer %d is not a customer of bank %d", customerID, bankID)
+	}
+	if err := s.customers.CheckVerified(customerID); err != nil {
+		return nil, err
+	}
+
+	account, err := s.repo.Create(bankID, customerID, accountType, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (s *Service) GetAccount(id int64) (*Account, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid account ID: %d", id)
+	}
+
+	account, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return account, nil
+}
+
+// SetOverdraft lets a customer account go below zero down to minus limit.
+// rateBps is the annual interest charged on a negative balance.
+func (s *Service) SetOverdraft(id, limit, rateBps int64) (*Account, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("overdraft limit cannot be negative")
+	}
+	if rateBps < 0 || rateBps > 10000 {
+		return nil, fmt.Errorf("overdraft rate must be between 0%% and 100%%")
+	}
+
+	account, err := s.GetAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	if account.Internal() {
+		return nil, fmt.Errorf("internal bank accounts have no overdraft")
+	}
+
+	account, err = s.repo.SetOverdraft(id, limit, rateBps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set overdraft: %w", err)
+	}
+
+	return account, nil
+}
+
+// GetAccountByNumber finds an account by the number a person typed, which
+// is checked for typos first.
+func (s *Service) GetAccountByNumber(number string) (*Account, error) {
+	bankID, id, err := accountno.Parse(number)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.repo.GetByID(id)
+	if err != nil || account.BankID != bankID || account.Number != accountno.Normalize(number) {
+		return nil, fmt.Errorf("account %s does not exist", accountno.Group(number))
+	}
+
+	return account, nil
+}
+
+func (s *Service) GetCustomerAccounts(customerID int64) []*Account {
+	return s.repo.GetByCustomerID(customerID)
+}
+
+func (s *Service) GetAllAccounts() []*Account {
+	return s.repo.GetAll()
+}
@@ -0,0 +1,69 @@
+package aml
+
+import (
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/money"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+// HandleQueue lets a bank operator go through the bank's open alerts.
+func (h *Handler) HandleQueue(bankID, reviewerID int64) {
+	alerts := h.service.GetBankAlerts(bankID, StatusOpen)
+	if len(alerts) == 0 {
+		h.con.Println("No open alerts.")
+		return
+	}
+
+	h.con.Printf("%d open alert(s):\n", len(alerts))
+	h.con.Println("ID\tCustomer\tTransaction\tAmount\tRule\tHeld")
+	for _, a := range alerts {
+		h.con.Printf("%d\t%d\t%d\t%s\t%s\t%t\n", a.ID, a.CustomerID, a.TransactionID, money.Format(a.Amount), a.Rule, a.Held)
+	}
+
+	idStr, _ := h.con.Prompt("Alert ID to review (empty to go back): ")
+	if idStr == "" {
+		return
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.con.Printf("Invalid ID format: %s\n", idStr)
+		return
+	}
+	alert, err := h.service.GetAlert(id)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+
+	h.con.Printf("Alert %d on transaction %d of account %d: %s\n", alert.ID, alert.TransactionID, alert.AccountID, alert.Reason)
+	decision, _ := h.con.Prompt("Clear as a false positive or confirm as suspicious? (clear/confirm, empty to skip): ")
+	switch strings.ToLower(decision) {
+	case "clear":
+		note, _ := h.con.Prompt("Note (optional): ")
+		if _, err := h.service.Clear(bankID, id, reviewerID, note); err != nil {
+			h.con.Printf("Error: %v\n", err)
+			return
+		}
+		h.con.Printf("✅ Alert %d cleared.\n", id)
+	case "confirm":
+		note, _ := h.con.Prompt("Why is it suspicious? ")
+		if _, err := h.service.Confirm(bankID, id, reviewerID, note); err != nil {
+			h.con.Printf("Error: %v\n", err)
+			return
+		}
+		h.con.Printf("Alert %d confirmed.\n", id)
+	}
+}
@@ -0,0 +1,99 @@
+package aml
+
+import (
+	"fmt"
+	"time"
+)
+
+// Names of the monitoring rules, as recorded on alerts.
+const (
+	RuleLargeCash     = "large-cash"
+	RuleStructuring   = "structuring"
+	RuleRapidMovement = "rapid-movement"
+	RuleNewPayees     = "new-payees"
+)
+
+// Rules are the thresholds transactions are screened against. A rule with a
+// zero threshold is off. Hold keeps a matching transaction's money in the
+// bank's suspense account until an operator reviews the alert, otherwise
+// the transaction goes through and only raises the alert.
+type Rules struct {
+	LargeCash     LargeCash     `json:"largeCash"`
+	Structuring   Structuring   `json:"structuring"`
+	RapidMovement RapidMovement `json:"rapidMovement"`
+	NewPayees     NewPayees     `json:"newPayees"`
+}
+
+// LargeCash matches cash deposits and withdrawals of at least Threshold
+// (cents).
+type LargeCash struct {
+	Threshold int64 `json:"threshold"`
+	Hold      bool  `json:"hold"`
+}
+
+// Structuring matches a customer making Count or more cash deposits within
+// Window that each fall within Margin (cents) below the large cash
+// threshold, i.e. splitting a large deposit to stay under it.
+type Structuring struct {
+	Margin int64         `json:"margin"`
+	Count  int           `json:"count"`
+	Window time.Duration `json:"window"`
+	Hold   bool          `json:"hold"`
+}
+
+// RapidMovement matches money leaving an account soon after it came in: at
+// least ShareBps (basis points) of what was paid in within Window moving
+// out again, when that was at least Min (cents).
+type RapidMovement struct {
+	Min      int64         `json:"min"`
+	ShareBps int64         `json:"shareBps"`
+	Window   time.Duration `json:"window"`
+	Hold     bool          `json:"hold"`
+}
+
+// NewPayees matches transfers by a customer who saved Count or more payees
+// within Window.
+type NewPayees struct {
+	Count  int           `json:"count"`
+	Window time.Duration `json:"window"`
+	Hold   bool          `json:"hold"`
+}
+
+type Status string
+
+const (
+	StatusOpen Status = "open"
+	// StatusCleared alerts were false positives, a held transaction is
+	// released.
+	StatusCleared Status = "cleared"
+	// StatusConfirmed alerts were suspicious, a held transaction is returned
+	// to the payer.
+	StatusConfirmed Status = "confirmed"
+)
+
+func ParseStatus(s string) (Status, error) {
+	switch Status(s) {
+	case StatusOpen, StatusCleared, StatusConfirmed:
+		return Status(s), nil
+	}
+	return "", fmt.Errorf("unknown alert status %q (expected open, cleared or confirmed)", s)
+}
+
+// Alert is a transaction that matched a rule, waiting in the bank's case
+// queue. Held is whether the rule held the transaction.
+type Alert struct {
+	ID            int64      `json:"id"`
+	BankID        int64      `json:"bankid"`
+	CustomerID    int64      `json:"customerid"`
+	AccountID     int64      `json:"accountid"`
+	TransactionID int64      `json:"transactionid"`
+	Amount        int64      `json:"amount"`
+	Rule          string     `json:"rule"`
+	Reason        string     `json:"reason"`
+	Held          bool       `json:"held"`
+	Status        Status     `json:"status"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ReviewedBy    int64      `json:"reviewedBy,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewedAt,omitempty"`
+	Note          string     `json:"note,omitempty"`
+}
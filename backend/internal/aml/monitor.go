@@ -0,0 +1,198 @@
+package aml
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/payee"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+// Monitor screens every deposit, withdrawal and transfer against the rules
+// and opens alerts for the ones that match. It is the transactions service's
+// Screener.
+type Monitor struct {
+	repo     *Repository
+	txs      *transactions.Repository
+	accounts *account.Repository
+	payees   *payee.Repository
+	rules    Rules
+}
+
+func NewMonitor(repo *Repository, txs *transactions.Repository, accounts *account.Repository, payees *payee.Repository, rules Rules) *Monitor {
+	return &Monitor{
+		repo:     repo,
+		txs:      txs,
+		accounts: accounts,
+		payees:   payees,
+		rules:    rules,
+	}
+}
+
+func (m *Monitor) Screen(tx *transactions.Transaction, acc *account.Account) []transactions.Flag {
+	now := time.Now()
+
+	var flags []transactions.Flag
+	if reason, ok := m.largeCash(tx); ok {
+		flags = append(flags, transactions.Flag{Rule: RuleLargeCash, Reason: reason, Hold: m.rules.LargeCash.Hold})
+	}
+	if reason, ok := m.structuring(tx, acc, now); ok {
+		flags = append(flags, transactions.Flag{Rule: RuleStructuring, Reason: reason, Hold: m.rules.Structuring.Hold})
+	}
+	if reason, ok := m.rapidMovement(tx, acc, now); ok {
+		flags = append(flags, transactions.Flag{Rule: RuleRapidMovement, Reason: reason, Hold: m.rules.RapidMovement.Hold})
+	}
+	if reason, ok := m.newPayees(tx, acc, now); ok {
+		flags = append(flags, transactions.Flag{Rule: RuleNewPayees, Reason: reason, Hold: m.rules.NewPayees.Hold})
+	}
+
+	return flags
+}
+
+func (m *Monitor) Report(tx *transactions.Transaction, acc *account.Account, flags []transactions.Flag) error {
+	if len(flags) == 0 {
+		return nil
+	}
+
+	alerts := make([]Alert, 0, len(flags))
+	for _, f := range flags {
+		alerts = append(alerts, Alert{
+			BankID:        acc.BankID,
+			CustomerID:    acc.CustomerID,
+			AccountID:     acc.ID,
+			TransactionID: tx.Id,
+			Amount:        tx.Amount,
+			Rule:          f.Rule,
+			Reason:        f.Reason,
+			Held:          f.Hold,
+			Status:        StatusOpen,
+			CreatedAt:     tx.CreatedAt,
+		})
+	}
+
+	if err := m.repo.Create(alerts); err != nil {
+		return fmt.Errorf("failed to raise alert for transaction %d: %w", tx.Id, err)
+	}
+	return nil
+}
+
+func (m *Monitor) largeCash(tx *transactions.Transaction) (string, bool) {
+	rule := m.rules.LargeCash
+	if rule.Threshold == 0 || !cash(tx) || tx.Amount < rule.Threshold {
+		return "", false
+	}
+	return fmt.Sprintf("cash %s of %s, at or above %s", tx.Type, money.Format(tx.Amount), money.Format(rule.Threshold)), true
+}
+
+func (m *Monitor) structuring(tx *transactions.Transaction, acc *account.Account, now time.Time) (string, bool) {
+	rule := m.rules.Structuring
+	threshold := m.rules.LargeCash.Threshold
+	if threshold == 0 || rule.Margin == 0 || rule.Count == 0 || tx.Type != transactions.TypeDeposit {
+		return "", false
+	}
+
+	floor := threshold - rule.Margin
+	below := func(amount int64) bool { return amount >= floor && amount < threshold }
+	if !below(tx.Amount) {
+		return "", false
+	}
+
+	// deposits into any of the customer's accounts count, held ones too
+	owned := map[int64]bool{}
+	for _, a := range m.accounts.GetByCustomerID(acc.CustomerID) {
+		owned[a.ID] = true
+	}
+
+	count := 1
+	for _, past := range m.recent(now.Add(-rule.Window)) {
+		if past.Type == transactions.TypeDeposit && (owned[past.ToAccountID] || owned[past.HeldFor]) && below(past.Amount) {
+			count++
+		}
+	}
+	if count < rule.Count {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d cash deposits between %s and %s within %s", count, money.Format(floor), money.Format(threshold), describeWindow(rule.Window)), true
+}
+
+func (m *Monitor) rapidMovement(tx *transactions.Transaction, acc *account.Account, now time.Time) (string, bool) {
+	rule := m.rules.RapidMovement
+	if rule.Min == 0 || rule.ShareBps == 0 || tx.FromAccountID != acc.ID {
+		return "", false
+	}
+	if tx.Type != transactions.TypeWithdrawal && tx.Type != transactions.TypeTransfer {
+		return "", false
+	}
+
+	var in, out int64
+	for _, past := range m.recent(now.Add(-rule.Window)) {
+		switch {
+		case past.ToAccountID == acc.ID:
+			in += past.Amount
+		case past.FromAccountID == acc.ID && (past.Type == transactions.TypeWithdrawal || past.Type == transactions.TypeTransfer):
+			out += past.Amount
+		}
+	}
+	if in < rule.Min {
+		return "", false
+	}
+
+	// only the transaction that takes the account over the share matches
+	share := in * rule.ShareBps
+	if out*10000 >= share || (out+tx.Amount)*10000 < share {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s paid out within %s of %s paid in", money.Format(out+tx.Amount), describeWindow(rule.Window), money.Format(in)), true
+}
+
+func (m *Monitor) newPayees(tx *transactions.Transaction, acc *account.Account, now time.Time) (string, bool) {
+	rule := m.rules.NewPayees
+	if rule.Count == 0 || tx.Type != transactions.TypeTransfer {
+		return "", false
+	}
+
+	since := now.Add(-rule.Window)
+	count := 0
+	toNew := false
+	for _, p := range m.payees.GetByCustomerID(acc.CustomerID) {
+		if p.CreatedAt.After(since) {
+			count++
+			toNew = toNew || p.AccountID == tx.ToAccountID
+		}
+	}
+	if count < rule.Count || !toNew {
+		return "", false
+	}
+
+	return fmt.Sprintf("transfer to one of %d payees saved within %s", count, describeWindow(rule.Window)), true
+}
+
+// recent returns the transactions made after since.
+func (m *Monitor) recent(since time.Time) []*transactions.Transaction {
+	txs := []*transactions.Transaction{}
+	for _, tx := range m.txs.GetAll() {
+		if tx.CreatedAt.After(since) {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// cash is whether the transaction pays cash in or out, before it is held.
+func cash(tx *transactions.Transaction) bool {
+	return tx.Type == transactions.TypeDeposit || tx.Type == transactions.TypeWithdrawal
+}
+
+func describeWindow(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		days := int(d / (24 * time.Hour))
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.String()
+}
@@ -0,0 +1,86 @@
+package aml
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/transactions"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openDays is a bank that is always on today, never closed or frozen.
+type openDays struct{}
+
+func (openDays) BusinessDate(int64) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+func (openDays) Closed(int64, time.Time) bool { return false }
+func (openDays) Frozen(int64) (bool, error)   { return false, nil }
+
+type noJournal struct{}
+
+func (noJournal) Post(*transactions.Transaction) error { return nil }
+
+func TestHeldDepositIsReturnedWhenAlertFails(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db.json")
+
+	customers, err := customer.NewRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := customers.Create(1, 1, "Ada Lovelace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Status = customer.StatusVerified
+	if err := customers.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	accounts, err := account.NewRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := accounts.Create(1, c.ID, account.TypeChecking, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txRepo, err := transactions.NewRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the alerts are saved to a file in a folder that does not exist
+	alerts, err := NewRepository(filepath.Join(dir, "missing", "db.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitor := NewMonitor(alerts, txRepo, accounts, nil, Rules{LargeCash: LargeCash{Threshold: 100000, Hold: true}})
+	txs := transactions.NewService(txRepo, accounts, customer.NewService(customers, nil, nil), transactions.Limits{},
+		nil, nil, nil, monitor, noJournal{}, openDays{})
+
+	if _, err := txs.Deposit(acc.ID, 250000, "large cash"); err == nil || !strings.Contains(err.Error(), "failed to raise alert") {
+		t.Fatalf("Deposit = %v, want the alert to fail", err)
+	}
+
+	for _, a := range accounts.GetAll() {
+		if a.Balance != 0 {
+			t.Errorf("%s account %d balance = %d, want 0", a.Type, a.ID, a.Balance)
+		}
+	}
+
+	all := txs.GetAllTransactions()
+	if len(all) != 2 {
+		t.Fatalf("got %d transactions, want the held deposit and its reversal", len(all))
+	}
+	held, reversal := all[0], all[1]
+	if held.Status != transactions.StatusReturned {
+		t.Errorf("held deposit status = %q, want %q", held.Status, transactions.StatusReturned)
+	}
+	if reversal.Reverses != held.Id || reversal.FromAccountID != held.ToAccountID || reversal.ToAccountID != 0 {
+		t.Errorf("second transaction = %+v, want the deposit paid back out of suspense", reversal)
+	}
+}
@@ -0,0 +1,114 @@
+package aml
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	alerts   []*Alert // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		alerts:   []*Alert{},
+	}
+
+	if err := storage.LoadCollection(filePath, "alerts", &repo.alerts); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, alert := range repo.alerts {
+		if alert.ID >= repo.nextID {
+			repo.nextID = alert.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "alerts", r.alerts); err != nil {
+		return fmt.Errorf("failed to save alert data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(alerts []Alert) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, alert := range alerts {
+		alert.ID = r.nextID
+		r.alerts = append(r.alerts, &alert)
+		r.nextID++
+	}
+
+	return r.saveData()
+}
+
+func (r *Repository) Update(alert *Alert) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.alerts {
+		if existing.ID == alert.ID {
+			copied := *alert
+			r.alerts[i] = &copied
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("alert with ID %d not found", alert.ID)
+}
+
+func (r *Repository) GetByID(id int64) (*Alert, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, alert := range r.alerts {
+		if alert.ID == id {
+			copied := *alert
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("alert with ID %d not found", id)
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Alert {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	alerts := []*Alert{}
+	for _, alert := range r.alerts {
+		if alert.BankID == bankID {
+			copied := *alert
+			alerts = append(alerts, &copied)
+		}
+	}
+
+	return alerts
+}
+
+func (r *Repository) GetByTransactionID(transactionID int64) []*Alert {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	alerts := []*Alert{}
+	for _, alert := range r.alerts {
+		if alert.TransactionID == transactionID {
+			copied := *alert
+			alerts = append(alerts, &copied)
+		}
+	}
+
+	return alerts
+}
@@ -0,0 +1,124 @@
+package aml
+
+import (
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Service is the case queue of alerts for bank operators to review.
+type Service struct {
+	repo *Repository
+	txs  *transactions.Service
+}
+
+func NewService(repo *Repository, txs *transactions.Service) *Service {
+	return &Service{
+		repo: repo,
+		txs:  txs,
+	}
+}
+
+// GetBankAlerts returns the bank's alerts with the given status, all of them
+// when status is empty.
+func (s *Service) GetBankAlerts(bankID int64, status Status) []*Alert {
+	alerts := []*Alert{}
+	for _, alert := range s.repo.GetByBankID(bankID) {
+		if status == "" || alert.Status == status {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+func (s *Service) GetAlert(id int64) (*Alert, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid alert ID: %d", id)
+	}
+	return s.repo.GetByID(id)
+}
+
+// Clear closes an alert as a false positive. A held transaction is released
+// once none of its alerts holds it any more.
+func (s *Service) Clear(bankID, id, reviewerID int64, note string) (*Alert, error) {
+	alert, err := s.openAlert(bankID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.Held && s.stillHeld(alert) {
+		others := s.repo.GetByTransactionID(alert.TransactionID)
+		waiting := false
+		for _, other := range others {
+			waiting = waiting || (other.ID != alert.ID && other.Held && other.Status == StatusOpen)
+		}
+		if !waiting {
+			if _, err := s.txs.Release(alert.TransactionID, fmt.Sprintf("released after review of alert %d", alert.ID)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.close(alert, StatusCleared, reviewerID, note)
+}
+
+// Confirm closes an alert as suspicious. A held transaction is returned to
+// the payer straight away.
+func (s *Service) Confirm(bankID, id, reviewerID int64, note string) (*Alert, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, fmt.Errorf("give a note on why the transaction is suspicious")
+	}
+
+	alert, err := s.openAlert(bankID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.Held && s.stillHeld(alert) {
+		if _, err := s.txs.Return(alert.TransactionID, fmt.Sprintf("returned after review of alert %d", alert.ID)); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.close(alert, StatusConfirmed, reviewerID, note)
+}
+
+func (s *Service) openAlert(bankID, id int64) (*Alert, error) {
+	alert, err := s.GetAlert(id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.BankID != bankID {
+		return nil, fmt.Errorf("alert %d is not an alert of bank %d", id, bankID)
+	}
+	if alert.Status != StatusOpen {
+		return nil, fmt.Errorf("alert %d is already %s", id, alert.Status)
+	}
+	return alert, nil
+}
+
+// stillHeld is whether no other alert on the transaction was confirmed,
+// which would have returned the money already.
+func (s *Service) stillHeld(alert *Alert) bool {
+	for _, other := range s.repo.GetByTransactionID(alert.TransactionID) {
+		if other.Status == StatusConfirmed {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Service) close(alert *Alert, status Status, reviewerID int64, note string) (*Alert, error) {
+	now := time.Now()
+	alert.Status = status
+	alert.ReviewedBy = reviewerID
+	alert.ReviewedAt = &now
+	alert.Note = strings.TrimSpace(note)
+
+	if err := s.repo.Update(alert); err != nil {
+		return nil, fmt.Errorf("failed to update alert: %w", err)
+	}
+	return alert, nil
+}
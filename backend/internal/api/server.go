@@ -0,0 +1,171 @@
+package api
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/internal/transactions"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Server exposes a read-only JSON view of the database over HTTP. Writes still
+// go through the CLI so every change is made by an operator.
+type Server struct {
+	banks        *bank.Service
+	customers    *customer.Service
+	accounts     *account.Service
+	transactions *transactions.Service
+	holds        *hold.Service
+}
+
+func NewServer(banks *bank.Service, customers *customer.Service, accounts *account.Service, txs *transactions.Service, holds *hold.Service) *Server {
+	return &Server{
+		banks:        banks,
+		customers:    customers,
+		accounts:     accounts,
+		transactions: txs,
+		holds:        holds,
+	}
+}
+
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /banks", s.handleListBanks)
+	mux.HandleFunc("GET /banks/{id}", s.handleGetBank)
+	mux.HandleFunc("GET /customers", s.handleListCustomers)
+	mux.HandleFunc("GET /accounts", s.handleListAccounts)
+	mux.HandleFunc("GET /accounts/{id}", s.handleGetAccount)
+	mux.HandleFunc("GET /accounts/{id}/transactions", s.handleAccountTransactions)
+	mux.HandleFunc("GET /accounts/{id}/holds", s.handleAccountHolds)
+	return mux
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleListBanks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.banks.GetAllBanks())
+}
+
+func (s *Server) handleGetBank(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := s.banks.GetBank(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, b)
+}
+
+// customerView leaves out the details a customer sent for verification,
+// the API has no authentication.
+type customerView struct {
+	ID     int64           `json:"id"`
+	UserID int64           `json:"userid"`
+	BankID int64           `json:"bankid"`
+	Name   string          `json:"name"`
+	Status customer.Status `json:"status"`
+}
+
+func (s *Server) handleListCustomers(w http.ResponseWriter, r *http.Request) {
+	customers := s.customers.GetAllCustomers()
+	views := make([]customerView, len(customers))
+	for i, c := range customers {
+		views[i] = customerView{ID: c.ID, UserID: c.UserID, BankID: c.BankID, Name: c.Name, Status: c.Status}
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+// accountView is an account with its available balance next to the ledger
+// balance. The server never writes, so holds that have run out are left for
+// the next command to release and only kept out of the available balance.
+type accountView struct {
+	*account.Account
+	Available int64 `json:"available"`
+}
+
+func (s *Server) handleListAccounts(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	accounts := s.accounts.GetAllAccounts()
+	views := make([]accountView, len(accounts))
+	for i, acc := range accounts {
+		views[i] = accountView{acc, s.holds.Available(acc, now)}
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (s *Server) handleGetAccount(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	acc, err := s.accounts.GetAccount(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, accountView{acc, s.holds.Available(acc, time.Now())})
+}
+
+func (s *Server) handleAccountTransactions(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := s.accounts.GetAccount(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.transactions.GetAccountTransactions(id))
+}
+
+// handleAccountHolds lists the holds not yet settled, ?all=true lists every
+// hold.
+func (s *Server) handleAccountHolds(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := s.accounts.GetAccount(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.holds.GetAccountHolds(id, r.URL.Query().Get("all") != "true"))
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid ID format: " + idStr})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -1,111 +1,110 @@
 package bank
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"banking-app/backend/pkg/console"
 	"strconv"
-	"strings"
 )
 
 type Handler struct {
 	service *Service
+	con     *console.Console
 }
 
-func NewHandler(service *Service) *Handler {
+func NewHandler(service *Service, con *console.Console) *Handler {
 	return &Handler{
 		service: service,
+		con:     con,
 	}
 }
 
 func (h *Handler) HandleCreate(userID int64, name string) {
 	bank, err := h.service.CreateBank(userID, name)
 	if err != nil {
-		fmt.Printf("Error creating bank: %v\n", err)
+		h.con.Printf("Error creating bank: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Bank created successfully!\n")
-	fmt.Printf("ID: %d, Name: %s\n", bank.ID, bank.Name)
+	h.con.Printf("Bank created successfully!\n")
+	h.con.Printf("ID: %d, Name: %s\n", bank.ID, bank.Name)
 }
 
 func (h *Handler) HandleGet(idStr string) {
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		fmt.Printf("Invalid ID format: %s\n", idStr)
+		h.con.Printf("Invalid ID format: %s\n", idStr)
 		return
 	}
 
 	bank, err := h.service.GetBank(id)
 	if err != nil {
-		fmt.Printf("Error retrieving bank: %v\n", err)
+		h.con.Printf("Error retrieving bank: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Bank found:\n")
-	fmt.Printf("ID: %d, Name: %s\n", bank.ID, bank.Name)
+	h.con.Printf("Bank found:\n")
+	h.con.Printf("ID: %d, Name: %s\n", bank.ID, bank.Name)
 }
 
 func (h *Handler) HandleList() {
 	banks := h.service.GetAllBanks()
 
 	if len(banks) == 0 {
-		fmt.Println("No banks found.")
+		h.con.Println("No banks found.")
 		return
 	}
 
-	fmt.Printf("Found %d bank(s):\n", len(banks))
-	fmt.Println("ID\tName")
-	fmt.Println("--\t---------")
+	h.con.Printf("Found %d bank(s):\n", len(banks))
+	h.con.Println("ID\tName")
+	h.con.Println("--\t---------")
 
 	for _, bank := range banks {
-		fmt.Printf("%d\t%s\n", bank.ID, bank.Name)
+		h.con.Printf("%d\t%s\n", bank.ID, bank.Name)
 	}
 }
 
 func (h *Handler) HandleUpdate(idStr, name string) {
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		fmt.Printf("Invalid ID format: %s\n", idStr)
+		h.con.Printf("Invalid ID format: %s\n", idStr)
 		return
 	}
 
 	bank, err := h.service.UpdateBank(id, name)
 	if err != nil {
-		fmt.Printf("Error updating bank: %v\n", err)
+		h.con.Printf("Error updating bank: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Bank updated successfully!\n")
-	fmt.Printf("ID: %d, Name: %s\n", bank.ID, bank.Name)
+	h.con.Printf("Bank updated successfully!\n")
+	h.con.Printf("ID: %d, Name: %s\n", bank.ID, bank.Name)
 }
 
 func (h *Handler) HandleDelete(idStr string) {
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		fmt.Printf("Invalid ID format: %s\n", idStr)
+		h.con.Printf("Invalid ID format: %s\n", idStr)
 		return
 	}
 
 	err = h.service.DeleteBank(id)
 	if err != nil {
-		fmt.Printf("Error deleting bank: %v\n", err)
+		h.con.Printf("Error deleting bank: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Bank with ID %d deleted successfully!\n", id)
+	h.con.Printf("Bank with ID %d deleted successfully!\n", id)
 }
 
 func (h *Handler) NewBankLogin(userID int64) {
-	scanner := bufio.NewReader(os.Stdin)
-	bank, err := h.service.repo.GetBankByUserID(userID)
+	bank, err := h.service.GetBankByUserID(userID)
 	if err != nil {
-		fmt.Print("Enter bank name: ")
-		bankName, _ := scanner.ReadString('\n')
-		bankName = strings.TrimSpace(bankName) // Trim whitespace and newlines
+		bankName, err := h.con.Prompt("Enter bank name: ")
+		if err != nil {
+			return
+		}
 		h.HandleCreate(userID, bankName)
 	} else {
-		fmt.Printf("Welcome back, %s!\n", bank.Name)
+		h.con.Printf("Welcome back, %s!\n", bank.Name)
 	}
 }
 
@@ -0,0 +1,43 @@
+package bank
+
+import (
+	"banking-app/backend/pkg/console"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHandlerNewBankLogin(t *testing.T) {
+	s := NewService(NewRepository(filepath.Join(t.TempDir(), "db.json")), NameLimits{MinLength: 2, MaxLength: 50})
+	var out strings.Builder
+	con := console.New(strings.NewReader("Alice Bank\n"), &out)
+	h := NewHandler(s, con)
+
+	// the first login asks for the name of the bank to create
+	h.NewBankLogin(7)
+	b, err := s.GetBankByUserID(7)
+	if err != nil {
+		t.Fatalf("no bank created, printed %q", out.String())
+	}
+	if b.Name != "Alice Bank" {
+		t.Errorf("bank name = %q, want Alice Bank", b.Name)
+	}
+
+	// later ones welcome the operator back without a prompt
+	out.Reset()
+	h.NewBankLogin(7)
+	if got := out.String(); got != "Welcome back, Alice Bank!\n" {
+		t.Errorf("second login printed %q", got)
+	}
+}
+
+func TestHandlerNewBankLoginWithoutInput(t *testing.T) {
+	s := NewService(NewRepository(filepath.Join(t.TempDir(), "db.json")), NameLimits{MinLength: 2, MaxLength: 50})
+	con := console.New(strings.NewReader(""), &strings.Builder{})
+
+	NewHandler(s, con).NewBankLogin(7)
+
+	if b, err := s.GetBankByUserID(7); err == nil {
+		t.Errorf("created bank %q with no input", b.Name)
+	}
+}
@@ -13,3 +13,9 @@ func NewBank(id, userID int64, name string) *Bank {
 		Name:   name,
 	}
 }
+
+// NameLimits bounds the length of a bank name.
+type NameLimits struct {
+	MinLength int `json:"minLength"`
+	MaxLength int `json:"maxLength"`
+}
@@ -1,6 +1,7 @@
 package bank
 
 import (
+	"banking-app/backend/pkg/storage"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,7 +11,7 @@ import (
 
 // UnifiedDatabase represents the structure of database.json
 type UnifiedDatabase struct {
-	Banks     []*Bank `json:"banks"`
+	Banks     []*Bank       `json:"banks"`
 	Customers []interface{} `json:"customers"`
 	Users     []interface{} `json:"users"`
 }
@@ -80,44 +81,8 @@ func (r *Repository) loadDB() {
 }
 
 func (r *Repository) saveData() error {
-	// Load the existing unified database
-	var unifiedDB UnifiedDatabase
-	
-	if _, err := os.Stat(r.filePath); err == nil {
-		data, err := os.ReadFile(r.filePath)
-		if err == nil {
-			if err := json.Unmarshal(data, &unifiedDB); err != nil {
-				// If parsing fails, initialize with empty structure
-				unifiedDB = UnifiedDatabase{
-					Banks:     []*Bank{},
-					Customers: []interface{}{},
-					Users:     []interface{}{},
-				}
-			}
-		}
-	}
-	
-	// Ensure all fields are initialized
-	if unifiedDB.Banks == nil {
-		unifiedDB.Banks = []*Bank{}
-	}
-	if unifiedDB.Customers == nil {
-		unifiedDB.Customers = []interface{}{}
-	}
-	if unifiedDB.Users == nil {
-		unifiedDB.Users = []interface{}{}
-	}
-	
-	// Update the banks section
-	unifiedDB.Banks = r.banks
-	
-	// Marshal the entire unified database
-	data, err := json.MarshalIndent(unifiedDB, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal unified database: %w", err)
-	}
-
-	if err := os.WriteFile(r.filePath, data, 0644); err != nil {
+	// Only the banks section is replaced, the other collections are kept as-is
+	if err := storage.SaveCollection(r.filePath, "banks", r.banks); err != nil {
 		return fmt.Errorf("failed to write unified database: %w", err)
 	}
 
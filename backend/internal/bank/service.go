@@ -6,12 +6,14 @@ import (
 )
 
 type Service struct {
-	repo *Repository
+	repo   *Repository
+	limits NameLimits
 }
 
-func NewService(repo *Repository) *Service {
+func NewService(repo *Repository, limits NameLimits) *Service {
 	return &Service{
-		repo: repo,
+		repo:   repo,
+		limits: limits,
 	}
 }
 
@@ -46,6 +48,10 @@ func (s *Service) GetBank(id int64) (*Bank, error) {
 	return bank, nil
 }
 
+func (s *Service) GetBankByUserID(userID int64) (*Bank, error) {
+	return s.repo.GetBankByUserID(userID)
+}
+
 func (s *Service) GetAllBanks() []*Bank {
 	return s.repo.GetAll()
 }
@@ -81,11 +87,11 @@ func (s *Service) validateBankInput(name string) error {
 	if strings.TrimSpace(name) == "" {
 		return fmt.Errorf("input cannot be empty")
 	}
-	if len(name) < 2 {
-		return fmt.Errorf("input must be at least 2 characters long")
+	if len(name) < s.limits.MinLength {
+		return fmt.Errorf("input must be at least %d characters long", s.limits.MinLength)
 	}
-	if len(name) > 20 {
-		return fmt.Errorf("input cannot exceed 20 characters")
+	if len(name) > s.limits.MaxLength {
+		return fmt.Errorf("input cannot exceed %d characters", s.limits.MaxLength)
 	}
 
 	return nil
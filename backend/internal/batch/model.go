@@ -0,0 +1,110 @@
+package batch
+
+import (
+	"fmt"
+	"time"
+)
+
+// Format is the kind of payment file a batch was imported from.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatPain001 Format = "pain001"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatCSV, FormatPain001:
+		return Format(s), nil
+	case "pain.001", "xml":
+		return FormatPain001, nil
+	}
+	return "", fmt.Errorf("unknown payment file format %q (expected %q or %q)", s, FormatCSV, FormatPain001)
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // imported, not executed yet
+	StatusCompleted Status = "completed" // every valid line paid
+	StatusPartial   Status = "partial"   // some lines paid, some failed
+	StatusFailed    Status = "failed"    // no line could be paid
+)
+
+type LineStatus string
+
+const (
+	LineValid     LineStatus = "valid"
+	LineInvalid   LineStatus = "invalid"
+	LinePaid      LineStatus = "paid"
+	LineFailed    LineStatus = "failed"
+	LineDuplicate LineStatus = "duplicate"
+)
+
+// Line is one payment of a batch. Reference is the payer's own reference for
+// it, the end-to-end ID of pain.001, which must be unique for the account it
+// is paid from: a reference that was paid before is not paid again.
+type Line struct {
+	Number        int        `json:"number"`
+	Reference     string     `json:"reference"`
+	Name          string     `json:"name"`
+	Account       string     `json:"account"`
+	AccountID     int64      `json:"accountid,omitempty"`
+	Amount        int64      `json:"amount"`
+	Memo          string     `json:"memo,omitempty"`
+	Status        LineStatus `json:"status"`
+	Error         string     `json:"error,omitempty"`
+	TransactionID int64      `json:"transactionid,omitempty"`
+	PaidAt        *time.Time `json:"paidAt,omitempty"`
+}
+
+// Payable reports whether executing the batch pays the line, it is valid or
+// failed the last time.
+func (l *Line) Payable() bool {
+	return l.Status == LineValid || l.Status == LineFailed
+}
+
+// Batch is a payment file imported for one of a customer's accounts. Checksum
+// is the SHA-256 of the file, importing the same file again for the account
+// gives back this batch.
+type Batch struct {
+	ID         int64      `json:"id"`
+	CustomerID int64      `json:"customerid"`
+	AccountID  int64      `json:"accountid"`
+	FileName   string     `json:"fileName"`
+	Format     Format     `json:"format"`
+	Checksum   string     `json:"checksum"`
+	MessageID  string     `json:"messageId,omitempty"`
+	Status     Status     `json:"status"`
+	Lines      []Line     `json:"lines"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExecutedAt *time.Time `json:"executedAt,omitempty"`
+}
+
+// Totals counts the lines and adds up their amounts by status.
+type Totals struct {
+	Lines     int   `json:"lines"`
+	Payable   int   `json:"payable"`
+	ToPay     int64 `json:"toPay"`
+	Paid      int   `json:"paid"`
+	PaidTotal int64 `json:"paidTotal"`
+	Rejected  int   `json:"rejected"`
+}
+
+func (b *Batch) Totals() Totals {
+	t := Totals{Lines: len(b.Lines)}
+	for _, l := range b.Lines {
+		switch {
+		case l.Payable():
+			t.Payable++
+			t.ToPay += l.Amount
+		case l.Status == LinePaid:
+			t.Paid++
+			t.PaidTotal += l.Amount
+		default:
+			t.Rejected++
+		}
+	}
+	return t
+}
@@ -0,0 +1,195 @@
+package batch
+
+import (
+	"banking-app/backend/pkg/money"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// file is what a payment file holds before its lines are checked against the
+// accounts. Debtor and Currency are empty when the file does not say.
+type file struct {
+	MessageID string
+	Debtor    string
+	Lines     []rawLine
+}
+
+// rawLine is a line as written in the file. Amount is kept as text so a bad
+// amount makes the line invalid rather than the whole file.
+type rawLine struct {
+	Reference string
+	Name      string
+	Account   string
+	Amount    string
+	Currency  string
+	Memo      string
+}
+
+func parse(data []byte, format Format) (*file, error) {
+	if format == FormatPain001 {
+		return parsePain001(data)
+	}
+	return parseCSV(data)
+}
+
+// csvColumns are the columns a CSV payment file may have, by header name.
+// account, amount and reference are required.
+var csvColumns = []string{"reference", "name", "account", "amount", "currency", "memo"}
+
+// parseCSV reads a CSV payment file, a header row naming the columns and one
+// row per payment.
+func parseCSV(data []byte) (*file, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the payment file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		index[name] = i
+	}
+	for _, name := range []string{"account", "amount", "reference"} {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("the CSV header has no %q column (expected %s)", name, strings.Join(csvColumns, ","))
+		}
+	}
+
+	f := &file{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		f.Lines = append(f.Lines, rawLine{
+			Reference: field("reference"),
+			Name:      field("name"),
+			Account:   field("account"),
+			Amount:    field("amount"),
+			Currency:  field("currency"),
+			Memo:      field("memo"),
+		})
+	}
+
+	return f, nil
+}
+
+// painAccount is an account identification of pain.001, an IBAN or another
+// scheme. Account numbers of this bank are the other scheme.
+type painAccount struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+func (a painAccount) number() string {
+	if a.Other != "" {
+		return a.Other
+	}
+	return a.IBAN
+}
+
+// painDocument is the part of an ISO 20022 pain.001 customer credit transfer
+// initiation the importer reads. Elements are matched by name whatever the
+// version's namespace.
+type painDocument struct {
+	Header struct {
+		MessageID string `xml:"MsgId"`
+		Count     string `xml:"NbOfTxs"`
+		Sum       string `xml:"CtrlSum"`
+	} `xml:"CstmrCdtTrfInitn>GrpHdr"`
+	Payments []struct {
+		Debtor    painAccount `xml:"DbtrAcct"`
+		Transfers []struct {
+			EndToEndID string `xml:"PmtId>EndToEndId"`
+			Amount     struct {
+				Currency string `xml:"Ccy,attr"`
+				Value    string `xml:",chardata"`
+			} `xml:"Amt>InstdAmt"`
+			Creditor   string      `xml:"Cdtr>Nm"`
+			Account    painAccount `xml:"CdtrAcct"`
+			Remittance []string    `xml:"RmtInf>Ustrd"`
+		} `xml:"CdtTrfTxInf"`
+	} `xml:"CstmrCdtTrfInitn>PmtInf"`
+}
+
+// parsePain001 reads a pain.001 file. The number of transactions and the
+// control sum of the group header are checked when given.
+func parsePain001(data []byte) (*file, error) {
+	var doc painDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to read pain.001: %w", err)
+	}
+	if len(doc.Payments) == 0 {
+		return nil, fmt.Errorf("the pain.001 file has no payment information")
+	}
+
+	f := &file{MessageID: strings.TrimSpace(doc.Header.MessageID)}
+	var sum int64
+	for _, p := range doc.Payments {
+		debtor := strings.TrimSpace(p.Debtor.number())
+		if f.Debtor == "" {
+			f.Debtor = debtor
+		} else if debtor != "" && debtor != f.Debtor {
+			return nil, errors.New("every payment of a pain.001 file must be paid from the same account")
+		}
+
+		for _, t := range p.Transfers {
+			line := rawLine{
+				Reference: strings.TrimSpace(t.EndToEndID),
+				Name:      strings.TrimSpace(t.Creditor),
+				Account:   strings.TrimSpace(t.Account.number()),
+				Amount:    strings.TrimSpace(t.Amount.Value),
+				Currency:  strings.TrimSpace(t.Amount.Currency),
+				Memo:      strings.TrimSpace(strings.Join(t.Remittance, " ")),
+			}
+			// "NOTPROVIDED" is what pain.001 says when there is no reference
+			if line.Reference == "NOTPROVIDED" {
+				line.Reference = ""
+			}
+			if amount, err := money.Parse(line.Amount); err == nil {
+				sum += amount
+			}
+			f.Lines = append(f.Lines, line)
+		}
+	}
+
+	if count := strings.TrimSpace(doc.Header.Count); count != "" && count != fmt.Sprint(len(f.Lines)) {
+		return nil, fmt.Errorf("the group header says %s transactions but the file has %d", count, len(f.Lines))
+	}
+	if ctrl := strings.TrimSpace(doc.Header.Sum); ctrl != "" {
+		want, err := money.Parse(ctrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid control sum %q", ctrl)
+		}
+		if want != sum {
+			return nil, fmt.Errorf("the control sum %s does not match the transactions, which add up to %s", money.Format(want), money.Format(sum))
+		}
+	}
+
+	return f, nil
+}
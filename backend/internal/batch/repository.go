@@ -0,0 +1,135 @@
+package batch
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	batches  []*Batch // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		batches:  []*Batch{},
+	}
+
+	if err := storage.LoadCollection(filePath, "batches", &repo.batches); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, b := range repo.batches {
+		if b.ID >= repo.nextID {
+			repo.nextID = b.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "batches", r.batches); err != nil {
+		return fmt.Errorf("failed to save batch data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(b Batch) (*Batch, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	b.ID = r.nextID
+	r.batches = append(r.batches, copyBatch(&b))
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return copyBatch(&b), nil
+}
+
+// Update replaces a stored batch, lines and all.
+func (r *Repository) Update(b *Batch) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, stored := range r.batches {
+		if stored.ID == b.ID {
+			r.batches[i] = copyBatch(b)
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("batch with ID %d not found", b.ID)
+}
+
+func (r *Repository) GetByID(id int64) (*Batch, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, b := range r.batches {
+		if b.ID == id {
+			return copyBatch(b), nil
+		}
+	}
+
+	return nil, fmt.Errorf("batch with ID %d not found", id)
+}
+
+// GetByChecksum returns the batch imported from the same file for the
+// account, nil if there is none.
+func (r *Repository) GetByChecksum(accountID int64, checksum string) *Batch {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, b := range r.batches {
+		if b.AccountID == accountID && b.Checksum == checksum {
+			return copyBatch(b)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByAccountID(accountID int64) []*Batch {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	batches := []*Batch{}
+	for _, b := range r.batches {
+		if b.AccountID == accountID {
+			batches = append(batches, copyBatch(b))
+		}
+	}
+
+	return batches
+}
+
+func (r *Repository) GetByCustomerID(customerID int64) []*Batch {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	batches := []*Batch{}
+	for _, b := range r.batches {
+		if b.CustomerID == customerID {
+			batches = append(batches, copyBatch(b))
+		}
+	}
+
+	return batches
+}
+
+// copyBatch copies a batch with its lines, so callers never share the cache.
+func copyBatch(b *Batch) *Batch {
+	copied := *b
+	copied.Lines = append([]Line(nil), b.Lines...)
+	return &copied
+}
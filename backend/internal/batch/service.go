@@ -0,0 +1,292 @@
+package batch
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxReference is the longest reference a line may have, the length of a
+// pain.001 end-to-end ID.
+const maxReference = 35
+
+type Service struct {
+	repo      *Repository
+	accounts  *account.Service
+	customers *customer.Service
+	txs       *transactions.Service
+	screening *sanctions.Service
+	currency  string
+}
+
+// NewService makes the batch service. currency is the ISO 4217 code amounts
+// are in, lines in another currency are rejected.
+func NewService(repo *Repository, accounts *account.Service, customers *customer.Service, txs *transactions.Service,
+	screening *sanctions.Service, currency string) *Service {
+	return &Service{
+		repo:      repo,
+		accounts:  accounts,
+		customers: customers,
+		txs:       txs,
+		screening: screening,
+		currency:  currency,
+	}
+}
+
+// Import reads a payment file to be paid from one of the customer's
+// accounts and checks every line, without paying anything. The same file
+// imported again for the account gives back the batch it was imported as,
+// reported by the second result, so it is never paid twice.
+func (s *Service) Import(customerID int64, fromNumber, fileName string, data []byte, format Format) (*Batch, bool, error) {
+	from, err := s.accounts.GetAccountByNumber(fromNumber)
+	if err != nil {
+		return nil, false, err
+	}
+	if from.CustomerID != customerID {
+		return nil, false, fmt.Errorf("account %s does not belong to customer %d", accountno.Group(from.Number), customerID)
+	}
+	if err := s.customers.CheckVerified(customerID); err != nil {
+		return nil, false, err
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if existing := s.repo.GetByChecksum(from.ID, checksum); existing != nil {
+		return existing, true, nil
+	}
+
+	f, err := parse(data, format)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(f.Lines) == 0 {
+		return nil, false, fmt.Errorf("the payment file has no payments")
+	}
+	if f.Debtor != "" && accountno.Normalize(f.Debtor) != from.Number {
+		return nil, false, fmt.Errorf("the payment file pays from account %s, not %s", f.Debtor, accountno.Group(from.Number))
+	}
+
+	b := Batch{
+		CustomerID: customerID,
+		AccountID:  from.ID,
+		FileName:   fileName,
+		Format:     format,
+		Checksum:   checksum,
+		MessageID:  f.MessageID,
+		Status:     StatusPending,
+		CreatedAt:  time.Now(),
+	}
+	paid := s.paidReferences(from.ID)
+	seen := map[string]int{}
+	for i, raw := range f.Lines {
+		b.Lines = append(b.Lines, s.check(i+1, raw, from, seen, paid))
+	}
+
+	created, err := s.repo.Create(b)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to save batch: %w", err)
+	}
+	return created, false, nil
+}
+
+// check validates one line of a payment file. seen holds the references of
+// the lines before it, paid the references already paid from the account.
+func (s *Service) check(number int, raw rawLine, from *account.Account, seen map[string]int, paid map[string]int64) Line {
+	line := Line{
+		Number:    number,
+		Reference: raw.Reference,
+		Name:      raw.Name,
+		Account:   raw.Account,
+		Memo:      raw.Memo,
+		Status:    LineValid,
+	}
+	invalid := func(format string, args ...any) Line {
+		line.Status = LineInvalid
+		line.Error = fmt.Sprintf(format, args...)
+		return line
+	}
+
+	amount, err := money.Parse(raw.Amount)
+	if err != nil {
+		return invalid("%v", err)
+	}
+	line.Amount = amount
+	if amount <= 0 {
+		return invalid("amount must be positive")
+	}
+	if raw.Currency != "" && !strings.EqualFold(raw.Currency, s.currency) {
+		return invalid("currency %s is not %s", raw.Currency, s.currency)
+	}
+
+	if raw.Reference == "" {
+		return invalid("reference is missing")
+	}
+	if len(raw.Reference) > maxReference {
+		return invalid("reference is longer than %d characters", maxReference)
+	}
+	if first, ok := seen[raw.Reference]; ok {
+		return invalid("reference repeats line %d", first)
+	}
+	seen[raw.Reference] = number
+
+	to, err := s.accounts.GetAccountByNumber(raw.Account)
+	if err != nil {
+		return invalid("%v", err)
+	}
+	line.AccountID = to.ID
+	if line.Name == "" {
+		line.Name = accountno.Group(to.Number)
+	}
+	if to.ID == from.ID {
+		return invalid("cannot pay the account the batch is paid from")
+	}
+	if to.Internal() {
+		return invalid("account %s cannot be paid", accountno.Group(to.Number))
+	}
+
+	if batchID, ok := paid[raw.Reference]; ok {
+		line.Status = LineDuplicate
+		line.Error = fmt.Sprintf("reference already paid in batch %d", batchID)
+	}
+	return line
+}
+
+// paidReferences maps the references paid from an account to the batch that
+// paid them.
+func (s *Service) paidReferences(accountID int64) map[string]int64 {
+	paid := map[string]int64{}
+	for _, b := range s.repo.GetByAccountID(accountID) {
+		for _, l := range b.Lines {
+			if l.Status == LinePaid {
+				paid[l.Reference] = b.ID
+			}
+		}
+	}
+	return paid
+}
+
+// Quote returns the fees executing the batch would charge, so they can be
+// shown before the customer confirms.
+func (s *Service) Quote(customerID, id int64) (int64, error) {
+	b, err := s.GetBatch(customerID, id)
+	if err != nil {
+		return 0, err
+	}
+
+	var charge int64
+	for _, l := range b.Lines {
+		if !l.Payable() {
+			continue
+		}
+		c, err := s.txs.QuoteTransfer(b.AccountID, l.Amount)
+		if err != nil {
+			return 0, err
+		}
+		charge += c
+	}
+	return charge, nil
+}
+
+// Execute pays every line of a batch that is valid or failed before, in
+// order. A line that fails does not stop the others. The batch is saved
+// after every payment, so whatever happens a paid line is never paid again.
+func (s *Service) Execute(customerID, id int64) (*Batch, error) {
+	b, err := s.GetBatch(customerID, id)
+	if err != nil {
+		return nil, err
+	}
+	from, err := s.accounts.GetAccount(b.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	paid := s.paidReferences(b.AccountID)
+	for i := range b.Lines {
+		l := &b.Lines[i]
+		if !l.Payable() {
+			continue
+		}
+		// another batch may have paid it since this one was imported
+		if batchID, ok := paid[l.Reference]; ok {
+			l.Status = LineDuplicate
+			l.Error = fmt.Sprintf("reference already paid in batch %d", batchID)
+		} else {
+			s.pay(from, l)
+			if l.Status == LinePaid {
+				paid[l.Reference] = b.ID
+			}
+		}
+
+		if err := s.repo.Update(b); err != nil {
+			return nil, fmt.Errorf("failed to save batch: %w", err)
+		}
+	}
+
+	now := time.Now()
+	b.ExecutedAt = &now
+	switch t := b.Totals(); {
+	case t.Paid == 0:
+		b.Status = StatusFailed
+	case t.Payable > 0:
+		b.Status = StatusPartial
+	default:
+		b.Status = StatusCompleted
+	}
+	if err := s.repo.Update(b); err != nil {
+		return nil, fmt.Errorf("failed to save batch: %w", err)
+	}
+
+	return b, nil
+}
+
+// pay makes the transfer of one line, screening the name it is paid to like
+// a payee's. The line is paid once the money has moved, even if what comes
+// after (a fee, a notification) failed, that error is kept on the line.
+func (s *Service) pay(from *account.Account, l *Line) {
+	subject := sanctions.Subject{Kind: sanctions.KindPayee, BankID: from.BankID, CustomerID: from.CustomerID, Name: l.Name}
+	if err := s.screening.Screen(subject); err != nil {
+		l.Status = LineFailed
+		l.Error = err.Error()
+		return
+	}
+
+	memo := l.Memo
+	if memo == "" {
+		memo = l.Reference
+	}
+	tx, err := s.txs.TransferToPayee(from.ID, l.AccountID, l.Amount, l.Name, memo)
+	if tx == nil {
+		l.Status = LineFailed
+		l.Error = err.Error()
+		return
+	}
+
+	l.Status = LinePaid
+	l.Error = ""
+	if err != nil {
+		l.Error = err.Error()
+	}
+	l.TransactionID = tx.Id
+	l.PaidAt = &tx.CreatedAt
+}
+
+// GetBatch returns one of the customer's batches.
+func (s *Service) GetBatch(customerID, id int64) (*Batch, error) {
+	b, err := s.repo.GetByID(id)
+	if err != nil || b.CustomerID != customerID {
+		return nil, fmt.Errorf("customer %d has no batch %d", customerID, id)
+	}
+	return b, nil
+}
+
+func (s *Service) GetCustomerBatches(customerID int64) []*Batch {
+	return s.repo.GetByCustomerID(customerID)
+}
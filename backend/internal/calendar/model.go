@@ -0,0 +1,15 @@
+package calendar
+
+import "time"
+
+// Holiday is a day a bank does not execute payments on. Date is YYYY-MM-DD.
+type Holiday struct {
+	BankID int64  `json:"bankid"`
+	Date   string `json:"date"`
+	Name   string `json:"name"`
+}
+
+// Day returns the YYYY-MM-DD form of t that holidays are stored under.
+func Day(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	holidays []*Holiday // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		holidays: []*Holiday{},
+	}
+
+	if err := storage.LoadCollection(filePath, "holidays", &repo.holidays); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "holidays", r.holidays); err != nil {
+		return fmt.Errorf("failed to save holiday data: %w", err)
+	}
+	return nil
+}
+
+// Add stores a holiday, renaming it if the bank already has one that day.
+func (r *Repository) Add(holiday Holiday) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.holidays {
+		if existing.BankID == holiday.BankID && existing.Date == holiday.Date {
+			existing.Name = holiday.Name
+			return r.saveData()
+		}
+	}
+
+	r.holidays = append(r.holidays, &holiday)
+	sort.Slice(r.holidays, func(i, j int) bool {
+		if r.holidays[i].BankID != r.holidays[j].BankID {
+			return r.holidays[i].BankID < r.holidays[j].BankID
+		}
+		return r.holidays[i].Date < r.holidays[j].Date
+	})
+
+	return r.saveData()
+}
+
+func (r *Repository) Remove(bankID int64, date string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.holidays {
+		if existing.BankID == bankID && existing.Date == date {
+			r.holidays = append(r.holidays[:i], r.holidays[i+1:]...)
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("bank %d has no holiday on %s", bankID, date)
+}
+
+func (r *Repository) Get(bankID int64, date string) *Holiday {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, holiday := range r.holidays {
+		if holiday.BankID == bankID && holiday.Date == date {
+			copied := *holiday
+			return &copied
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Holiday {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	holidays := []*Holiday{}
+	for _, holiday := range r.holidays {
+		if holiday.BankID == bankID {
+			copied := *holiday
+			holidays = append(holidays, &copied)
+		}
+	}
+
+	return holidays
+}
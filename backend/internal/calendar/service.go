@@ -0,0 +1,61 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) AddHoliday(bankID int64, date, name string) error {
+	if bankID <= 0 {
+		return fmt.Errorf("invalid bank ID: %d", bankID)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("holiday name cannot be empty")
+	}
+
+	return s.repo.Add(Holiday{BankID: bankID, Date: date, Name: name})
+}
+
+func (s *Service) RemoveHoliday(bankID int64, date string) error {
+	return s.repo.Remove(bankID, date)
+}
+
+func (s *Service) GetBankHolidays(bankID int64) []*Holiday {
+	return s.repo.GetByBankID(bankID)
+}
+
+// IsHoliday reports whether the bank is closed on the day of t.
+func (s *Service) IsHoliday(bankID int64, t time.Time) bool {
+	return s.repo.Get(bankID, Day(t)) != nil
+}
+
+// IsBusinessDay reports whether the bank is open on the day of t, which is
+// never on a Saturday or Sunday.
+func (s *Service) IsBusinessDay(bankID int64, t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !s.IsHoliday(bankID, t)
+}
+
+// NextBusinessDay returns t, or the first day after it the bank is open.
+func (s *Service) NextBusinessDay(bankID int64, t time.Time) time.Time {
+	for !s.IsBusinessDay(bankID, t) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
@@ -0,0 +1,89 @@
+package card
+
+import (
+	"banking-app/backend/pkg/cardno"
+	"time"
+)
+
+// Status is whether a card can be used.
+type Status string
+
+const (
+	StatusActive Status = "active"
+	// StatusFrozen cards are declined until unfrozen, they are frozen by the
+	// bank or after too many wrong PINs.
+	StatusFrozen Status = "frozen"
+	// StatusCancelled cards can never be used again.
+	StatusCancelled Status = "cancelled"
+)
+
+// Limits cap what can be spent with a card, in cents. 0 is no cap.
+type Limits struct {
+	PerPurchase int64 `json:"perPurchase,omitempty"`
+	Daily       int64 `json:"daily,omitempty"`
+}
+
+// Card is a virtual debit card that pays out of a customer account. The PIN
+// is only kept as a salted hash.
+type Card struct {
+	ID         int64     `json:"id"`
+	BankID     int64     `json:"bankid"`
+	CustomerID int64     `json:"customerid"`
+	AccountID  int64     `json:"accountid"`
+	PAN        string    `json:"pan"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	PINHash    string    `json:"pinHash"`
+	Status     Status    `json:"status"`
+	Limits     Limits    `json:"limits"`
+	FailedPINs int       `json:"failedPins,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Masked is the card number as it may be shown.
+func (c *Card) Masked() string {
+	return cardno.Mask(c.PAN)
+}
+
+// Expiry is the expiry date printed on the card, MM/YY. ExpiresAt is the
+// first moment the card no longer works, the start of the next month.
+func (c *Card) Expiry() string {
+	return c.ExpiresAt.AddDate(0, 0, -1).Format("01/06")
+}
+
+// Expired reports whether the card has expired at t.
+func (c *Card) Expired(t time.Time) bool {
+	return !t.Before(c.ExpiresAt)
+}
+
+// AuthStatus is where a card payment is.
+type AuthStatus string
+
+const (
+	AuthApproved AuthStatus = "approved"
+	AuthDeclined AuthStatus = "declined"
+	// AuthCaptured payments were taken from the account.
+	AuthCaptured AuthStatus = "captured"
+	// AuthReleased payments were cancelled by the merchant, the money can be
+	// spent again.
+	AuthReleased AuthStatus = "released"
+	// AuthExpired payments were not captured before the hold ran out.
+	AuthExpired AuthStatus = "expired"
+)
+
+// Authorisation is a merchant's request to take a card payment. An approved
+// authorisation holds the amount on the account until the merchant captures
+// or releases it. Declined ones are kept with the reason.
+type Authorisation struct {
+	ID             int64      `json:"id"`
+	CardID         int64      `json:"cardid"`
+	AccountID      int64      `json:"accountid"`
+	Merchant       string     `json:"merchant"`
+	Amount         int64      `json:"amount"`
+	Status         AuthStatus `json:"status"`
+	DeclineReason  string     `json:"declineReason,omitempty"`
+	HoldID         int64      `json:"holdid,omitempty"`
+	CapturedAmount int64      `json:"capturedAmount,omitempty"`
+	TransactionID  int64      `json:"transactionid,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	SettledAt      *time.Time `json:"settledAt,omitempty"`
+}
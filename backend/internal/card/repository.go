@@ -0,0 +1,216 @@
+package card
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath   string
+	mutex      sync.RWMutex
+	nextID     int64
+	nextAuthID int64
+	cards      []*Card // Cache for in-memory operations
+	auths      []*Authorisation
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath:   filePath,
+		nextID:     1,
+		nextAuthID: 1,
+		cards:      []*Card{},
+		auths:      []*Authorisation{},
+	}
+
+	if err := storage.LoadCollection(filePath, "cards", &repo.cards); err != nil {
+		return nil, err
+	}
+	if err := storage.LoadCollection(filePath, "authorisations", &repo.auths); err != nil {
+		return nil, err
+	}
+
+	// find the highest IDs to set nextID and nextAuthID correctly
+	for _, c := range repo.cards {
+		if c.ID >= repo.nextID {
+			repo.nextID = c.ID + 1
+		}
+	}
+	for _, a := range repo.auths {
+		if a.ID >= repo.nextAuthID {
+			repo.nextAuthID = a.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveCards() error {
+	if err := storage.SaveCollection(r.filePath, "cards", r.cards); err != nil {
+		return fmt.Errorf("failed to save card data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) saveAuths() error {
+	if err := storage.SaveCollection(r.filePath, "authorisations", r.auths); err != nil {
+		return fmt.Errorf("failed to save authorisation data: %w", err)
+	}
+	return nil
+}
+
+// Create stores a new card. build is given the card's ID, which the card
+// number is made from.
+func (r *Repository) Create(build func(id int64) (Card, error)) (*Card, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, err := build(r.nextID)
+	if err != nil {
+		return nil, err
+	}
+	c.ID = r.nextID
+	r.cards = append(r.cards, &c)
+	r.nextID++
+
+	if err := r.saveCards(); err != nil {
+		return nil, err
+	}
+
+	copied := c
+	return &copied, nil
+}
+
+func (r *Repository) Update(c *Card) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.cards {
+		if existing.ID == c.ID {
+			copied := *c
+			r.cards[i] = &copied
+			return r.saveCards()
+		}
+	}
+
+	return fmt.Errorf("card with ID %d not found", c.ID)
+}
+
+func (r *Repository) GetByID(id int64) (*Card, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, c := range r.cards {
+		if c.ID == id {
+			copied := *c
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("card with ID %d not found", id)
+}
+
+func (r *Repository) GetByPAN(pan string) (*Card, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, c := range r.cards {
+		if c.PAN == pan {
+			copied := *c
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("card not found")
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Card {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	cards := []*Card{}
+	for _, c := range r.cards {
+		if c.BankID == bankID {
+			copied := *c
+			cards = append(cards, &copied)
+		}
+	}
+
+	return cards
+}
+
+func (r *Repository) GetByCustomerID(customerID int64) []*Card {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	cards := []*Card{}
+	for _, c := range r.cards {
+		if c.CustomerID == customerID {
+			copied := *c
+			cards = append(cards, &copied)
+		}
+	}
+
+	return cards
+}
+
+func (r *Repository) CreateAuth(a Authorisation) (*Authorisation, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	a.ID = r.nextAuthID
+	r.auths = append(r.auths, &a)
+	r.nextAuthID++
+
+	if err := r.saveAuths(); err != nil {
+		return nil, err
+	}
+
+	copied := a
+	return &copied, nil
+}
+
+func (r *Repository) UpdateAuth(a *Authorisation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.auths {
+		if existing.ID == a.ID {
+			copied := *a
+			r.auths[i] = &copied
+			return r.saveAuths()
+		}
+	}
+
+	return fmt.Errorf("authorisation with ID %d not found", a.ID)
+}
+
+func (r *Repository) GetAuth(id int64) (*Authorisation, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, a := range r.auths {
+		if a.ID == id {
+			copied := *a
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("authorisation with ID %d not found", id)
+}
+
+func (r *Repository) GetAuthsByCardID(cardID int64) []*Authorisation {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	auths := []*Authorisation{}
+	for _, a := range r.auths {
+		if a.CardID == cardID {
+			copied := *a
+			auths = append(auths, &copied)
+		}
+	}
+
+	return auths
+}
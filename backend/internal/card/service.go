@@ -0,0 +1,419 @@
+package card
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/cardno"
+	"banking-app/backend/pkg/money"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ValidYears is how long a new card works for.
+	ValidYears = 3
+	// MaxFailedPINs wrong PINs in a row freeze the card.
+	MaxFailedPINs = 3
+)
+
+// ErrDeclined is returned, wrapped with the reason, when an authorisation is
+// declined. The declined authorisation is still recorded.
+var ErrDeclined = errors.New("declined")
+
+type Service struct {
+	repo      *Repository
+	accounts  *account.Repository
+	customers *customer.Service
+	holds     *hold.Service
+	txs       *transactions.Service
+}
+
+func NewService(repo *Repository, accounts *account.Repository, customers *customer.Service, holds *hold.Service, txs *transactions.Service) *Service {
+	return &Service{
+		repo:      repo,
+		accounts:  accounts,
+		customers: customers,
+		holds:     holds,
+		txs:       txs,
+	}
+}
+
+// Issue gives the owner of a customer account a new card that pays out of
+// it. The card works until the end of the month ValidYears from now.
+func (s *Service) Issue(accountID int64, pin string, limits Limits) (*Card, error) {
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Internal() {
+		return nil, fmt.Errorf("cards cannot be issued on internal bank accounts")
+	}
+	if err := s.customers.CheckVerified(acc.CustomerID); err != nil {
+		return nil, err
+	}
+	if err := validatePIN(pin); err != nil {
+		return nil, err
+	}
+	if err := validateLimits(limits); err != nil {
+		return nil, err
+	}
+	pinHash, err := hashPIN(pin)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expires := time.Date(now.Year()+ValidYears, now.Month()+1, 1, 0, 0, 0, 0, time.Local)
+
+	c, err := s.repo.Create(func(id int64) (Card, error) {
+		pan, err := cardno.New(acc.BankID, id)
+		if err != nil {
+			return Card{}, err
+		}
+		return Card{
+			BankID:     acc.BankID,
+			CustomerID: acc.CustomerID,
+			AccountID:  acc.ID,
+			PAN:        pan,
+			ExpiresAt:  expires,
+			PINHash:    pinHash,
+			Status:     StatusActive,
+			Limits:     limits,
+			CreatedAt:  now,
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue card: %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *Service) GetCard(id int64) (*Card, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid card ID: %d", id)
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *Service) GetBankCards(bankID int64) []*Card {
+	return s.repo.GetByBankID(bankID)
+}
+
+func (s *Service) GetCustomerCards(customerID int64) []*Card {
+	return s.repo.GetByCustomerID(customerID)
+}
+
+// GetCardAuthorisations returns the authorisations made with a card,
+// marking the approved ones whose hold has expired.
+func (s *Service) GetCardAuthorisations(cardID int64) ([]*Authorisation, error) {
+	auths := s.repo.GetAuthsByCardID(cardID)
+	for _, auth := range auths {
+		if err := s.refresh(auth); err != nil {
+			return nil, err
+		}
+	}
+	return auths, nil
+}
+
+func (s *Service) SetLimits(id int64, limits Limits) (*Card, error) {
+	if err := validateLimits(limits); err != nil {
+		return nil, err
+	}
+	c, err := s.GetCard(id)
+	if err != nil {
+		return nil, err
+	}
+	c.Limits = limits
+	return c, s.save(c)
+}
+
+func (s *Service) Freeze(id int64) (*Card, error) {
+	return s.setStatus(id, StatusFrozen)
+}
+
+// Unfreeze lets a frozen card be used again and forgets earlier wrong PINs.
+func (s *Service) Unfreeze(id int64) (*Card, error) {
+	return s.setStatus(id, StatusActive)
+}
+
+// Cancel stops a card for good. Payments already authorised can still be
+// captured.
+func (s *Service) Cancel(id int64) (*Card, error) {
+	return s.setStatus(id, StatusCancelled)
+}
+
+// Authorise is a merchant asking to take amount with a card. The card, its
+// expiry and PIN and the card limits are checked, and the amount is held on
+// the account until the merchant captures or releases it.
+func (s *Service) Authorise(pan, expiry, pin string, amount int64, merchant string) (*Authorisation, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	merchant = strings.TrimSpace(merchant)
+	if merchant == "" {
+		return nil, fmt.Errorf("merchant name is required")
+	}
+	pan, err := cardno.Parse(pan)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.repo.GetByPAN(pan)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := Authorisation{
+		CardID:    c.ID,
+		AccountID: c.AccountID,
+		Merchant:  merchant,
+		Amount:    amount,
+		CreatedAt: time.Now(),
+	}
+
+	reason, err := s.check(c, expiry, pin, amount, auth.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		h, err := s.holds.Place(c.AccountID, amount, fmt.Sprintf("card %s at %s", c.PAN[cardno.Length-4:], merchant))
+		switch {
+		case errors.Is(err, transactions.ErrInsufficientFunds):
+			reason = "insufficient funds"
+		case err != nil:
+			return nil, err
+		default:
+			auth.HoldID = h.ID
+		}
+	}
+
+	if reason != "" {
+		auth.Status = AuthDeclined
+		auth.DeclineReason = reason
+	} else {
+		auth.Status = AuthApproved
+	}
+
+	created, err := s.repo.CreateAuth(auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record authorisation: %w", err)
+	}
+	if reason != "" {
+		return created, fmt.Errorf("%w: %s", ErrDeclined, reason)
+	}
+	return created, nil
+}
+
+// Capture takes an approved payment from the account. amount can be less
+// than was authorised, e.g. for a partial shipment, 0 captures all of it.
+func (s *Service) Capture(authID, amount int64) (*Authorisation, error) {
+	auth, err := s.approvedAuth(authID)
+	if err != nil {
+		return nil, err
+	}
+	if amount == 0 {
+		amount = auth.Amount
+	}
+	if amount < 0 || amount > auth.Amount {
+		return nil, fmt.Errorf("capture amount must be between 0.01 and the authorised %s", money.Format(auth.Amount))
+	}
+
+	// the purchase is posted before the hold is settled, so a purchase that
+	// fails leaves the authorisation to be captured again
+	h, err := s.holds.GetHold(auth.HoldID)
+	if err != nil {
+		return nil, err
+	}
+	if h.Status != hold.StatusActive {
+		return nil, fmt.Errorf("hold %d is already %s", h.ID, h.Status)
+	}
+	if h.Expired(time.Now()) {
+		if _, err := s.holds.Capture(auth.HoldID); err != nil && !errors.Is(err, hold.ErrExpired) {
+			return nil, err
+		}
+		if err := s.expire(auth); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("authorisation %d has expired", auth.ID)
+	}
+
+	tx, err := s.txs.CardPurchase(auth.AccountID, amount, auth.Merchant, fmt.Sprintf("card authorisation %d", auth.ID))
+	if tx == nil {
+		return nil, err
+	}
+	if _, herr := s.holds.Capture(auth.HoldID); herr != nil {
+		err = errors.Join(err, herr)
+	}
+
+	now := time.Now()
+	auth.Status = AuthCaptured
+	auth.CapturedAmount = amount
+	auth.TransactionID = tx.Id
+	auth.SettledAt = &now
+	if err := s.repo.UpdateAuth(auth); err != nil {
+		return nil, fmt.Errorf("failed to update authorisation: %w", err)
+	}
+
+	return auth, err
+}
+
+// Release cancels an approved payment, the held money can be spent again.
+func (s *Service) Release(authID int64) (*Authorisation, error) {
+	auth, err := s.approvedAuth(authID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.holds.Release(auth.HoldID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	auth.Status = AuthReleased
+	auth.SettledAt = &now
+	if err := s.repo.UpdateAuth(auth); err != nil {
+		return nil, fmt.Errorf("failed to update authorisation: %w", err)
+	}
+
+	return auth, nil
+}
+
+// check returns why a card payment should be declined, "" to approve it. A
+// wrong PIN counts towards freezing the card, a right one resets the count.
+func (s *Service) check(c *Card, expiry, pin string, amount int64, now time.Time) (string, error) {
+	switch c.Status {
+	case StatusFrozen:
+		return "card frozen", nil
+	case StatusCancelled:
+		return "card cancelled", nil
+	}
+	if c.Expired(now) {
+		return "card expired", nil
+	}
+	if strings.TrimSpace(expiry) != c.Expiry() {
+		return "wrong expiry date", nil
+	}
+
+	if !checkPIN(c.PINHash, pin) {
+		c.FailedPINs++
+		reason := "wrong PIN"
+		if c.FailedPINs >= MaxFailedPINs {
+			c.Status = StatusFrozen
+			reason = "wrong PIN, card frozen"
+		}
+		return reason, s.save(c)
+	}
+	if c.FailedPINs > 0 {
+		c.FailedPINs = 0
+		if err := s.save(c); err != nil {
+			return "", err
+		}
+	}
+
+	if err := s.customers.CheckVerified(c.CustomerID); err != nil {
+		return "customer not verified", nil
+	}
+	if c.Limits.PerPurchase > 0 && amount > c.Limits.PerPurchase {
+		return fmt.Sprintf("over the per-purchase limit of %s", money.Format(c.Limits.PerPurchase)), nil
+	}
+	if c.Limits.Daily > 0 && s.spentToday(c.ID, now)+amount > c.Limits.Daily {
+		return fmt.Sprintf("over the daily limit of %s", money.Format(c.Limits.Daily)), nil
+	}
+
+	return "", nil
+}
+
+// spentToday is what was authorised with a card since midnight and not
+// released, counting captured payments at the amount taken.
+func (s *Service) spentToday(cardID int64, now time.Time) int64 {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var spent int64
+	for _, a := range s.repo.GetAuthsByCardID(cardID) {
+		if a.CreatedAt.Before(midnight) {
+			continue
+		}
+		switch a.Status {
+		case AuthApproved:
+			spent += a.Amount
+		case AuthCaptured:
+			spent += a.CapturedAmount
+		}
+	}
+	return spent
+}
+
+func (s *Service) approvedAuth(id int64) (*Authorisation, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid authorisation ID: %d", id)
+	}
+	auth, err := s.repo.GetAuth(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refresh(auth); err != nil {
+		return nil, err
+	}
+	if auth.Status != AuthApproved {
+		return nil, fmt.Errorf("authorisation %d is %s", id, auth.Status)
+	}
+	return auth, nil
+}
+
+// refresh marks an approved authorisation expired once its hold has been
+// released as expired.
+func (s *Service) refresh(auth *Authorisation) error {
+	if auth.Status != AuthApproved {
+		return nil
+	}
+	h, err := s.holds.GetHold(auth.HoldID)
+	if err != nil {
+		return err
+	}
+	if h.Status != hold.StatusExpired {
+		return nil
+	}
+	return s.expire(auth)
+}
+
+func (s *Service) expire(auth *Authorisation) error {
+	now := time.Now()
+	auth.Status = AuthExpired
+	auth.SettledAt = &now
+	if err := s.repo.UpdateAuth(auth); err != nil {
+		return fmt.Errorf("failed to update authorisation: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) setStatus(id int64, status Status) (*Card, error) {
+	c, err := s.GetCard(id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status == StatusCancelled {
+		return nil, fmt.Errorf("card %d is cancelled", id)
+	}
+	c.Status = status
+	c.FailedPINs = 0
+	return c, s.save(c)
+}
+
+func (s *Service) save(c *Card) error {
+	if err := s.repo.Update(c); err != nil {
+		return fmt.Errorf("failed to update card: %w", err)
+	}
+	return nil
+}
+
+func validateLimits(limits Limits) error {
+	if limits.PerPurchase < 0 || limits.Daily < 0 {
+		return fmt.Errorf("card limits cannot be negative")
+	}
+	if limits.Daily > 0 && limits.PerPurchase > limits.Daily {
+		return fmt.Errorf("the per-purchase limit cannot be above the daily limit")
+	}
+	return nil
+}
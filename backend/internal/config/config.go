@@ -0,0 +1,378 @@
+package config
+
+import (
+	"banking-app/backend/internal/aml"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/fee"
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/internal/payee"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/internal/statement"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/internal/user"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the validated settings the app runs with. Values are layered,
+// each layer overriding the one before it:
+//
+//  1. Default()
+//  2. the config file (YAML or TOML, picked by extension)
+//  3. BANKING_* environment variables, e.g. BANKING_SERVER_PORT
+//  4. command-line flags
+//
+// Every setting has a dotted key (e.g. "server.port") that is used in all
+// layers, see Keys for the full list.
+type Config struct {
+	Storage    StorageConfig       `json:"storage"`
+	Server     ServerConfig        `json:"server"`
+	Password   user.PasswordPolicy `json:"password"`
+	Bank       bank.NameLimits     `json:"bank"`
+	Limits     transactions.Limits `json:"limits"`
+	Fees       fee.Defaults        `json:"fees"`
+	Payees     payee.Policy        `json:"payees"`
+	AML        aml.Rules           `json:"aml"`
+	Sanctions  sanctions.Settings  `json:"sanctions"`
+	Holds      hold.Policy         `json:"holds"`
+	Statements statement.Settings  `json:"statements"`
+}
+
+type StorageConfig struct {
+	Path string `json:"path"`
+}
+
+type ServerConfig struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
+}
+
+// EnvPrefix starts every environment variable the config reads.
+const EnvPrefix = "BANKING_"
+
+func Default() Config {
+	return Config{
+		Storage: StorageConfig{Path: "../../db/database.json"},
+		Server:  ServerConfig{Host: "127.0.0.1", Port: 8080},
+		Password: user.PasswordPolicy{
+			MinLength: 8,
+		},
+		Bank: bank.NameLimits{
+			MinLength: 2,
+			MaxLength: 20,
+		},
+		Payees: payee.Policy{
+			CoolingOff:    24 * time.Hour,
+			LargeTransfer: 100000,
+		},
+		AML: aml.Rules{
+			LargeCash:     aml.LargeCash{Threshold: 1000000, Hold: true},
+			Structuring:   aml.Structuring{Margin: 100000, Count: 3, Window: 72 * time.Hour},
+			RapidMovement: aml.RapidMovement{Min: 500000, ShareBps: 9000, Window: 48 * time.Hour},
+			NewPayees:     aml.NewPayees{Count: 3, Window: 7 * 24 * time.Hour},
+		},
+		Sanctions:  sanctions.Settings{ThresholdBps: 9000},
+		Holds:      hold.Policy{Expiry: 7 * 24 * time.Hour},
+		Statements: statement.Settings{Currency: "EUR"},
+	}
+}
+
+// Load builds the config from the defaults, the file at path (skipped when
+// path is empty), the environment and finally the flag overrides.
+func Load(path string, environ []string, overrides map[string]string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		if err := cfg.apply(values, path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := cfg.apply(fromEnv(environ), "environment"); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.apply(overrides, "flags"); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.Storage.Path) == "" {
+		return fmt.Errorf("storage.path cannot be empty")
+	}
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		return fmt.Errorf("server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Password.MinLength < 1 {
+		return fmt.Errorf("password.min_length must be at least 1, got %d", c.Password.MinLength)
+	}
+	if c.Bank.MinLength < 1 {
+		return fmt.Errorf("bank.name_min_length must be at least 1, got %d", c.Bank.MinLength)
+	}
+	if c.Bank.MaxLength < c.Bank.MinLength {
+		return fmt.Errorf("bank.name_max_length (%d) cannot be below bank.name_min_length (%d)", c.Bank.MaxLength, c.Bank.MinLength)
+	}
+
+	for key, v := range map[string]int64{
+		"limits.max_deposit":    c.Limits.MaxDeposit,
+		"limits.max_withdrawal": c.Limits.MaxWithdrawal,
+		"limits.max_transfer":   c.Limits.MaxTransfer,
+		"fees.transfer":         c.Fees.Transfer,
+		"fees.withdrawal":       c.Fees.Withdrawal,
+		"fees.maintenance":      c.Fees.Maintenance,
+		"fees.overdraft":        c.Fees.Overdraft,
+		"fees.late_payment":     c.Fees.LatePayment,
+		"payees.large_transfer": c.Payees.LargeTransfer,
+		"payees.cooling_off":    int64(c.Payees.CoolingOff),
+
+		"aml.large_cash":            c.AML.LargeCash.Threshold,
+		"aml.structuring_margin":    c.AML.Structuring.Margin,
+		"aml.structuring_count":     int64(c.AML.Structuring.Count),
+		"aml.structuring_window":    int64(c.AML.Structuring.Window),
+		"aml.rapid_movement_min":    c.AML.RapidMovement.Min,
+		"aml.rapid_movement_share":  c.AML.RapidMovement.ShareBps,
+		"aml.rapid_movement_window": int64(c.AML.RapidMovement.Window),
+		"aml.new_payees_count":      int64(c.AML.NewPayees.Count),
+		"aml.new_payees_window":     int64(c.AML.NewPayees.Window),
+
+		"holds.expiry": int64(c.Holds.Expiry),
+	} {
+		if v < 0 {
+			return fmt.Errorf("%s cannot be negative", key)
+		}
+	}
+	if c.AML.RapidMovement.ShareBps > 10000 {
+		return fmt.Errorf("aml.rapid_movement_share cannot be above 100%%")
+	}
+	if c.Sanctions.ThresholdBps <= 0 || c.Sanctions.ThresholdBps > 10000 {
+		return fmt.Errorf("sanctions.threshold must be above 0%% and at most 100%%")
+	}
+	if c.AML.LargeCash.Threshold > 0 && c.AML.Structuring.Margin > c.AML.LargeCash.Threshold {
+		return fmt.Errorf("aml.structuring_margin cannot be above aml.large_cash")
+	}
+	if !currencyCode(c.Statements.Currency) {
+		return fmt.Errorf("statements.currency must be a three-letter ISO 4217 code, e.g. EUR")
+	}
+
+	return nil
+}
+
+func currencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys lists every setting that can be configured.
+func Keys() []string {
+	var c Config
+	keys := make([]string, 0, len(c.setters()))
+	for key := range c.setters() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EnvName is the environment variable for a key, e.g. BANKING_SERVER_PORT.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func (c *Config) setters() map[string]func(string) error {
+	return map[string]func(string) error{
+		"storage.path":           stringVar(&c.Storage.Path),
+		"server.host":            stringVar(&c.Server.Host),
+		"server.port":            intVar(&c.Server.Port),
+		"password.min_length":    intVar(&c.Password.MinLength),
+		"password.require_digit": boolVar(&c.Password.RequireDigit),
+		"password.require_upper": boolVar(&c.Password.RequireUpper),
+		"bank.name_min_length":   intVar(&c.Bank.MinLength),
+		"bank.name_max_length":   intVar(&c.Bank.MaxLength),
+		"limits.max_deposit":     moneyVar(&c.Limits.MaxDeposit),
+		"limits.max_withdrawal":  moneyVar(&c.Limits.MaxWithdrawal),
+		"limits.max_transfer":    moneyVar(&c.Limits.MaxTransfer),
+		"fees.transfer":          moneyVar(&c.Fees.Transfer),
+		"fees.withdrawal":        moneyVar(&c.Fees.Withdrawal),
+		"fees.maintenance":       moneyVar(&c.Fees.Maintenance),
+		"fees.overdraft":         moneyVar(&c.Fees.Overdraft),
+		"fees.late_payment":      moneyVar(&c.Fees.LatePayment),
+		"payees.cooling_off":     durationVar(&c.Payees.CoolingOff),
+		"payees.large_transfer":  moneyVar(&c.Payees.LargeTransfer),
+
+		"aml.large_cash":            moneyVar(&c.AML.LargeCash.Threshold),
+		"aml.large_cash_hold":       boolVar(&c.AML.LargeCash.Hold),
+		"aml.structuring_margin":    moneyVar(&c.AML.Structuring.Margin),
+		"aml.structuring_count":     intVar(&c.AML.Structuring.Count),
+		"aml.structuring_window":    durationVar(&c.AML.Structuring.Window),
+		"aml.structuring_hold":      boolVar(&c.AML.Structuring.Hold),
+		"aml.rapid_movement_min":    moneyVar(&c.AML.RapidMovement.Min),
+		"aml.rapid_movement_share":  percentVar(&c.AML.RapidMovement.ShareBps),
+		"aml.rapid_movement_window": durationVar(&c.AML.RapidMovement.Window),
+		"aml.rapid_movement_hold":   boolVar(&c.AML.RapidMovement.Hold),
+		"aml.new_payees_count":      intVar(&c.AML.NewPayees.Count),
+		"aml.new_payees_window":     durationVar(&c.AML.NewPayees.Window),
+		"aml.new_payees_hold":       boolVar(&c.AML.NewPayees.Hold),
+
+		"sanctions.watchlist": stringVar(&c.Sanctions.Watchlist),
+		"sanctions.threshold": percentVar(&c.Sanctions.ThresholdBps),
+
+		"holds.expiry": durationVar(&c.Holds.Expiry),
+
+		"statements.currency": stringVar(&c.Statements.Currency),
+	}
+}
+
+func (c *Config) apply(values map[string]string, source string) error {
+	setters := c.setters()
+
+	for key, value := range values {
+		set, ok := setters[key]
+		if !ok {
+			return fmt.Errorf("%s: unknown setting %q", source, key)
+		}
+		if err := set(value); err != nil {
+			return fmt.Errorf("%s: %s: %w", source, key, err)
+		}
+	}
+
+	return nil
+}
+
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var values map[string]string
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		values, err = parseYAML(string(data))
+	case ".toml":
+		values, err = parseTOML(string(data))
+	default:
+		return nil, fmt.Errorf("unsupported config format %q, use .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// fromEnv picks the BANKING_* variables that match a known key. Other
+// variables with the prefix (such as BANKING_CONFIG) are ignored.
+func fromEnv(environ []string) map[string]string {
+	values := map[string]string{}
+
+	for _, key := range Keys() {
+		name := EnvName(key)
+		for _, kv := range environ {
+			if k, v, ok := strings.Cut(kv, "="); ok && k == name {
+				values[key] = v
+			}
+		}
+	}
+
+	return values
+}
+
+func stringVar(p *string) func(string) error {
+	return func(v string) error {
+		*p = v
+		return nil
+	}
+}
+
+func intVar(p *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", v)
+		}
+		*p = n
+		return nil
+	}
+}
+
+func boolVar(p *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+		*p = b
+		return nil
+	}
+}
+
+func moneyVar(p *int64) func(string) error {
+	return func(v string) error {
+		amount, err := money.Parse(v)
+		if err != nil {
+			return err
+		}
+		*p = amount
+		return nil
+	}
+}
+
+// percentVar reads a percentage with up to two decimals, e.g. 92.5, into
+// basis points. It cannot be above 100.
+func percentVar(p *int64) func(string) error {
+	return func(v string) error {
+		f, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64)
+		if err != nil || math.IsNaN(f) {
+			return fmt.Errorf("invalid percentage %q, e.g. 90 or 92.5", v)
+		}
+		bps := math.Round(f * 100)
+		if math.Abs(f*100-bps) > 1e-6 {
+			return fmt.Errorf("invalid percentage %q, at most two decimals", v)
+		}
+		if bps < 0 || bps > 10000 {
+			return fmt.Errorf("percentage %q must be between 0 and 100", v)
+		}
+		*p = int64(bps)
+		return nil
+	}
+}
+
+func durationVar(p *time.Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, e.g. 24h or 90m", v)
+		}
+		*p = d
+		return nil
+	}
+}
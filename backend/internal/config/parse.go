@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The config files only ever hold sections of scalar settings, so instead of
+// pulling in full YAML and TOML libraries we parse that subset ourselves. Both
+// parsers flatten the file into dotted keys such as "server.port".
+
+// parseYAML reads nested "key: value" mappings, using indentation for nesting.
+func parseYAML(data string) (map[string]string, error) {
+	values := map[string]string{}
+
+	type level struct {
+		indent int
+		prefix string
+	}
+	stack := []level{{indent: -1}}
+
+	for n, line := range strings.Split(data, "\n") {
+		line = stripComment(line)
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", n+1)
+		}
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "- ") {
+			return nil, fmt.Errorf("line %d: lists are not supported", n+1)
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", n+1)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		for len(stack) > 1 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		fullKey := stack[len(stack)-1].prefix + key
+
+		if value == "" {
+			// start of a nested mapping
+			stack = append(stack, level{indent: indent, prefix: fullKey + "."})
+			continue
+		}
+
+		v, err := unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		values[fullKey] = v
+	}
+
+	return values, nil
+}
+
+// parseTOML reads "[section]" tables holding "key = value" pairs.
+func parseTOML(data string) (map[string]string, error) {
+	values := map[string]string{}
+	prefix := ""
+
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(stripComment(line))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: invalid table header %q", n+1, line)
+			}
+			prefix = strings.TrimSpace(line[1:len(line)-1]) + "."
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key = value\"", n+1)
+		}
+
+		v, err := unquote(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		values[prefix+strings.TrimSpace(key)] = v
+	}
+
+	return values, nil
+}
+
+// stripComment drops a trailing "# ..." that is not inside quotes.
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquote(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted string %s", value)
+		}
+		return s, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("invalid quoted string %s", value)
+		}
+		return value[1 : len(value)-1], nil
+	}
+	return value, nil
+}
@@ -0,0 +1,143 @@
+package customer
+
+import (
+	"banking-app/backend/pkg/console"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+// HandleStatus tells a customer where their verification stands.
+func (h *Handler) HandleStatus(customerID int64) {
+	c, err := h.service.GetCustomer(customerID)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+
+	switch c.Status {
+	case StatusUnverified:
+		h.con.Println("We need to verify your identity before you can open accounts.")
+	case StatusPending:
+		h.con.Println("Your details are being reviewed by the bank.")
+	case StatusRejected:
+		h.con.Printf("Your identity could not be verified: %s\n", c.LastEvent().Note)
+	case StatusVerified:
+		h.con.Println("Your identity is verified.")
+	}
+}
+
+func (h *Handler) HandleSubmit(customerID int64) {
+	name, _ := h.con.Prompt("Full name: ")
+	dobStr, _ := h.con.Prompt("Date of birth (YYYY-MM-DD): ")
+	address, _ := h.con.Prompt("Address: ")
+	docTypeStr, _ := h.con.Prompt("ID document (passport, id-card or driving-licence): ")
+	docNumber, _ := h.con.Prompt("Document number: ")
+	country, _ := h.con.Prompt("Issuing country (e.g. GB): ")
+	expiresStr, _ := h.con.Prompt("Expiry date (YYYY-MM-DD): ")
+
+	dob, err := time.ParseInLocation(time.DateOnly, dobStr, time.Local)
+	if err != nil {
+		h.con.Printf("Invalid date: %s\n", dobStr)
+		return
+	}
+	docType, err := ParseDocumentType(docTypeStr)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	expires, err := time.ParseInLocation(time.DateOnly, expiresStr, time.Local)
+	if err != nil {
+		h.con.Printf("Invalid date: %s\n", expiresStr)
+		return
+	}
+
+	_, err = h.service.SubmitDetails(customerID, Details{
+		FullName:    name,
+		DateOfBirth: dob,
+		Address:     address,
+		Document: Document{
+			Type:      docType,
+			Number:    docNumber,
+			Country:   country,
+			ExpiresAt: expires,
+		},
+	})
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+
+	h.con.Println("✅ Thank you, the bank will review your details.")
+}
+
+// HandleReviewQueue lets a bank operator go through the customers waiting
+// for verification.
+func (h *Handler) HandleReviewQueue(bankID, reviewerID int64) {
+	queue := h.service.GetReviewQueue(bankID)
+	if len(queue) == 0 {
+		h.con.Println("No customers are waiting for review.")
+		return
+	}
+
+	h.con.Printf("%d customer(s) waiting for review:\n", len(queue))
+	h.con.Println("ID\tName\tSubmitted")
+	for _, c := range queue {
+		h.con.Printf("%d\t%s\t%s\n", c.ID, c.Name, c.LastEvent().At.Format("2006-01-02 15:04"))
+	}
+
+	idStr, _ := h.con.Prompt("Customer ID to review (empty to go back): ")
+	if idStr == "" {
+		return
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.con.Printf("Invalid ID format: %s\n", idStr)
+		return
+	}
+	c, err := h.service.GetCustomer(id)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	if c.BankID != bankID || c.Details == nil {
+		h.con.Printf("Customer %d is not waiting for review.\n", id)
+		return
+	}
+
+	d := c.Details
+	h.con.Printf("Name:          %s\n", d.FullName)
+	h.con.Printf("Date of birth: %s\n", d.DateOfBirth.Format(time.DateOnly))
+	h.con.Printf("Address:       %s\n", d.Address)
+	h.con.Printf("Document:      %s %s (%s), expires %s\n", d.Document.Type, d.Document.Number, d.Document.Country, d.Document.ExpiresAt.Format(time.DateOnly))
+
+	decision, _ := h.con.Prompt("Approve or reject? (a/r, empty to skip): ")
+	switch strings.ToLower(decision) {
+	case "a":
+		note, _ := h.con.Prompt("Note (optional): ")
+		if _, err := h.service.Approve(bankID, id, reviewerID, note); err != nil {
+			h.con.Printf("Error: %v\n", err)
+			return
+		}
+		h.con.Printf("✅ Customer %d verified.\n", id)
+	case "r":
+		reason, _ := h.con.Prompt("Reason: ")
+		if _, err := h.service.Reject(bankID, id, reviewerID, reason); err != nil {
+			h.con.Printf("Error: %v\n", err)
+			return
+		}
+		h.con.Printf("Customer %d rejected.\n", id)
+	}
+}
@@ -1,7 +1,102 @@
 package customer
 
+import (
+	"fmt"
+	"time"
+)
+
+// Status is where a customer is in the know-your-customer checks. Only
+// verified customers can open accounts and transact.
+type Status string
+
+const (
+	// StatusUnverified customers have not sent their details yet.
+	StatusUnverified Status = "unverified"
+	// StatusPending customers are waiting for the bank to review them.
+	StatusPending  Status = "pending"
+	StatusVerified Status = "verified"
+	// StatusRejected customers can correct their details and send them again.
+	StatusRejected Status = "rejected"
+)
+
+func ParseStatus(s string) (Status, error) {
+	switch Status(s) {
+	case StatusUnverified, StatusPending, StatusVerified, StatusRejected:
+		return Status(s), nil
+	}
+	return "", fmt.Errorf("unknown verification status %q (expected unverified, pending, verified or rejected)", s)
+}
+
+// DocumentType is the kind of identity document a customer shows.
+type DocumentType string
+
+const (
+	DocumentPassport       DocumentType = "passport"
+	DocumentIDCard         DocumentType = "id-card"
+	DocumentDrivingLicence DocumentType = "driving-licence"
+)
+
+func ParseDocumentType(s string) (DocumentType, error) {
+	switch DocumentType(s) {
+	case DocumentPassport, DocumentIDCard, DocumentDrivingLicence:
+		return DocumentType(s), nil
+	}
+	return "", fmt.Errorf("unknown document type %q (expected passport, id-card or driving-licence)", s)
+}
+
+type Document struct {
+	Type      DocumentType `json:"type"`
+	Number    string       `json:"number"`
+	Country   string       `json:"country"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+}
+
+// Details are what a customer sends for verification.
+type Details struct {
+	FullName    string    `json:"fullName"`
+	DateOfBirth time.Time `json:"dateOfBirth"`
+	Address     string    `json:"address"`
+	Document    Document  `json:"document"`
+}
+
+// Event is one step in a customer's verification history. By is the user
+// who took it: the customer submitting details or the bank operator
+// reviewing them, 0 for changes made by the system.
+type Event struct {
+	At     time.Time `json:"at"`
+	Status Status    `json:"status"`
+	By     int64     `json:"by"`
+	Note   string    `json:"note,omitempty"`
+}
 
 type Customer struct {
-	ID       int64                      `json:"id"`
-	Name     string                     `json:"name"`
+	ID      int64    `json:"id"`
+	UserID  int64    `json:"userid"`
+	BankID  int64    `json:"bankid"`
+	Name    string   `json:"name"`
+	Details *Details `json:"details,omitempty"`
+	Status  Status   `json:"status"`
+	History []Event  `json:"history,omitempty"`
+}
+
+func NewCustomer(id, userID, bankID int64, name string) *Customer {
+	return &Customer{
+		ID:     id,
+		UserID: userID,
+		BankID: bankID,
+		Name:   name,
+		Status: StatusUnverified,
+	}
+}
+
+func (c *Customer) Verified() bool {
+	return c.Status == StatusVerified
+}
+
+// LastEvent is the latest step in the customer's verification history.
+func (c *Customer) LastEvent() Event {
+	if len(c.History) == 0 {
+		return Event{Status: c.Status}
+	}
+	return c.History[len(c.History)-1]
 }
@@ -0,0 +1,140 @@
+package customer
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath  string
+	mutex     sync.RWMutex
+	nextID    int64
+	customers []*Customer // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath:  filePath,
+		nextID:    1,
+		customers: []*Customer{},
+	}
+
+	if err := storage.LoadCollection(filePath, "customers", &repo.customers); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, customer := range repo.customers {
+		if customer.ID >= repo.nextID {
+			repo.nextID = customer.ID + 1
+		}
+		// customers from before verification checks have never been
+		// checked, they send their details like new customers
+		if customer.Status == "" {
+			customer.Status = StatusUnverified
+			customer.History = []Event{{Status: StatusUnverified, Note: "customer before verification checks were introduced"}}
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "customers", r.customers); err != nil {
+		return fmt.Errorf("failed to save customer data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(userID, bankID int64, name string) (*Customer, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	customer := NewCustomer(r.nextID, userID, bankID, name)
+	r.customers = append(r.customers, customer)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func (r *Repository) Update(customer *Customer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.customers {
+		if existing.ID == customer.ID {
+			r.customers[i] = copyCustomer(customer)
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("customer with ID %d not found", customer.ID)
+}
+
+func (r *Repository) GetByID(id int64) (*Customer, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, customer := range r.customers {
+		if customer.ID == id {
+			return customer, nil
+		}
+	}
+
+	return nil, fmt.Errorf("customer with ID %d not found", id)
+}
+
+func (r *Repository) GetByUserID(userID int64) (*Customer, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, customer := range r.customers {
+		if customer.UserID == userID {
+			return customer, nil
+		}
+	}
+
+	return nil, fmt.Errorf("customer with User ID %d not found", userID)
+}
+
+func (r *Repository) GetAll() []*Customer {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Return a copy to avoid external modification
+	customers := make([]*Customer, len(r.customers))
+	copy(customers, r.customers)
+
+	return customers
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Customer {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	customers := []*Customer{}
+	for _, customer := range r.customers {
+		if customer.BankID == bankID {
+			customers = append(customers, customer)
+		}
+	}
+
+	return customers
+}
+
+// copyCustomer copies a customer including their details and history, so
+// callers can change it without touching the cache.
+func copyCustomer(c *Customer) *Customer {
+	copied := *c
+	if c.Details != nil {
+		details := *c.Details
+		copied.Details = &details
+	}
+	copied.History = append([]Event(nil), c.History...)
+	return &copied
+}
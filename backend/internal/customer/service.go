@@ -0,0 +1,230 @@
+package customer
+
+import (
+	"banking-app/backend/internal/notification"
+	"banking-app/backend/internal/sanctions"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MinimumAge is how old a customer must be to be verified.
+const MinimumAge = 18
+
+// ErrNotVerified is returned, wrapped with the customer and their status,
+// when an unverified customer tries to open an account or transact.
+var ErrNotVerified = errors.New("customer is not verified")
+
+type Service struct {
+	repo          *Repository
+	notifications *notification.Service
+	screening     *sanctions.Service
+}
+
+func NewService(repo *Repository, notifications *notification.Service, screening *sanctions.Service) *Service {
+	return &Service{
+		repo:          repo,
+		notifications: notifications,
+		screening:     screening,
+	}
+}
+
+func (s *Service) CreateCustomer(userID, bankID int64, name string) (*Customer, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+	if bankID <= 0 {
+		return nil, fmt.Errorf("invalid bank ID: %d", bankID)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+
+	// Customers can only belong to one bank
+	if existing, err := s.repo.GetByUserID(userID); err == nil {
+		return nil, fmt.Errorf("user %d is already customer %d of bank %d", userID, existing.ID, existing.BankID)
+	}
+
+	subject := sanctions.Subject{Kind: sanctions.KindCustomer, BankID: bankID, UserID: userID, Name: name}
+	if err := s.screening.Screen(subject); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.repo.Create(userID, bankID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+func (s *Service) GetCustomer(id int64) (*Customer, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid customer ID: %d", id)
+	}
+
+	customer, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+func (s *Service) GetCustomerByUserID(userID int64) (*Customer, error) {
+	return s.repo.GetByUserID(userID)
+}
+
+func (s *Service) GetAllCustomers() []*Customer {
+	return s.repo.GetAll()
+}
+
+func (s *Service) GetBankCustomers(bankID int64) []*Customer {
+	return s.repo.GetByBankID(bankID)
+}
+
+// SubmitDetails records a customer's details and puts them in the bank's
+// review queue. Rejected customers can correct and resubmit them.
+func (s *Service) SubmitDetails(customerID int64, details Details) (*Customer, error) {
+	c, err := s.GetCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Verified() {
+		return nil, fmt.Errorf("customer %d is already verified", customerID)
+	}
+
+	details.FullName = strings.TrimSpace(details.FullName)
+	details.Address = strings.TrimSpace(details.Address)
+	details.Document.Number = strings.ToUpper(strings.TrimSpace(details.Document.Number))
+	details.Document.Country = strings.ToUpper(strings.TrimSpace(details.Document.Country))
+	if err := validateDetails(details, time.Now()); err != nil {
+		return nil, err
+	}
+
+	subject := sanctions.Subject{Kind: sanctions.KindCustomer, BankID: c.BankID, UserID: c.UserID, CustomerID: c.ID, Name: details.FullName}
+	if err := s.screening.Screen(subject); err != nil {
+		return nil, err
+	}
+
+	c = copyCustomer(c)
+	c.Name = details.FullName
+	c.Details = &details
+
+	return s.setStatus(c, StatusPending, c.UserID, "details submitted")
+}
+
+// Approve verifies a pending customer of the bank. reviewerID is the user
+// of the bank operator.
+func (s *Service) Approve(bankID, customerID, reviewerID int64, note string) (*Customer, error) {
+	c, err := s.pendingCustomer(bankID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	c, err = s.setStatus(c, StatusVerified, reviewerID, strings.TrimSpace(note))
+	if err != nil {
+		return nil, err
+	}
+	return c, s.notifications.Notify(c.ID, 0, "Your identity has been verified, you can now open accounts.")
+}
+
+// Reject sends a pending customer's details back with the reason, which the
+// customer sees so they can fix them.
+func (s *Service) Reject(bankID, customerID, reviewerID int64, reason string) (*Customer, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("give a reason for rejecting the customer")
+	}
+
+	c, err := s.pendingCustomer(bankID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	c, err = s.setStatus(c, StatusRejected, reviewerID, reason)
+	if err != nil {
+		return nil, err
+	}
+	return c, s.notifications.Notify(c.ID, 0, fmt.Sprintf("Your identity could not be verified: %s. Please check your details and submit them again.", reason))
+}
+
+// GetReviewQueue returns the bank's customers waiting for review, oldest
+// submission first.
+func (s *Service) GetReviewQueue(bankID int64) []*Customer {
+	queue := []*Customer{}
+	for _, c := range s.repo.GetByBankID(bankID) {
+		if c.Status == StatusPending {
+			queue = append(queue, c)
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].LastEvent().At.Before(queue[j].LastEvent().At)
+	})
+	return queue
+}
+
+// CheckVerified returns an error wrapping ErrNotVerified unless the customer
+// has passed verification.
+func (s *Service) CheckVerified(customerID int64) error {
+	c, err := s.GetCustomer(customerID)
+	if err != nil {
+		return err
+	}
+	if !c.Verified() {
+		return fmt.Errorf("%w: customer %d is %s", ErrNotVerified, c.ID, c.Status)
+	}
+	return nil
+}
+
+func (s *Service) pendingCustomer(bankID, customerID int64) (*Customer, error) {
+	c, err := s.GetCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if c.BankID != bankID {
+		return nil, fmt.Errorf("customer %d is not a customer of bank %d", customerID, bankID)
+	}
+	if c.Status != StatusPending {
+		return nil, fmt.Errorf("customer %d is %s, only pending customers can be reviewed", customerID, c.Status)
+	}
+	return copyCustomer(c), nil
+}
+
+func (s *Service) setStatus(c *Customer, status Status, by int64, note string) (*Customer, error) {
+	c.Status = status
+	c.History = append(c.History, Event{At: time.Now(), Status: status, By: by, Note: note})
+
+	if err := s.repo.Update(c); err != nil {
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+	return s.repo.GetByID(c.ID)
+}
+
+func validateDetails(d Details, now time.Time) error {
+	if d.FullName == "" {
+		return fmt.Errorf("full name cannot be empty")
+	}
+	if d.DateOfBirth.IsZero() || d.DateOfBirth.After(now) {
+		return fmt.Errorf("invalid date of birth")
+	}
+	if d.DateOfBirth.AddDate(MinimumAge, 0, 0).After(now) {
+		return fmt.Errorf("customers must be at least %d years old", MinimumAge)
+	}
+	if d.Address == "" {
+		return fmt.Errorf("address cannot be empty")
+	}
+	if _, err := ParseDocumentType(string(d.Document.Type)); err != nil {
+		return err
+	}
+	if d.Document.Number == "" {
+		return fmt.Errorf("document number cannot be empty")
+	}
+	if len(d.Document.Country) != 2 || strings.Trim(d.Document.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("document country must be a two-letter country code, e.g. GB")
+	}
+	if !d.Document.ExpiresAt.After(now) {
+		return fmt.Errorf("the document has expired")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package eod
+
+import (
+	"banking-app/backend/internal/calendar"
+	"fmt"
+	"time"
+)
+
+// Dates tells which business day each bank is on. A bank that has never
+// closed a day is on today; after that it is on the first day after the
+// last closed one it is open, so weekends and holidays are skipped.
+type Dates struct {
+	repo     *Repository
+	calendar *calendar.Service
+}
+
+func NewDates(repo *Repository, calendar *calendar.Service) *Dates {
+	return &Dates{
+		repo:     repo,
+		calendar: calendar,
+	}
+}
+
+func (d *Dates) BusinessDate(bankID int64) time.Time {
+	last := d.repo.Last(bankID)
+	if last == nil {
+		return truncateDay(time.Now())
+	}
+	return d.NextDate(bankID, parseDay(last.Date))
+}
+
+// NextDate is the business day after day.
+func (d *Dates) NextDate(bankID int64, day time.Time) time.Time {
+	return d.calendar.NextBusinessDay(bankID, day.AddDate(0, 0, 1))
+}
+
+// Closed reports whether day is before the bank's business date. Nothing is
+// closed for a bank that has never run end of day.
+func (d *Dates) Closed(bankID int64, day time.Time) bool {
+	if d.repo.Last(bankID) == nil {
+		return false
+	}
+	return truncateDay(day).Before(d.BusinessDate(bankID))
+}
+
+// Freeze stops postings into a bank, other than those of the end-of-day
+// jobs, until the returned func is called. The bank is marked in the
+// database, so postings made by other processes are stopped as well. A
+// bank can only be frozen once at a time.
+func (d *Dates) Freeze(bankID int64) (thaw func() error, err error) {
+	if err := d.repo.StartClosing(bankID); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return d.repo.EndClosing(bankID)
+	}, nil
+}
+
+// Frozen reports whether the bank's end of day is running, in this process
+// or another.
+func (d *Dates) Frozen(bankID int64) (bool, error) {
+	closing, err := d.repo.GetClosing(bankID)
+	if err != nil {
+		return false, fmt.Errorf("failed to read end of day state of bank %d: %w", bankID, err)
+	}
+	return closing != nil, nil
+}
+
+// Closing returns the marker of the bank's running end of day, nil if it
+// is not running.
+func (d *Dates) Closing(bankID int64) (*Closing, error) {
+	return d.repo.GetClosing(bankID)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// parseDay reads a date stored by this package, which is always valid.
+func parseDay(s string) time.Time {
+	t, _ := time.ParseInLocation(time.DateOnly, s, time.Local)
+	return t
+}
@@ -0,0 +1,73 @@
+package eod
+
+import (
+	"banking-app/backend/internal/account"
+	"time"
+)
+
+// Step is what one job of an end-of-day run did.
+type Step struct {
+	Name    string `json:"name"`
+	Done    int    `json:"done"`              // postings or payments made
+	Skipped int    `json:"skipped,omitempty"` // nothing due, or could not be made
+	Note    string `json:"note,omitempty"`
+}
+
+// Line is the row of a trial balance for the accounts of one type. Balance
+// is the sum of their balances, Ledger the net of every posting to them.
+// Amounts are in cents.
+type Line struct {
+	Type     account.Type `json:"type"`
+	Accounts int          `json:"accounts"`
+	Balance  int64        `json:"balance"`
+	Ledger   int64        `json:"ledger"`
+}
+
+// Difference is how far the balances are from the ledger, 0 when they agree.
+func (l Line) Difference() int64 {
+	return l.Balance - l.Ledger
+}
+
+// TrialBalance checks a bank's balances by account type against the ledger
+// at the close of a day. Debit and Credit are the totals of the bank's
+// general ledger, which are equal when its books balance.
+type TrialBalance struct {
+	Lines   []Line `json:"lines"`
+	Balance int64  `json:"balance"`
+	Ledger  int64  `json:"ledger"`
+	Debit   int64  `json:"debit"`
+	Credit  int64  `json:"credit"`
+}
+
+// Balanced reports whether the balances of every account type agree with
+// the ledger and the general ledger's debits equal its credits.
+func (tb TrialBalance) Balanced() bool {
+	for _, l := range tb.Lines {
+		if l.Difference() != 0 {
+			return false
+		}
+	}
+	return tb.Debit == tb.Credit
+}
+
+// Day is a business day a bank has closed. Once closed nothing more can be
+// posted into it except adjustments, and the bank's business date moves to
+// NextDate.
+type Day struct {
+	ID           int64        `json:"id"`
+	BankID       int64        `json:"bankid"`
+	Date         string       `json:"date"` // YYYY-MM-DD
+	NextDate     string       `json:"nextDate"`
+	ClosedBy     int64        `json:"closedBy"`
+	ClosedAt     time.Time    `json:"closedAt"`
+	Steps        []Step       `json:"steps"`
+	TrialBalance TrialBalance `json:"trialBalance"`
+}
+
+// Closing marks a bank whose end of day is running. It is kept in the
+// database, so postings from every process are stopped, not only those of
+// the process closing the day.
+type Closing struct {
+	BankID    int64     `json:"bankid"`
+	StartedAt time.Time `json:"startedAt"`
+}
@@ -0,0 +1,163 @@
+package eod
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	days     []*Day // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		days:     []*Day{},
+	}
+
+	if err := storage.LoadCollection(filePath, "closedDays", &repo.days); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, d := range repo.days {
+		if d.ID >= repo.nextID {
+			repo.nextID = d.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "closedDays", r.days); err != nil {
+		return fmt.Errorf("failed to save closed day data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(d Day) (*Day, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	d.ID = r.nextID
+	r.days = append(r.days, &d)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	copied := d
+	return &copied, nil
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Day {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	days := []*Day{}
+	for _, d := range r.days {
+		if d.BankID == bankID {
+			copied := *d
+			days = append(days, &copied)
+		}
+	}
+
+	return days
+}
+
+// Last returns the day the bank closed most recently, nil if it never has.
+func (r *Repository) Last(bankID int64) *Day {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var last *Day
+	for _, d := range r.days {
+		if d.BankID == bankID && (last == nil || d.Date > last.Date) {
+			last = d
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	copied := *last
+	return &copied
+}
+
+// The closing markers are read from and written to the file every time
+// instead of being cached, another process may have set or cleared one.
+
+// GetClosing returns the marker of the bank's running end of day, nil if it
+// is not running.
+func (r *Repository) GetClosing(bankID int64) (*Closing, error) {
+	closings, err := r.loadClosings()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range closings {
+		if c.BankID == bankID {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// StartClosing sets the bank's marker. It fails if the bank already has one.
+func (r *Repository) StartClosing(bankID int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	closings, err := r.loadClosings()
+	if err != nil {
+		return err
+	}
+	for _, c := range closings {
+		if c.BankID == bankID {
+			return fmt.Errorf("end of day is already running for bank %d, since %s", bankID, c.StartedAt.Format("2006-01-02 15:04"))
+		}
+	}
+
+	closings = append(closings, Closing{BankID: bankID, StartedAt: time.Now()})
+	return r.saveClosings(closings)
+}
+
+// EndClosing clears the bank's marker.
+func (r *Repository) EndClosing(bankID int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	closings, err := r.loadClosings()
+	if err != nil {
+		return err
+	}
+	kept := []Closing{}
+	for _, c := range closings {
+		if c.BankID != bankID {
+			kept = append(kept, c)
+		}
+	}
+	return r.saveClosings(kept)
+}
+
+func (r *Repository) loadClosings() ([]Closing, error) {
+	closings := []Closing{}
+	if err := storage.LoadCollection(r.filePath, "closings", &closings); err != nil {
+		return nil, err
+	}
+	return closings, nil
+}
+
+func (r *Repository) saveClosings(closings []Closing) error {
+	if err := storage.SaveCollection(r.filePath, "closings", closings); err != nil {
+		return fmt.Errorf("failed to save closing data: %w", err)
+	}
+	return nil
+}
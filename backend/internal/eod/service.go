@@ -0,0 +1,207 @@
+package eod
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/gl"
+	"banking-app/backend/internal/interest"
+	"banking-app/backend/internal/loan"
+	"banking-app/backend/internal/standingorder"
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"time"
+)
+
+type Service struct {
+	repo     *Repository
+	dates    *Dates
+	accounts *account.Repository
+	txs      *transactions.Service
+	interest *interest.Service
+	orders   *standingorder.Service
+	loans    *loan.Service
+	ledger   *gl.Service
+}
+
+// NewService takes the services the end-of-day jobs post through, which
+// must be made on the transactions service returned by EndOfDay so they
+// can post while the day is frozen.
+func NewService(repo *Repository, dates *Dates, accounts *account.Repository, txs *transactions.Service, interest *interest.Service, orders *standingorder.Service, loans *loan.Service, ledger *gl.Service) *Service {
+	return &Service{
+		repo:     repo,
+		dates:    dates,
+		accounts: accounts,
+		txs:      txs,
+		interest: interest,
+		orders:   orders,
+		loans:    loans,
+		ledger:   ledger,
+	}
+}
+
+func (s *Service) BusinessDate(bankID int64) time.Time {
+	return s.dates.BusinessDate(bankID)
+}
+
+func (s *Service) GetBankDays(bankID int64) []*Day {
+	return s.repo.GetByBankID(bankID)
+}
+
+// Closing returns the marker of the bank's running end of day, nil if it is
+// not running.
+func (s *Service) Closing(bankID int64) (*Closing, error) {
+	return s.dates.Closing(bankID)
+}
+
+// Close runs end of day for a bank's business date: it accrues interest,
+// makes the standing order payments and loan collections due, bills the
+// monthly maintenance fee on the last business day of the month, takes the
+// trial balance and moves the bank on to the next business day. The day is
+// then closed to postings. The bank is frozen while the jobs run, so nothing
+// else can post into the day under them.
+//
+// A day cannot be closed before it has started. If a job fails the day
+// stays open; every job picks up where it stopped, so Close can be run
+// again.
+func (s *Service) Close(bankID, closedBy int64) (*Day, error) {
+	date := s.dates.BusinessDate(bankID)
+	if date.After(truncateDay(time.Now())) {
+		return nil, fmt.Errorf("business date %s of bank %d has not started yet", date.Format(time.DateOnly), bankID)
+	}
+	next := s.dates.NextDate(bankID, date)
+
+	thaw, err := s.dates.Freeze(bankID)
+	if err != nil {
+		return nil, err
+	}
+	closed, err := s.close(bankID, closedBy, date, next)
+	if thawErr := thaw(); thawErr != nil && err == nil {
+		return nil, fmt.Errorf("closed business day %s, but bank %d is still frozen: %w", closed.Date, bankID, thawErr)
+	}
+	return closed, err
+}
+
+// close runs the jobs of a frozen bank's day and saves it.
+func (s *Service) close(bankID, closedBy int64, date, next time.Time) (*Day, error) {
+	day := Day{
+		BankID:   bankID,
+		Date:     date.Format(time.DateOnly),
+		NextDate: next.Format(time.DateOnly),
+		ClosedBy: closedBy,
+	}
+
+	for _, job := range []func(int64, time.Time, time.Time) (Step, error){s.accrueInterest, s.runOrders, s.collectLoans, s.chargeMaintenance} {
+		step, err := job(bankID, date, next)
+		if err != nil {
+			return nil, fmt.Errorf("end of day %s stopped at %s: %w", day.Date, step.Name, err)
+		}
+		day.Steps = append(day.Steps, step)
+	}
+
+	day.TrialBalance = s.TrialBalance(bankID)
+	day.ClosedAt = time.Now()
+
+	closed, err := s.repo.Create(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close business day: %w", err)
+	}
+
+	return closed, nil
+}
+
+func (s *Service) accrueInterest(bankID int64, date, _ time.Time) (Step, error) {
+	step := Step{Name: "interest"}
+	results, err := s.interest.AccrueBank(bankID, date)
+	for _, r := range results {
+		if r.Days > 0 {
+			step.Done++
+		} else {
+			step.Skipped++
+		}
+	}
+	return step, err
+}
+
+func (s *Service) runOrders(bankID int64, date, _ time.Time) (Step, error) {
+	step := Step{Name: "standing orders"}
+	results, err := s.orders.Run(bankID, date)
+	for _, r := range results {
+		if r.Outcome == "paid" {
+			step.Done++
+		} else {
+			step.Skipped++
+		}
+	}
+	return step, err
+}
+
+func (s *Service) collectLoans(bankID int64, date, _ time.Time) (Step, error) {
+	step := Step{Name: "loan collections"}
+	results, err := s.loans.Collect(bankID, date.AddDate(0, 0, 1).Add(-time.Second))
+	for _, r := range results {
+		if r.Collected {
+			step.Done++
+		} else {
+			step.Skipped++
+		}
+	}
+	return step, err
+}
+
+// chargeMaintenance bills the month when date is its last business day.
+func (s *Service) chargeMaintenance(bankID int64, date, next time.Time) (Step, error) {
+	step := Step{Name: "maintenance fees"}
+	if next.Month() == date.Month() {
+		step.Note = "not the last business day of the month"
+		return step, nil
+	}
+
+	results, err := s.txs.ChargeMaintenance(bankID, date.Format("2006-01"))
+	for _, r := range results {
+		if r.Charged {
+			step.Done++
+		} else {
+			step.Skipped++
+		}
+	}
+	return step, err
+}
+
+// lineOrder is the order account types are listed in a trial balance.
+var lineOrder = []account.Type{account.TypeChecking, account.TypeSavings, account.TypeIncome, account.TypeLoan, account.TypeSuspense}
+
+// TrialBalance adds up the balances of a bank's accounts by type and checks
+// them against the postings in the ledger, and takes the debit and credit
+// totals from the general ledger.
+func (s *Service) TrialBalance(bankID int64) TrialBalance {
+	ledger := map[int64]int64{}
+	for _, tx := range s.txs.GetAllTransactions() {
+		ledger[tx.FromAccountID] -= tx.Amount
+		ledger[tx.ToAccountID] += tx.Amount
+	}
+
+	lines := map[account.Type]*Line{}
+	for _, acc := range s.accounts.GetAll() {
+		if acc.BankID != bankID {
+			continue
+		}
+		l := lines[acc.Type]
+		if l == nil {
+			l = &Line{Type: acc.Type}
+			lines[acc.Type] = l
+		}
+		l.Accounts++
+		l.Balance += acc.Balance
+		l.Ledger += ledger[acc.ID]
+	}
+
+	gltb := s.ledger.TrialBalance(bankID)
+	tb := TrialBalance{Lines: []Line{}, Debit: gltb.Debit, Credit: gltb.Credit}
+	for _, t := range lineOrder {
+		if l := lines[t]; l != nil {
+			tb.Lines = append(tb.Lines, *l)
+			tb.Balance += l.Balance
+			tb.Ledger += l.Ledger
+		}
+	}
+	return tb
+}
@@ -0,0 +1,80 @@
+package fee
+
+import "fmt"
+
+type Type string
+
+const (
+	TypeTransfer    Type = "transfer"
+	TypeWithdrawal  Type = "withdrawal"
+	TypeMaintenance Type = "maintenance"
+	TypeOverdraft   Type = "overdraft"
+	TypeLatePayment Type = "late-payment"
+)
+
+var Types = []Type{TypeTransfer, TypeWithdrawal, TypeMaintenance, TypeOverdraft, TypeLatePayment}
+
+func ParseType(s string) (Type, error) {
+	for _, t := range Types {
+		if Type(s) == t {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown fee type %q (expected transfer, withdrawal, maintenance, overdraft or late-payment)", s)
+}
+
+// Rule is how one bank prices one fee type. All amounts are in cents,
+// PercentBps is in basis points of the transaction amount (50 is 0.5%).
+// Max 0 means the fee is not capped.
+type Rule struct {
+	ID         int64 `json:"id"`
+	BankID     int64 `json:"bankid"`
+	Type       Type  `json:"type"`
+	Flat       int64 `json:"flat"`
+	PercentBps int64 `json:"percentBps"`
+	Min        int64 `json:"min"`
+	Max        int64 `json:"max"`
+}
+
+// Apply returns the fee for a transaction of the given amount.
+func (r Rule) Apply(amount int64) int64 {
+	fee := r.Flat + amount*r.PercentBps/10000
+
+	if fee < r.Min {
+		fee = r.Min
+	}
+	if r.Max > 0 && fee > r.Max {
+		fee = r.Max
+	}
+
+	return fee
+}
+
+// Defaults are the flat fees of banks that have no rule of their own for a
+// fee type. They come from the fees section of the config.
+type Defaults struct {
+	Transfer    int64 `json:"transfer"`
+	Withdrawal  int64 `json:"withdrawal"`
+	Maintenance int64 `json:"maintenance"`
+	Overdraft   int64 `json:"overdraft"`
+	LatePayment int64 `json:"latePayment"`
+}
+
+func (d Defaults) Rule(bankID int64, t Type) Rule {
+	flat := map[Type]int64{
+		TypeTransfer:    d.Transfer,
+		TypeWithdrawal:  d.Withdrawal,
+		TypeMaintenance: d.Maintenance,
+		TypeOverdraft:   d.Overdraft,
+		TypeLatePayment: d.LatePayment,
+	}[t]
+
+	return Rule{BankID: bankID, Type: t, Flat: flat}
+}
+
+// MaintenanceCharge records that an account paid its maintenance fee for a
+// month, so running the monthly charge twice does not bill it twice.
+type MaintenanceCharge struct {
+	AccountID int64  `json:"accountid"`
+	Month     string `json:"month"` // YYYY-MM
+}
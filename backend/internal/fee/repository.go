@@ -0,0 +1,126 @@
+package fee
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	rules    []*Rule // Cache for in-memory operations
+	charges  []MaintenanceCharge
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		rules:    []*Rule{},
+		charges:  []MaintenanceCharge{},
+	}
+
+	if err := storage.LoadCollection(filePath, "fees", &repo.rules); err != nil {
+		return nil, err
+	}
+	if err := storage.LoadCollection(filePath, "maintenanceCharges", &repo.charges); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, rule := range repo.rules {
+		if rule.ID >= repo.nextID {
+			repo.nextID = rule.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+// SaveRule stores the rule for its bank and fee type, replacing the old one.
+func (r *Repository) SaveRule(rule Rule) (*Rule, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var saved *Rule
+	for _, existing := range r.rules {
+		if existing.BankID == rule.BankID && existing.Type == rule.Type {
+			rule.ID = existing.ID
+			*existing = rule
+			saved = existing
+			break
+		}
+	}
+	if saved == nil {
+		rule.ID = r.nextID
+		r.nextID++
+		saved = &rule
+		r.rules = append(r.rules, saved)
+	}
+
+	if err := storage.SaveCollection(r.filePath, "fees", r.rules); err != nil {
+		return nil, fmt.Errorf("failed to save fee data: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetRule returns the bank's rule for a fee type, or nil if it has none.
+func (r *Repository) GetRule(bankID int64, t Type) *Rule {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, rule := range r.rules {
+		if rule.BankID == bankID && rule.Type == t {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteRule(bankID int64, t Type) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, rule := range r.rules {
+		if rule.BankID == bankID && rule.Type == t {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+
+			if err := storage.SaveCollection(r.filePath, "fees", r.rules); err != nil {
+				return fmt.Errorf("failed to save fee data: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bank %d has no %s fee rule", bankID, t)
+}
+
+func (r *Repository) HasCharge(accountID int64, month string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, charge := range r.charges {
+		if charge.AccountID == accountID && charge.Month == month {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Repository) AddCharge(charge MaintenanceCharge) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.charges = append(r.charges, charge)
+
+	if err := storage.SaveCollection(r.filePath, "maintenanceCharges", r.charges); err != nil {
+		return fmt.Errorf("failed to save maintenance charge data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package fee
+
+import "fmt"
+
+type Service struct {
+	repo     *Repository
+	defaults Defaults
+}
+
+func NewService(repo *Repository, defaults Defaults) *Service {
+	return &Service{
+		repo:     repo,
+		defaults: defaults,
+	}
+}
+
+func (s *Service) SetRule(rule Rule) (*Rule, error) {
+	if rule.BankID <= 0 {
+		return nil, fmt.Errorf("invalid bank ID: %d", rule.BankID)
+	}
+	if rule.Flat < 0 || rule.PercentBps < 0 || rule.Min < 0 || rule.Max < 0 {
+		return nil, fmt.Errorf("fee amounts cannot be negative")
+	}
+	if rule.PercentBps > 10000 {
+		return nil, fmt.Errorf("percentage cannot exceed 100%%")
+	}
+	if rule.Max > 0 && rule.Max < rule.Min {
+		return nil, fmt.Errorf("maximum fee cannot be below the minimum")
+	}
+
+	saved, err := s.repo.SaveRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set fee rule: %w", err)
+	}
+
+	return saved, nil
+}
+
+// RemoveRule drops a bank's own rule so the default applies again.
+func (s *Service) RemoveRule(bankID int64, t Type) error {
+	return s.repo.DeleteRule(bankID, t)
+}
+
+// Schedule returns the rule in force for every fee type at a bank, falling
+// back to the defaults where the bank has not set its own.
+func (s *Service) Schedule(bankID int64) []Rule {
+	schedule := make([]Rule, 0, len(Types))
+	for _, t := range Types {
+		schedule = append(schedule, s.rule(bankID, t))
+	}
+	return schedule
+}
+
+// Quote returns the fee a bank charges for a transaction of the given amount.
+func (s *Service) Quote(bankID int64, t Type, amount int64) int64 {
+	return s.rule(bankID, t).Apply(amount)
+}
+
+func (s *Service) rule(bankID int64, t Type) Rule {
+	if rule := s.repo.GetRule(bankID, t); rule != nil {
+		return *rule
+	}
+	return s.defaults.Rule(bankID, t)
+}
+
+func (s *Service) MaintenanceCharged(accountID int64, month string) bool {
+	return s.repo.HasCharge(accountID, month)
+}
+
+func (s *Service) RecordMaintenanceCharge(accountID int64, month string) error {
+	return s.repo.AddCharge(MaintenanceCharge{AccountID: accountID, Month: month})
+}
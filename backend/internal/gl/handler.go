@@ -0,0 +1,47 @@
+package gl
+
+import (
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/money"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+// HandleReports shows the bank operator the balance sheet and the profit and
+// loss to date.
+func (h *Handler) HandleReports(bankID int64) {
+	bs := h.service.BalanceSheet(bankID)
+	h.con.Println("\nBalance sheet")
+	h.printSection("Assets", bs.Assets, bs.TotalAssets)
+	h.printSection("Liabilities", bs.Liabilities, bs.TotalLiabilities)
+	h.printSection("Equity", bs.Equity, bs.TotalEquity)
+	h.con.Printf("  %-30s %14s\n", "Current earnings", money.Format(bs.Earnings))
+
+	pl, err := h.service.ProfitAndLoss(bankID, "", "")
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	h.con.Println("\nProfit and loss")
+	h.printSection("Income", pl.Income, pl.TotalIncome)
+	h.printSection("Expenses", pl.Expenses, pl.TotalExpenses)
+	h.con.Printf("  %-30s %14s\n", "Net profit", money.Format(pl.NetProfit))
+}
+
+func (h *Handler) printSection(title string, balances []Balance, total int64) {
+	h.con.Printf("%s\n", title)
+	for _, b := range balances {
+		h.con.Printf("    %s %-25s %14s\n", b.Code, b.Name, money.Format(b.Net()))
+	}
+	h.con.Printf("  %-30s %14s\n", "Total "+title, money.Format(total))
+}
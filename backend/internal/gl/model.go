@@ -0,0 +1,127 @@
+package gl
+
+import "time"
+
+// Category is the kind of a general ledger account. Assets and expenses
+// grow with debits, the others with credits.
+type Category string
+
+const (
+	CategoryAsset     Category = "asset"
+	CategoryLiability Category = "liability"
+	CategoryEquity    Category = "equity"
+	CategoryIncome    Category = "income"
+	CategoryExpense   Category = "expense"
+)
+
+func (c Category) debitNormal() bool {
+	return c == CategoryAsset || c == CategoryExpense
+}
+
+// Codes of the standard chart of accounts every bank gets.
+const (
+	CodeCash              = "1000"
+	CodeLoans             = "1100"
+	CodeClearing          = "1200"
+	CodeDeposits          = "2000"
+	CodeSuspense          = "2100"
+	CodeCardSettlement    = "2200"
+	CodeRetainedEarnings  = "3000"
+	CodeFeeIncome         = "4000"
+	CodeLoanInterest      = "4100"
+	CodeOverdraftInterest = "4200"
+	CodeInterestPaid      = "5000"
+)
+
+var standardChart = []Account{
+	{Code: CodeCash, Name: "Cash", Category: CategoryAsset},
+	{Code: CodeLoans, Name: "Loans to customers", Category: CategoryAsset},
+	{Code: CodeClearing, Name: "Interbank clearing", Category: CategoryAsset},
+	{Code: CodeDeposits, Name: "Customer deposits", Category: CategoryLiability},
+	{Code: CodeSuspense, Name: "Suspense", Category: CategoryLiability},
+	{Code: CodeCardSettlement, Name: "Card settlement", Category: CategoryLiability},
+	{Code: CodeRetainedEarnings, Name: "Retained earnings", Category: CategoryEquity},
+	{Code: CodeFeeIncome, Name: "Fee income", Category: CategoryIncome},
+	{Code: CodeLoanInterest, Name: "Loan interest income", Category: CategoryIncome},
+	{Code: CodeOverdraftInterest, Name: "Overdraft interest income", Category: CategoryIncome},
+	{Code: CodeInterestPaid, Name: "Interest paid", Category: CategoryExpense},
+}
+
+// Account is an account in a bank's chart of accounts.
+type Account struct {
+	ID       int64    `json:"id"`
+	BankID   int64    `json:"bankid"`
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+}
+
+// Line is one side of a journal entry, in cents.
+type Line struct {
+	Code   string `json:"code"`
+	Debit  int64  `json:"debit,omitempty"`
+	Credit int64  `json:"credit,omitempty"`
+}
+
+// Entry is a balanced journal entry in a bank's general ledger, debits equal
+// credits. Every transaction posts one entry in each bank it touches.
+type Entry struct {
+	ID            int64     `json:"id"`
+	BankID        int64     `json:"bankid"`
+	TransactionID int64     `json:"transactionid,omitempty"`
+	BusinessDate  string    `json:"businessDate"`
+	Memo          string    `json:"memo"`
+	Lines         []Line    `json:"lines"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// Balance is what has been posted to a general ledger account.
+type Balance struct {
+	Account
+	Debit  int64 `json:"debit"`
+	Credit int64 `json:"credit"`
+}
+
+// Net is the balance on the account's normal side, e.g. positive for an
+// asset with more debits than credits.
+func (b Balance) Net() int64 {
+	if b.Category.debitNormal() {
+		return b.Debit - b.Credit
+	}
+	return b.Credit - b.Debit
+}
+
+type TrialBalance struct {
+	Accounts []Balance `json:"accounts"`
+	Debit    int64     `json:"debit"`
+	Credit   int64     `json:"credit"`
+}
+
+// BalanceSheet is a bank's financial position. Earnings is the profit not yet
+// moved to retained earnings, so assets equal liabilities plus equity plus
+// earnings.
+type BalanceSheet struct {
+	Assets           []Balance `json:"assets"`
+	Liabilities      []Balance `json:"liabilities"`
+	Equity           []Balance `json:"equity"`
+	TotalAssets      int64     `json:"totalAssets"`
+	TotalLiabilities int64     `json:"totalLiabilities"`
+	TotalEquity      int64     `json:"totalEquity"`
+	Earnings         int64     `json:"earnings"`
+}
+
+// ProfitAndLoss is a bank's income and expenses over the business days From
+// through To, an empty bound is open.
+type ProfitAndLoss struct {
+	From          string    `json:"from,omitempty"`
+	To            string    `json:"to,omitempty"`
+	Income        []Balance `json:"income"`
+	Expenses      []Balance `json:"expenses"`
+	TotalIncome   int64     `json:"totalIncome"`
+	TotalExpenses int64     `json:"totalExpenses"`
+	NetProfit     int64     `json:"netProfit"`
+}
+
+func (tb TrialBalance) Balanced() bool {
+	return tb.Debit == tb.Credit
+}
@@ -0,0 +1,131 @@
+package gl
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath    string
+	mutex       sync.RWMutex
+	nextID      int64
+	nextEntryID int64
+	accounts    []*Account // Cache for in-memory operations
+	entries     []*Entry
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath:    filePath,
+		nextID:      1,
+		nextEntryID: 1,
+		accounts:    []*Account{},
+		entries:     []*Entry{},
+	}
+
+	if err := storage.LoadCollection(filePath, "glAccounts", &repo.accounts); err != nil {
+		return nil, err
+	}
+	if err := storage.LoadCollection(filePath, "journal", &repo.entries); err != nil {
+		return nil, err
+	}
+
+	// find the highest IDs to set nextID and nextEntryID correctly
+	for _, a := range repo.accounts {
+		if a.ID >= repo.nextID {
+			repo.nextID = a.ID + 1
+		}
+	}
+	for _, e := range repo.entries {
+		if e.ID >= repo.nextEntryID {
+			repo.nextEntryID = e.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+// CreateChart adds a chart of accounts for a bank.
+func (r *Repository) CreateChart(bankID int64, chart []Account) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, a := range chart {
+		a.ID = r.nextID
+		a.BankID = bankID
+		r.accounts = append(r.accounts, &a)
+		r.nextID++
+	}
+
+	if err := storage.SaveCollection(r.filePath, "glAccounts", r.accounts); err != nil {
+		return fmt.Errorf("failed to save chart of accounts: %w", err)
+	}
+	return nil
+}
+
+// GetChart returns a bank's general ledger accounts, empty if it has no
+// chart yet.
+func (r *Repository) GetChart(bankID int64) []*Account {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	chart := []*Account{}
+	for _, a := range r.accounts {
+		if a.BankID == bankID {
+			copied := *a
+			chart = append(chart, &copied)
+		}
+	}
+
+	return chart
+}
+
+func (r *Repository) CreateEntry(e Entry) (*Entry, error) {
+	created, err := r.CreateEntries([]Entry{e})
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateEntries saves entries together, either all of them are in the
+// journal afterwards or none is.
+func (r *Repository) CreateEntries(entries []Entry) ([]*Entry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	count, nextID := len(r.entries), r.nextEntryID
+	for _, e := range entries {
+		e.ID = r.nextEntryID
+		r.entries = append(r.entries, &e)
+		r.nextEntryID++
+	}
+
+	if err := storage.SaveCollection(r.filePath, "journal", r.entries); err != nil {
+		r.entries, r.nextEntryID = r.entries[:count], nextID
+		return nil, fmt.Errorf("failed to save journal: %w", err)
+	}
+
+	created := make([]*Entry, len(entries))
+	for i, e := range r.entries[count:] {
+		copied := *e
+		created[i] = &copied
+	}
+	return created, nil
+}
+
+func (r *Repository) GetEntries(bankID int64) []*Entry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entries := []*Entry{}
+	for _, e := range r.entries {
+		if e.BankID == bankID {
+			copied := *e
+			entries = append(entries, &copied)
+		}
+	}
+
+	return entries
+}
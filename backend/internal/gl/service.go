@@ -0,0 +1,340 @@
+package gl
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Service keeps a general ledger per bank. It is the journal of the
+// transactions service, so every posted transaction is booked here too.
+type Service struct {
+	repo     *Repository
+	accounts *account.Repository
+}
+
+func NewService(repo *Repository, accounts *account.Repository) *Service {
+	return &Service{
+		repo:     repo,
+		accounts: accounts,
+	}
+}
+
+// Open gives a bank that has no chart of accounts yet the standard chart,
+// with an opening entry that brings in the balances its accounts already
+// have. Income collected so far counts as retained earnings and what the
+// bank holds against the rest counts as cash.
+func (s *Service) Open(bankID int64) error {
+	if len(s.repo.GetChart(bankID)) > 0 {
+		return nil
+	}
+	if err := s.repo.CreateChart(bankID, standardChart); err != nil {
+		return err
+	}
+
+	var lines []Line
+	var net int64
+	for _, acc := range s.accounts.GetAll() {
+		if acc.BankID != bankID || acc.Balance == 0 {
+			continue
+		}
+		code := CodeRetainedEarnings
+		if acc.Type != account.TypeIncome {
+			code = accountCode(acc, "")
+		}
+		lines = append(lines, credit(code, acc.Balance))
+		net += acc.Balance
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	lines = append(lines, debit(CodeCash, net))
+
+	_, err := s.repo.CreateEntry(Entry{
+		BankID:       bankID,
+		BusinessDate: time.Now().Format(time.DateOnly),
+		Memo:         "opening balances",
+		Lines:        merge(lines),
+		CreatedAt:    time.Now(),
+	})
+	return err
+}
+
+// Post books a transaction in the general ledger of every bank it touches.
+// The paying side is debited and the receiving side credited. Money from or
+// to outside the bank goes through the account for its type, such as cash
+// for deposits, and a transfer between banks goes through interbank clearing
+// on both sides.
+func (s *Service) Post(tx *transactions.Transaction) error {
+	from, err := s.side(tx.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := s.side(tx.ToAccountID)
+	if err != nil {
+		return err
+	}
+
+	entries := map[int64][]Line{}
+	switch {
+	case from == nil && to == nil:
+		return fmt.Errorf("transaction %d has no account", tx.Id)
+	case from == nil:
+		entries[to.BankID] = []Line{debit(externalCode(tx.Type), tx.Amount), credit(accountCode(to, tx.Type), tx.Amount)}
+	case to == nil:
+		entries[from.BankID] = []Line{debit(accountCode(from, tx.Type), tx.Amount), credit(externalCode(tx.Type), tx.Amount)}
+	case from.BankID == to.BankID:
+		entries[from.BankID] = []Line{debit(accountCode(from, tx.Type), tx.Amount), credit(accountCode(to, tx.Type), tx.Amount)}
+	default:
+		entries[from.BankID] = []Line{debit(accountCode(from, tx.Type), tx.Amount), credit(CodeClearing, tx.Amount)}
+		entries[to.BankID] = []Line{debit(CodeClearing, tx.Amount), credit(accountCode(to, tx.Type), tx.Amount)}
+	}
+
+	date := tx.BusinessDate
+	if date == "" {
+		date = tx.CreatedAt.Format(time.DateOnly)
+	}
+	memo := tx.Memo
+	if memo == "" {
+		memo = string(tx.Type)
+	}
+
+	banks := make([]int64, 0, len(entries))
+	for bankID := range entries {
+		banks = append(banks, bankID)
+	}
+	sort.Slice(banks, func(i, j int) bool { return banks[i] < banks[j] })
+
+	journal := make([]Entry, 0, len(banks))
+	for _, bankID := range banks {
+		// a bank journals from its first transaction on when it has no
+		// chart yet, there is nothing to open with
+		if len(s.repo.GetChart(bankID)) == 0 {
+			if err := s.repo.CreateChart(bankID, standardChart); err != nil {
+				return err
+			}
+		}
+		journal = append(journal, Entry{
+			BankID:        bankID,
+			TransactionID: tx.Id,
+			BusinessDate:  date,
+			Memo:          memo,
+			Lines:         entries[bankID],
+			CreatedAt:     tx.CreatedAt,
+		})
+	}
+
+	// both sides of a transfer between banks are saved in one write, so
+	// neither bank is left with its half alone
+	_, err = s.repo.CreateEntries(journal)
+	return err
+}
+
+func (s *Service) side(accountID int64) (*account.Account, error) {
+	if accountID == 0 {
+		return nil, nil
+	}
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account %d: %w", accountID, err)
+	}
+	return acc, nil
+}
+
+// accountCode is the general ledger account a bank account posts into.
+// The income account splits by what the money was collected for.
+func accountCode(acc *account.Account, txType transactions.Type) string {
+	switch acc.Type {
+	case account.TypeLoan:
+		return CodeLoans
+	case account.TypeSuspense:
+		return CodeSuspense
+	case account.TypeIncome:
+		switch txType {
+		case transactions.TypeLoanInterest:
+			return CodeLoanInterest
+		case transactions.TypeOverdraftInterest:
+			return CodeOverdraftInterest
+		}
+		return CodeFeeIncome
+	}
+	return CodeDeposits
+}
+
+// externalCode is the general ledger account of the side of a transaction
+// outside the bank.
+func externalCode(txType transactions.Type) string {
+	switch txType {
+	case transactions.TypeInterest:
+		return CodeInterestPaid
+	case transactions.TypeCard:
+		return CodeCardSettlement
+	}
+	return CodeCash
+}
+
+func debit(code string, amount int64) Line {
+	if amount < 0 {
+		return Line{Code: code, Credit: -amount}
+	}
+	return Line{Code: code, Debit: amount}
+}
+
+func credit(code string, amount int64) Line {
+	if amount < 0 {
+		return Line{Code: code, Debit: -amount}
+	}
+	return Line{Code: code, Credit: amount}
+}
+
+// merge nets the lines posted to the same account, so the opening entry
+// shows each account once.
+func merge(lines []Line) []Line {
+	net := map[string]int64{}
+	var codes []string
+	for _, l := range lines {
+		if _, ok := net[l.Code]; !ok {
+			codes = append(codes, l.Code)
+		}
+		net[l.Code] += l.Debit - l.Credit
+	}
+
+	merged := []Line{}
+	for _, code := range codes {
+		if net[code] != 0 {
+			merged = append(merged, debit(code, net[code]))
+		}
+	}
+	return merged
+}
+
+func (s *Service) GetChart(bankID int64) []*Account {
+	return s.repo.GetChart(bankID)
+}
+
+func (s *Service) GetJournal(bankID int64) []*Entry {
+	return s.repo.GetEntries(bankID)
+}
+
+// balances adds up what was posted to each account of a bank's chart on the
+// business days from through to, an empty bound is open.
+func (s *Service) balances(bankID int64, from, to string) []Balance {
+	chart := s.repo.GetChart(bankID)
+	sort.Slice(chart, func(i, j int) bool { return chart[i].Code < chart[j].Code })
+
+	totals := map[string]*Balance{}
+	balances := make([]Balance, len(chart))
+	for i, a := range chart {
+		balances[i] = Balance{Account: *a}
+		totals[a.Code] = &balances[i]
+	}
+
+	for _, e := range s.repo.GetEntries(bankID) {
+		if (from != "" && e.BusinessDate < from) || (to != "" && e.BusinessDate > to) {
+			continue
+		}
+		for _, l := range e.Lines {
+			if b, ok := totals[l.Code]; ok {
+				b.Debit += l.Debit
+				b.Credit += l.Credit
+			}
+		}
+	}
+
+	return balances
+}
+
+func (s *Service) TrialBalance(bankID int64) TrialBalance {
+	tb := TrialBalance{Accounts: s.balances(bankID, "", "")}
+	for _, b := range tb.Accounts {
+		tb.Debit += b.Debit
+		tb.Credit += b.Credit
+	}
+	return tb
+}
+
+func (s *Service) BalanceSheet(bankID int64) BalanceSheet {
+	bs := BalanceSheet{Assets: []Balance{}, Liabilities: []Balance{}, Equity: []Balance{}}
+	for _, b := range s.balances(bankID, "", "") {
+		switch b.Category {
+		case CategoryAsset:
+			bs.Assets = append(bs.Assets, b)
+			bs.TotalAssets += b.Net()
+		case CategoryLiability:
+			bs.Liabilities = append(bs.Liabilities, b)
+			bs.TotalLiabilities += b.Net()
+		case CategoryEquity:
+			bs.Equity = append(bs.Equity, b)
+			bs.TotalEquity += b.Net()
+		case CategoryIncome:
+			bs.Earnings += b.Net()
+		case CategoryExpense:
+			bs.Earnings -= b.Net()
+		}
+	}
+	return bs
+}
+
+// ProfitAndLoss reports a bank's income and expenses over the business days
+// from through to (YYYY-MM-DD), an empty bound is open.
+func (s *Service) ProfitAndLoss(bankID int64, from, to string) (ProfitAndLoss, error) {
+	for _, d := range []string{from, to} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, d); err != nil {
+			return ProfitAndLoss{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", d)
+		}
+	}
+	if from != "" && to != "" && from > to {
+		return ProfitAndLoss{}, fmt.Errorf("the period starts after it ends")
+	}
+
+	pl := ProfitAndLoss{From: from, To: to, Income: []Balance{}, Expenses: []Balance{}}
+	for _, b := range s.balances(bankID, from, to) {
+		switch b.Category {
+		case CategoryIncome:
+			pl.Income = append(pl.Income, b)
+			pl.TotalIncome += b.Net()
+		case CategoryExpense:
+			pl.Expenses = append(pl.Expenses, b)
+			pl.TotalExpenses += b.Net()
+		}
+	}
+	pl.NetProfit = pl.TotalIncome - pl.TotalExpenses
+	return pl, nil
+}
+
+// Adjust books amount on the normal side of a general ledger account against
+// cash, a negative amount on the other side, in the business day day. It is
+// used to correct the ledger when it has drifted from the accounts.
+func (s *Service) Adjust(bankID int64, code string, amount int64, day time.Time, memo string) (*Entry, error) {
+	var acc *Account
+	for _, a := range s.repo.GetChart(bankID) {
+		if a.Code == code {
+			acc = a
+		}
+	}
+	if acc == nil {
+		return nil, fmt.Errorf("bank %d has no general ledger account %s", bankID, code)
+	}
+
+	lines := []Line{credit(code, amount), debit(CodeCash, amount)}
+	if acc.Category.debitNormal() {
+		lines = []Line{debit(code, amount), credit(CodeCash, amount)}
+	}
+
+	entry, err := s.repo.CreateEntry(Entry{
+		BankID:       bankID,
+		BusinessDate: day.Format(time.DateOnly),
+		Memo:         memo,
+		Lines:        lines,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,42 @@
+package hold
+
+import "time"
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	// StatusCaptured holds were turned into a payment.
+	StatusCaptured Status = "captured"
+	// StatusReleased holds gave the money back to spend.
+	StatusReleased Status = "released"
+	// StatusExpired holds were not captured in time and released
+	// automatically.
+	StatusExpired Status = "expired"
+)
+
+// Policy is how holds are placed. Expiry is how long a hold lasts before it
+// is released automatically, 0 keeps holds until they are settled.
+type Policy struct {
+	Expiry time.Duration `json:"expiry"`
+}
+
+// Hold sets money aside on an account, so it can no longer be spent but has
+// not been taken from the balance yet. Reference says what it is for, e.g.
+// a card authorisation. A hold not settled by ExpiresAt is released
+// automatically, see Service.ExpireDue.
+type Hold struct {
+	ID        int64      `json:"id"`
+	AccountID int64      `json:"accountid"`
+	Amount    int64      `json:"amount"`
+	Reference string     `json:"reference"`
+	Status    Status     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	SettledAt *time.Time `json:"settledAt,omitempty"`
+}
+
+// Expired reports whether an active hold has run out at t.
+func (h *Hold) Expired(t time.Time) bool {
+	return h.Status == StatusActive && h.ExpiresAt != nil && !t.Before(*h.ExpiresAt)
+}
@@ -0,0 +1,118 @@
+package hold
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	holds    []*Hold // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		holds:    []*Hold{},
+	}
+
+	if err := storage.LoadCollection(filePath, "holds", &repo.holds); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, h := range repo.holds {
+		if h.ID >= repo.nextID {
+			repo.nextID = h.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "holds", r.holds); err != nil {
+		return fmt.Errorf("failed to save hold data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(h Hold) (*Hold, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	h.ID = r.nextID
+	r.holds = append(r.holds, &h)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	copied := h
+	return &copied, nil
+}
+
+func (r *Repository) Update(h *Hold) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.holds {
+		if existing.ID == h.ID {
+			copied := *h
+			r.holds[i] = &copied
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("hold with ID %d not found", h.ID)
+}
+
+func (r *Repository) GetByID(id int64) (*Hold, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, h := range r.holds {
+		if h.ID == id {
+			copied := *h
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("hold with ID %d not found", id)
+}
+
+func (r *Repository) GetByAccountID(accountID int64) []*Hold {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	holds := []*Hold{}
+	for _, h := range r.holds {
+		if h.AccountID == accountID {
+			copied := *h
+			holds = append(holds, &copied)
+		}
+	}
+
+	return holds
+}
+
+// GetActive returns the holds that have not been settled yet.
+func (r *Repository) GetActive() []*Hold {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	holds := []*Hold{}
+	for _, h := range r.holds {
+		if h.Status == StatusActive {
+			copied := *h
+			holds = append(holds, &copied)
+		}
+	}
+
+	return holds
+}
@@ -0,0 +1,174 @@
+package hold
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/money"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrExpired is returned, wrapped with the hold, when capturing a hold that
+// has run out.
+var ErrExpired = errors.New("hold has expired")
+
+type Service struct {
+	repo     *Repository
+	accounts *account.Repository
+	policy   Policy
+
+	// settling keeps two settlements of the same hold, such as a capture
+	// and the expiry ticker, from both giving the money back
+	settling sync.Mutex
+}
+
+func NewService(repo *Repository, accounts *account.Repository, policy Policy) *Service {
+	return &Service{
+		repo:     repo,
+		accounts: accounts,
+		policy:   policy,
+	}
+}
+
+// Place sets amount aside on a customer account. It fails with an error
+// wrapping transactions.ErrInsufficientFunds when the account does not have
+// that much available.
+func (s *Service) Place(accountID, amount int64, reference string) (*Hold, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("hold amount must be positive")
+	}
+
+	// holds that ran out count against the available balance no longer
+	if _, err := s.ExpireDue(time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to release expired holds: %w", err)
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Internal() {
+		return nil, fmt.Errorf("internal bank accounts cannot be held")
+	}
+	if acc.Available() < amount {
+		return nil, fmt.Errorf("%w in account %d: %s available", transactions.ErrInsufficientFunds, acc.ID, money.Format(acc.Available()))
+	}
+
+	h := Hold{
+		AccountID: accountID,
+		Amount:    amount,
+		Reference: reference,
+		Status:    StatusActive,
+		CreatedAt: time.Now(),
+	}
+	if s.policy.Expiry > 0 {
+		expires := h.CreatedAt.Add(s.policy.Expiry)
+		h.ExpiresAt = &expires
+	}
+
+	created, err := s.repo.Create(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to place hold: %w", err)
+	}
+	if err := s.accounts.AdjustHeld(accountID, amount); err != nil {
+		return nil, fmt.Errorf("failed to place hold: %w", err)
+	}
+
+	return created, nil
+}
+
+// Capture ends a hold that is about to be paid, the caller posts the
+// payment.
+func (s *Service) Capture(id int64) (*Hold, error) {
+	return s.settle(id, StatusCaptured)
+}
+
+// Release ends a hold without a payment, the money can be spent again.
+func (s *Service) Release(id int64) (*Hold, error) {
+	return s.settle(id, StatusReleased)
+}
+
+func (s *Service) GetHold(id int64) (*Hold, error) {
+	return s.repo.GetByID(id)
+}
+
+// ExpireDue releases every hold that has run out by now and returns them.
+func (s *Service) ExpireDue(now time.Time) ([]*Hold, error) {
+	expired := []*Hold{}
+	for _, h := range s.repo.GetActive() {
+		if !h.Expired(now) {
+			continue
+		}
+		h, err := s.settle(h.ID, StatusExpired)
+		if err != nil {
+			return expired, err
+		}
+		expired = append(expired, h)
+	}
+	return expired, nil
+}
+
+// GetAccountHolds returns the holds of an account, only the active ones that
+// have not run out if activeOnly is set.
+func (s *Service) GetAccountHolds(accountID int64, activeOnly bool) []*Hold {
+	now := time.Now()
+	holds := []*Hold{}
+	for _, h := range s.repo.GetByAccountID(accountID) {
+		if !activeOnly || (h.Status == StatusActive && !h.Expired(now)) {
+			holds = append(holds, h)
+		}
+	}
+	return holds
+}
+
+// Available is what can be spent from an account at now. Holds that have
+// run out no longer count even if ExpireDue has not released them yet, so
+// views that only read do not have to save anything.
+func (s *Service) Available(acc *account.Account, now time.Time) int64 {
+	available := acc.Available()
+	for _, h := range s.repo.GetByAccountID(acc.ID) {
+		if h.Status == StatusActive && h.Expired(now) {
+			available += h.Amount
+		}
+	}
+	return available
+}
+
+func (s *Service) settle(id int64, status Status) (*Hold, error) {
+	s.settling.Lock()
+	defer s.settling.Unlock()
+
+	h, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if h.Status != StatusActive {
+		return nil, fmt.Errorf("hold %d is already %s", id, h.Status)
+	}
+	// a hold that ran out before ExpireDue got to it cannot be captured
+	// any more, it is released as expired instead
+	expired := status == StatusCaptured && h.Expired(time.Now())
+	if expired {
+		status = StatusExpired
+	}
+
+	now := time.Now()
+	h.Status = status
+	h.SettledAt = &now
+	if err := s.repo.Update(h); err != nil {
+		return nil, fmt.Errorf("failed to update hold: %w", err)
+	}
+	if err := s.accounts.AdjustHeld(h.AccountID, -h.Amount); err != nil {
+		return nil, fmt.Errorf("failed to update hold: %w", err)
+	}
+
+	if expired {
+		return nil, fmt.Errorf("hold %d: %w", id, ErrExpired)
+	}
+	return h, nil
+}
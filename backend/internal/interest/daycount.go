@@ -0,0 +1,40 @@
+package interest
+
+import "time"
+
+// dayFraction returns the share of a year that day d earns under dc, as a
+// numerator and denominator. Day d covers the period up to the next day.
+func dayFraction(dc DayCount, d time.Time) (int64, int64) {
+	if dc == Thirty360 {
+		return days360(d, d.AddDate(0, 0, 1)), 360
+	}
+	return 1, 365
+}
+
+// days360 counts the days between two dates by the 30/360 (US) rules. Summed
+// day by day every month comes to exactly 30: a 31st counts as the 30th, so
+// in a 31-day month the day starting on the 30th is worth nothing, and the
+// last day of February is worth the days February is short.
+func days360(from, to time.Time) int64 {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return int64(360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1))
+}
+
+// truncateDay drops the time of day, keeping the location.
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func isLastOfMonth(d time.Time) bool {
+	return d.AddDate(0, 0, 1).Month() != d.Month()
+}
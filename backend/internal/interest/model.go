@@ -0,0 +1,59 @@
+package interest
+
+import (
+	"fmt"
+	"time"
+)
+
+type DayCount string
+
+const (
+	// Actual365 gives every calendar day 1/365 of the annual rate.
+	Actual365 DayCount = "actual/365"
+	// Thirty360 treats every month as 30 days of a 360 day year.
+	Thirty360 DayCount = "30/360"
+)
+
+func ParseDayCount(s string) (DayCount, error) {
+	switch DayCount(s) {
+	case Actual365, Thirty360:
+		return DayCount(s), nil
+	}
+	return "", fmt.Errorf("unknown day count %q (expected %q or %q)", s, Actual365, Thirty360)
+}
+
+// Product is a savings product a bank offers. RateBps is the annual rate in
+// basis points, 250 is 2.50%.
+type Product struct {
+	ID       int64    `json:"id"`
+	BankID   int64    `json:"bankid"`
+	Name     string   `json:"name"`
+	RateBps  int64    `json:"rateBps"`
+	DayCount DayCount `json:"dayCount"`
+}
+
+func NewProduct(id, bankID int64, name string, rateBps int64, dayCount DayCount) *Product {
+	return &Product{
+		ID:       id,
+		BankID:   bankID,
+		Name:     name,
+		RateBps:  rateBps,
+		DayCount: dayCount,
+	}
+}
+
+// MicrosPerCent is the precision interest accrues at. Daily interest is
+// usually a fraction of a cent, so it is kept in millionths of a cent until
+// it is capitalised.
+const MicrosPerCent = 1_000_000
+
+// Accrual is the interest state of one account. AccruedThrough is the last
+// day interest was accrued for, Accrued is the interest earned and
+// OverdraftAccrued the overdraft interest owed that has not been capitalised
+// yet, both in millionths of a cent.
+type Accrual struct {
+	AccountID        int64     `json:"accountid"`
+	AccruedThrough   time.Time `json:"accruedThrough"`
+	Accrued          int64     `json:"accrued"`
+	OverdraftAccrued int64     `json:"overdraftAccrued,omitempty"`
+}
@@ -0,0 +1,132 @@
+package interest
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	products []*Product // Cache for in-memory operations
+	accruals []*Accrual
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		products: []*Product{},
+		accruals: []*Accrual{},
+	}
+
+	if err := storage.LoadCollection(filePath, "products", &repo.products); err != nil {
+		return nil, err
+	}
+	if err := storage.LoadCollection(filePath, "accruals", &repo.accruals); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, product := range repo.products {
+		if product.ID >= repo.nextID {
+			repo.nextID = product.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) CreateProduct(bankID int64, name string, rateBps int64, dayCount DayCount) (*Product, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	product := NewProduct(r.nextID, bankID, name, rateBps, dayCount)
+	r.products = append(r.products, product)
+	r.nextID++
+
+	if err := storage.SaveCollection(r.filePath, "products", r.products); err != nil {
+		return nil, fmt.Errorf("failed to save product data: %w", err)
+	}
+
+	return product, nil
+}
+
+func (r *Repository) GetProduct(id int64) (*Product, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, product := range r.products {
+		if product.ID == id {
+			return product, nil
+		}
+	}
+
+	return nil, fmt.Errorf("product with ID %d not found", id)
+}
+
+func (r *Repository) GetProductsByBankID(bankID int64) []*Product {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	products := []*Product{}
+	for _, product := range r.products {
+		if product.BankID == bankID {
+			products = append(products, product)
+		}
+	}
+
+	return products
+}
+
+func (r *Repository) GetAllProducts() []*Product {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Return a copy to avoid external modification
+	products := make([]*Product, len(r.products))
+	copy(products, r.products)
+
+	return products
+}
+
+// GetAccrual returns the accrual state of an account, or nil if interest has
+// never been accrued for it.
+func (r *Repository) GetAccrual(accountID int64) *Accrual {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, accrual := range r.accruals {
+		if accrual.AccountID == accountID {
+			return accrual
+		}
+	}
+
+	return nil
+}
+
+// SaveAccrual stores the accrual state of an account, replacing the old one.
+func (r *Repository) SaveAccrual(accrual *Accrual) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	found := false
+	for i, existing := range r.accruals {
+		if existing.AccountID == accrual.AccountID {
+			r.accruals[i] = accrual
+			found = true
+			break
+		}
+	}
+	if !found {
+		r.accruals = append(r.accruals, accrual)
+	}
+
+	if err := storage.SaveCollection(r.filePath, "accruals", r.accruals); err != nil {
+		return fmt.Errorf("failed to save accrual data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package interest
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Service struct {
+	repo         *Repository
+	accounts     *account.Repository
+	transactions *transactions.Service
+}
+
+func NewService(repo *Repository, accounts *account.Repository, txs *transactions.Service) *Service {
+	return &Service{
+		repo:         repo,
+		accounts:     accounts,
+		transactions: txs,
+	}
+}
+
+func (s *Service) CreateProduct(bankID int64, name string, rateBps int64, dayCount DayCount) (*Product, error) {
+	if bankID <= 0 {
+		return nil, fmt.Errorf("invalid bank ID: %d", bankID)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("product name cannot be empty")
+	}
+	if rateBps < 0 || rateBps > 10000 {
+		return nil, fmt.Errorf("rate must be between 0%% and 100%%")
+	}
+
+	product, err := s.repo.CreateProduct(bankID, name, rateBps, dayCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	return product, nil
+}
+
+func (s *Service) GetProduct(id int64) (*Product, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid product ID: %d", id)
+	}
+	return s.repo.GetProduct(id)
+}
+
+func (s *Service) GetBankProducts(bankID int64) []*Product {
+	return s.repo.GetProductsByBankID(bankID)
+}
+
+func (s *Service) GetAllProducts() []*Product {
+	return s.repo.GetAllProducts()
+}
+
+// AccrualResult is what one run did for one account.
+type AccrualResult struct {
+	AccountID        int64     `json:"accountId"`
+	From             time.Time `json:"from"`             // first day accrued by this run
+	Through          time.Time `json:"through"`          // last day accrued by this run
+	Days             int       `json:"days"`             // 0 when the account was up to date
+	Accrued          int64     `json:"accrued"`          // millionths of a cent
+	Capitalised      int64     `json:"capitalised"`      // cents posted to the account
+	Pending          int64     `json:"pending"`          // millionths of a cent, not yet capitalised
+	OverdraftAccrued int64     `json:"overdraftAccrued"` // millionths of a cent
+	OverdraftCharged int64     `json:"overdraftCharged"` // cents debited from the account
+	OverdraftPending int64     `json:"overdraftPending"` // millionths of a cent, not yet charged
+	Skipped          string    `json:"skipped,omitempty"`
+}
+
+// Accrue brings every savings account, and every account with an overdraft
+// rate, up to date through the given day. Each account continues from the
+// last day it was accrued for, so running it after downtime backfills every
+// missed day. A day earns savings interest on a positive end-of-day balance
+// and costs overdraft interest (actual/365) on a negative one. The balance
+// is rebuilt from the ledger, and both are capitalised on the last day of
+// every month. With dryRun nothing is posted or saved.
+func (s *Service) Accrue(through time.Time, dryRun bool) ([]AccrualResult, error) {
+	through = truncateDay(through)
+	if !through.Before(truncateDay(time.Now())) {
+		return nil, fmt.Errorf("interest can only be accrued for days that are over, the latest is %s", truncateDay(time.Now()).AddDate(0, 0, -1).Format(time.DateOnly))
+	}
+	return s.accrue(0, through, dryRun)
+}
+
+// AccrueBank accrues the accounts of one bank through the business day it is
+// closing, which counts as over even if the calendar day is not.
+func (s *Service) AccrueBank(bankID int64, through time.Time) ([]AccrualResult, error) {
+	return s.accrue(bankID, truncateDay(through), false)
+}
+
+func (s *Service) accrue(bankID int64, through time.Time, dryRun bool) ([]AccrualResult, error) {
+	var results []AccrualResult
+	for _, acc := range s.accounts.GetAll() {
+		if acc.Type != account.TypeSavings && acc.OverdraftRateBps == 0 {
+			continue
+		}
+		if bankID != 0 && acc.BankID != bankID {
+			continue
+		}
+
+		result, err := s.accrueAccount(acc, through, dryRun)
+		if err != nil {
+			return results, fmt.Errorf("account %d: %w", acc.ID, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *Service) accrueAccount(acc *account.Account, through time.Time, dryRun bool) (AccrualResult, error) {
+	result := AccrualResult{AccountID: acc.ID}
+
+	product, err := s.repo.GetProduct(acc.ProductID)
+	if err != nil && acc.OverdraftRateBps == 0 {
+		result.Skipped = "no savings product"
+		return result, nil
+	}
+
+	var next Accrual
+	if state := s.repo.GetAccrual(acc.ID); state != nil {
+		// work on a copy so a dry run leaves the stored state alone
+		next = *state
+	} else {
+		// interest starts on the day the account was opened, or for an
+		// account that only pays overdraft interest when that was set up
+		start := truncateDay(acc.CreatedAt.In(through.Location()))
+		if product == nil && acc.OverdraftSince != nil {
+			if since := truncateDay(acc.OverdraftSince.In(through.Location())); since.After(start) {
+				start = since
+			}
+		}
+		next = Accrual{AccountID: acc.ID, AccruedThrough: start.AddDate(0, 0, -1)}
+	}
+
+	result.From = truncateDay(next.AccruedThrough.In(through.Location())).AddDate(0, 0, 1)
+
+	// The ledger is read once, interest posted during this run is tracked
+	// in Capitalised and OverdraftCharged instead.
+	startBalance := acc.Balance
+	txs := s.transactions.GetAccountTransactions(acc.ID)
+
+	for d := result.From; !d.After(through); d = d.AddDate(0, 0, 1) {
+		endOfDay := d.AddDate(0, 0, 1)
+		balance := balanceAt(acc.ID, startBalance, txs, endOfDay) + result.Capitalised - result.OverdraftCharged
+
+		switch {
+		case balance > 0 && product != nil:
+			num, den := dayFraction(product.DayCount, d)
+			micros := balance * product.RateBps * num * (MicrosPerCent / 10000) / den
+			next.Accrued += micros
+			result.Accrued += micros
+		case balance < 0 && acc.OverdraftRateBps > 0:
+			num, den := dayFraction(Actual365, d)
+			micros := -balance * acc.OverdraftRateBps * num * (MicrosPerCent / 10000) / den
+			next.OverdraftAccrued += micros
+			result.OverdraftAccrued += micros
+		}
+		next.AccruedThrough = d
+		result.Through = d
+		result.Days++
+
+		if isLastOfMonth(d) && product != nil && next.Accrued >= MicrosPerCent {
+			cents := next.Accrued / MicrosPerCent
+			if !dryRun {
+				memo := fmt.Sprintf("%s interest for %s", product.Name, d.Format("January 2006"))
+				if _, err := s.transactions.PostInterest(acc.ID, cents, endOfDay.Add(-time.Second), memo); err != nil {
+					return result, err
+				}
+			}
+			// the fraction of a cent carries over to next month
+			next.Accrued -= cents * MicrosPerCent
+			result.Capitalised += cents
+		}
+
+		if isLastOfMonth(d) && next.OverdraftAccrued >= MicrosPerCent {
+			cents := next.OverdraftAccrued / MicrosPerCent
+			if !dryRun {
+				memo := fmt.Sprintf("overdraft interest for %s", d.Format("January 2006"))
+				if _, err := s.transactions.ChargeOverdraftInterest(acc.ID, cents, endOfDay.Add(-time.Second), memo); err != nil {
+					return result, err
+				}
+			}
+			next.OverdraftAccrued -= cents * MicrosPerCent
+			result.OverdraftCharged += cents
+		}
+
+		if !dryRun && isLastOfMonth(d) {
+			// save at every month end so a failure later on cannot make a
+			// rerun post the same month twice
+			saved := next
+			if err := s.repo.SaveAccrual(&saved); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	result.Pending = next.Accrued
+	result.OverdraftPending = next.OverdraftAccrued
+
+	if !dryRun && result.Days > 0 {
+		if err := s.repo.SaveAccrual(&next); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// balanceAt rebuilds what the balance was at the given moment by undoing
+// every transaction recorded at or after it.
+func balanceAt(accountID, current int64, txs []*transactions.Transaction, at time.Time) int64 {
+	balance := current
+	for _, tx := range txs {
+		if tx.CreatedAt.Before(at) {
+			continue
+		}
+		if tx.ToAccountID == accountID {
+			balance -= tx.Amount
+		}
+		if tx.FromAccountID == accountID {
+			balance += tx.Amount
+		}
+	}
+	return balance
+}
@@ -0,0 +1,55 @@
+package limit
+
+import "time"
+
+// Rule caps how much a customer can move out of their accounts. A rule with
+// CustomerID 0 applies to every customer of the bank. Amounts are in cents
+// and 0 leaves the cap to the rule below it (see Service.Effective), so a
+// customer rule only needs the caps that differ from the bank's.
+//
+// A rule with ExpiresAt is a temporary raise by the bank operator, it stops
+// applying at that moment.
+type Rule struct {
+	ID               int64      `json:"id"`
+	BankID           int64      `json:"bankid"`
+	CustomerID       int64      `json:"customerid,omitempty"`
+	MaxTransaction   int64      `json:"maxTransaction,omitempty"`
+	DailyOutflow     int64      `json:"dailyOutflow,omitempty"`
+	MonthlyOutflow   int64      `json:"monthlyOutflow,omitempty"`
+	TransfersPerHour int        `json:"transfersPerHour,omitempty"`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Temporary reports whether the rule is a temporary raise.
+func (r Rule) Temporary() bool {
+	return r.ExpiresAt != nil
+}
+
+func (r Rule) active(at time.Time) bool {
+	return r.ExpiresAt == nil || at.Before(*r.ExpiresAt)
+}
+
+// over lays the non-zero caps of r over base.
+func (r Rule) over(base Rule) Rule {
+	if r.MaxTransaction != 0 {
+		base.MaxTransaction = r.MaxTransaction
+	}
+	if r.DailyOutflow != 0 {
+		base.DailyOutflow = r.DailyOutflow
+	}
+	if r.MonthlyOutflow != 0 {
+		base.MonthlyOutflow = r.MonthlyOutflow
+	}
+	if r.TransfersPerHour != 0 {
+		base.TransfersPerHour = r.TransfersPerHour
+	}
+	return base
+}
+
+// Usage is what a customer has already moved out of their accounts, worked
+// out from the ledger by the caller.
+type Usage struct {
+	Today             int64 `json:"today"`
+	ThisMonth         int64 `json:"thisMonth"`
+	TransfersLastHour int   `json:"transfersLastHour"`
+}
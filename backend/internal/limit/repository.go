@@ -0,0 +1,107 @@
+package limit
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	rules    []*Rule // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		rules:    []*Rule{},
+	}
+
+	if err := storage.LoadCollection(filePath, "limits", &repo.rules); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, rule := range repo.rules {
+		if rule.ID >= repo.nextID {
+			repo.nextID = rule.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "limits", r.rules); err != nil {
+		return fmt.Errorf("failed to save limit data: %w", err)
+	}
+	return nil
+}
+
+// Save stores a rule, replacing the bank's or customer's existing rule of the
+// same kind (permanent or temporary).
+func (r *Repository) Save(rule Rule) (*Rule, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.rules {
+		if existing.BankID == rule.BankID && existing.CustomerID == rule.CustomerID && existing.Temporary() == rule.Temporary() {
+			rule.ID = existing.ID
+			r.rules[i] = &rule
+			return &rule, r.saveData()
+		}
+	}
+
+	rule.ID = r.nextID
+	r.nextID++
+	r.rules = append(r.rules, &rule)
+
+	return &rule, r.saveData()
+}
+
+func (r *Repository) Delete(bankID, customerID int64, temporary bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.rules {
+		if existing.BankID == bankID && existing.CustomerID == customerID && existing.Temporary() == temporary {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("no such limit rule")
+}
+
+// Find returns the bank's or customer's permanent or temporary rule, or nil.
+func (r *Repository) Find(bankID, customerID int64, temporary bool) *Rule {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, rule := range r.rules {
+		if rule.BankID == bankID && rule.CustomerID == customerID && rule.Temporary() == temporary {
+			copied := *rule
+			return &copied
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Rule {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rules := []*Rule{}
+	for _, rule := range r.rules {
+		if rule.BankID == bankID {
+			copied := *rule
+			rules = append(rules, &copied)
+		}
+	}
+
+	return rules
+}
@@ -0,0 +1,104 @@
+package limit
+
+import (
+	"banking-app/backend/pkg/money"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLimitExceeded is returned, wrapped with the reason, when a debit would
+// break one of the customer's limits.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) SetRule(rule Rule) (*Rule, error) {
+	if rule.BankID <= 0 {
+		return nil, fmt.Errorf("invalid bank ID: %d", rule.BankID)
+	}
+	if rule.CustomerID < 0 {
+		return nil, fmt.Errorf("invalid customer ID: %d", rule.CustomerID)
+	}
+	if rule.MaxTransaction < 0 || rule.DailyOutflow < 0 || rule.MonthlyOutflow < 0 || rule.TransfersPerHour < 0 {
+		return nil, fmt.Errorf("limits cannot be negative")
+	}
+	if rule.ExpiresAt != nil && !rule.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("a temporary limit must expire in the future")
+	}
+
+	saved, err := s.repo.Save(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set limit: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *Service) RemoveRule(bankID, customerID int64, temporary bool) error {
+	return s.repo.Delete(bankID, customerID, temporary)
+}
+
+func (s *Service) GetBankRules(bankID int64) []*Rule {
+	return s.repo.GetByBankID(bankID)
+}
+
+// Effective is the limits that apply to a customer at the given moment. The
+// rules are laid over each other, each non-zero cap replacing the one below:
+// the bank's rule, the bank's temporary raise, the customer's rule and the
+// customer's temporary raise.
+func (s *Service) Effective(bankID, customerID int64, at time.Time) Rule {
+	effective := Rule{BankID: bankID, CustomerID: customerID}
+
+	for _, layer := range []struct {
+		customerID int64
+		temporary  bool
+	}{
+		{0, false},
+		{0, true},
+		{customerID, false},
+		{customerID, true},
+	} {
+		rule := s.repo.Find(bankID, layer.customerID, layer.temporary)
+		if rule != nil && rule.active(at) {
+			effective = rule.over(effective)
+		}
+	}
+
+	return effective
+}
+
+// Check returns an ErrLimitExceeded error when taking amount out of one of
+// the customer's accounts would break one of their limits, given what they
+// have already moved. transfer tells whether the debit is a transfer, which
+// counts towards the hourly number of transfers.
+func (s *Service) Check(bankID, customerID, amount int64, transfer bool, usage Usage, at time.Time) error {
+	l := s.Effective(bankID, customerID, at)
+
+	if l.MaxTransaction > 0 && amount > l.MaxTransaction {
+		return fmt.Errorf("%w: %s is above the maximum of %s per transaction",
+			ErrLimitExceeded, money.Format(amount), money.Format(l.MaxTransaction))
+	}
+	if l.DailyOutflow > 0 && usage.Today+amount > l.DailyOutflow {
+		return fmt.Errorf("%w: the daily limit is %s and %s has already been sent today",
+			ErrLimitExceeded, money.Format(l.DailyOutflow), money.Format(usage.Today))
+	}
+	if l.MonthlyOutflow > 0 && usage.ThisMonth+amount > l.MonthlyOutflow {
+		return fmt.Errorf("%w: the monthly limit is %s and %s has already been sent this month",
+			ErrLimitExceeded, money.Format(l.MonthlyOutflow), money.Format(usage.ThisMonth))
+	}
+	if transfer && l.TransfersPerHour > 0 && usage.TransfersLastHour >= l.TransfersPerHour {
+		return fmt.Errorf("%w: at most %d transfer(s) per hour are allowed, try again later",
+			ErrLimitExceeded, l.TransfersPerHour)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package loan
+
+import (
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+func (h *Handler) HandleApply(customerID int64) {
+	accountStr, _ := h.con.Prompt("Account to pay the loan into: ")
+	amountStr, _ := h.con.Prompt("Amount: ")
+	monthsStr, _ := h.con.Prompt("Term in months: ")
+	methodStr, _ := h.con.Prompt("Repayment (annuity or flat) [annuity]: ")
+
+	accountID, err := strconv.ParseInt(accountStr, 10, 64)
+	if err != nil {
+		h.con.Printf("Invalid account ID: %s\n", accountStr)
+		return
+	}
+	amount, err := money.Parse(amountStr)
+	if err != nil {
+		h.con.Printf("Invalid amount: %v\n", err)
+		return
+	}
+	months, err := strconv.Atoi(monthsStr)
+	if err != nil {
+		h.con.Printf("Invalid term: %s\n", monthsStr)
+		return
+	}
+	if methodStr == "" {
+		methodStr = string(Annuity)
+	}
+	method, err := ParseMethod(methodStr)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+
+	loan, err := h.service.Apply(customerID, accountID, amount, months, method)
+	if err != nil {
+		h.con.Printf("Error applying for loan: %v\n", err)
+		return
+	}
+
+	h.con.Printf("Application %d sent, the bank will review it.\n", loan.ID)
+}
+
+// HandleBalance is the loan-balance inquiry of the customer menu.
+func (h *Handler) HandleBalance(customerID int64) {
+	loans := h.service.GetCustomerLoans(customerID)
+	if len(loans) == 0 {
+		h.con.Println("You have no loans.")
+		return
+	}
+
+	now := time.Now()
+	for _, loan := range loans {
+		h.con.Println(describe(loan, now))
+	}
+}
+
+// describe is the one-line state of a loan as the borrower sees it.
+func describe(loan *Loan, at time.Time) string {
+	switch loan.Status {
+	case StatusPending:
+		return fmt.Sprintf("Loan %d: %s over %d months, waiting for the bank's decision", loan.ID, money.Format(loan.Principal), loan.Months)
+	case StatusRejected:
+		return fmt.Sprintf("Loan %d: %s, rejected: %s", loan.ID, money.Format(loan.Principal), loan.Note)
+	case StatusRepaid:
+		return fmt.Sprintf("Loan %d: %s, repaid in full", loan.ID, money.Format(loan.Principal))
+	}
+
+	desc := fmt.Sprintf("Loan %d: %s outstanding of %s at %s%%", loan.ID,
+		money.Format(loan.Outstanding()), money.Format(loan.Principal), money.Format(loan.RateBps))
+	if next := loan.NextDue(); next != nil {
+		desc += fmt.Sprintf(", next installment %s due %s", money.Format(next.Amount()), next.DueDate.Format(time.DateOnly))
+	}
+	if count, amount := loan.Arrears(at); count > 0 {
+		desc += fmt.Sprintf(", %d installment(s) overdue totalling %s", count, money.Format(amount))
+	}
+	return desc
+}
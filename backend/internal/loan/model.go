@@ -0,0 +1,134 @@
+package loan
+
+import (
+	"fmt"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusRejected Status = "rejected"
+	StatusActive   Status = "active"
+	StatusRepaid   Status = "repaid"
+)
+
+func ParseStatus(s string) (Status, error) {
+	switch Status(s) {
+	case StatusPending, StatusRejected, StatusActive, StatusRepaid:
+		return Status(s), nil
+	}
+	return "", fmt.Errorf("unknown loan status %q (expected pending, rejected, active or repaid)", s)
+}
+
+// Method is how a loan is paid back.
+type Method string
+
+const (
+	// Annuity installments are all the same amount, interest is charged on
+	// the principal still outstanding so the principal part grows over time.
+	Annuity Method = "annuity"
+	// Flat installments repay equal principal with interest charged on the
+	// original principal for the whole term.
+	Flat Method = "flat"
+)
+
+func ParseMethod(s string) (Method, error) {
+	switch Method(s) {
+	case Annuity, Flat:
+		return Method(s), nil
+	}
+	return "", fmt.Errorf("unknown repayment method %q (expected %q or %q)", s, Annuity, Flat)
+}
+
+// Loan is a customer's loan application and, once approved, the loan itself.
+// The principal is paid out into AccountID and the installments are
+// collected from it. Amounts are in cents, RateBps is the annual rate in
+// basis points and is set by the bank when it approves the loan.
+type Loan struct {
+	ID         int64         `json:"id"`
+	BankID     int64         `json:"bankid"`
+	CustomerID int64         `json:"customerid"`
+	AccountID  int64         `json:"accountid"`
+	Principal  int64         `json:"principal"`
+	Months     int           `json:"months"`
+	Method     Method        `json:"method"`
+	RateBps    int64         `json:"rateBps"`
+	Status     Status        `json:"status"`
+	Note       string        `json:"note,omitempty"` // why it was rejected
+	AppliedAt  time.Time     `json:"appliedAt"`
+	DecidedAt  *time.Time    `json:"decidedAt,omitempty"`
+	Schedule   []Installment `json:"schedule,omitempty"`
+}
+
+// Installment is one monthly payment of the amortisation schedule. Penalty is
+// the late-payment penalty added when it was not paid on its due date.
+type Installment struct {
+	Number    int        `json:"number"`
+	DueDate   time.Time  `json:"dueDate"`
+	Principal int64      `json:"principal"`
+	Interest  int64      `json:"interest"`
+	Penalty   int64      `json:"penalty,omitempty"`
+	PaidAt    *time.Time `json:"paidAt,omitempty"`
+}
+
+// Amount is what has to be collected to settle the installment.
+func (i Installment) Amount() int64 {
+	return i.Principal + i.Interest + i.Penalty
+}
+
+func (i Installment) Paid() bool {
+	return i.PaidAt != nil
+}
+
+// Outstanding is the principal that has not been repaid yet.
+func (l *Loan) Outstanding() int64 {
+	if l.Status != StatusActive {
+		return 0
+	}
+
+	outstanding := int64(0)
+	for _, inst := range l.Schedule {
+		if !inst.Paid() {
+			outstanding += inst.Principal
+		}
+	}
+	return outstanding
+}
+
+// NextDue returns the first unpaid installment, or nil when there is none.
+func (l *Loan) NextDue() *Installment {
+	for i := range l.Schedule {
+		if !l.Schedule[i].Paid() {
+			return &l.Schedule[i]
+		}
+	}
+	return nil
+}
+
+// Arrears counts the unpaid installments that fell due before the given
+// moment and what it takes to settle them.
+func (l *Loan) Arrears(at time.Time) (count int, amount int64) {
+	for _, inst := range l.Schedule {
+		if !inst.Paid() && inst.DueDate.Before(at) {
+			count++
+			amount += inst.Amount()
+		}
+	}
+	return count, amount
+}
+
+func NewLoan(id, bankID, customerID, accountID, principal int64, months int, method Method) *Loan {
+	return &Loan{
+		ID:         id,
+		BankID:     bankID,
+		CustomerID: customerID,
+		AccountID:  accountID,
+		Principal:  principal,
+		Months:     months,
+		Method:     method,
+		Status:     StatusPending,
+		AppliedAt:  time.Now(),
+	}
+}
@@ -0,0 +1,106 @@
+package loan
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	loans    []*Loan // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		loans:    []*Loan{},
+	}
+
+	if err := storage.LoadCollection(filePath, "loans", &repo.loans); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, loan := range repo.loans {
+		if loan.ID >= repo.nextID {
+			repo.nextID = loan.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "loans", r.loans); err != nil {
+		return fmt.Errorf("failed to save loan data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(bankID, customerID, accountID, principal int64, months int, method Method) (*Loan, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	loan := NewLoan(r.nextID, bankID, customerID, accountID, principal, months, method)
+	r.loans = append(r.loans, loan)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return copyLoan(loan), nil
+}
+
+// Update replaces the stored loan with the same ID.
+func (r *Repository) Update(loan *Loan) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.loans {
+		if existing.ID == loan.ID {
+			r.loans[i] = copyLoan(loan)
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("loan with ID %d not found", loan.ID)
+}
+
+// GetByID returns a copy of the loan, changes are stored with Update.
+func (r *Repository) GetByID(id int64) (*Loan, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, loan := range r.loans {
+		if loan.ID == id {
+			return copyLoan(loan), nil
+		}
+	}
+
+	return nil, fmt.Errorf("loan with ID %d not found", id)
+}
+
+func (r *Repository) GetAll() []*Loan {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	loans := make([]*Loan, 0, len(r.loans))
+	for _, loan := range r.loans {
+		loans = append(loans, copyLoan(loan))
+	}
+
+	return loans
+}
+
+// copyLoan copies a loan including its schedule, so callers can change it
+// without touching the cache.
+func copyLoan(l *Loan) *Loan {
+	c := *l
+	c.Schedule = append([]Installment(nil), l.Schedule...)
+	return &c
+}
@@ -0,0 +1,74 @@
+package loan
+
+import (
+	"math"
+	"time"
+)
+
+// Amortise builds the monthly repayment schedule of a loan paid out at start.
+// The first installment is due one month later. Rounding differences are
+// settled by the last installment, so the principal parts always add up to
+// the principal.
+func Amortise(principal, rateBps int64, months int, method Method, start time.Time) []Installment {
+	schedule := make([]Installment, 0, months)
+
+	payment := annuityPayment(principal, rateBps, months)
+	flatInterest := monthlyInterest(principal*int64(months), rateBps)
+
+	balance := principal
+	for n := 1; n <= months; n++ {
+		inst := Installment{Number: n, DueDate: addMonths(start, n)}
+
+		switch method {
+		case Flat:
+			inst.Principal = principal / int64(months)
+			inst.Interest = flatInterest / int64(months)
+			if n == months {
+				inst.Interest = flatInterest - inst.Interest*int64(months-1)
+			}
+		default:
+			inst.Interest = monthlyInterest(balance, rateBps)
+			inst.Principal = payment - inst.Interest
+		}
+
+		if n == months || inst.Principal > balance {
+			inst.Principal = balance
+		}
+		balance -= inst.Principal
+
+		schedule = append(schedule, inst)
+	}
+
+	return schedule
+}
+
+// monthlyInterest is one month (a twelfth of the annual rate) of interest on
+// amount, rounded to the nearest cent.
+func monthlyInterest(amount, rateBps int64) int64 {
+	return (amount*rateBps + 60000) / 120000
+}
+
+// annuityPayment is the fixed monthly installment that pays off principal in
+// the given number of months.
+func annuityPayment(principal, rateBps int64, months int) int64 {
+	if rateBps == 0 {
+		return (principal + int64(months) - 1) / int64(months)
+	}
+
+	r := float64(rateBps) / 120000
+	payment := float64(principal) * r / (1 - math.Pow(1+r, -float64(months)))
+	return int64(math.Round(payment))
+}
+
+// addMonths moves t by n months, keeping the day of the month where it
+// exists and using the last day of shorter months (Jan 31 becomes Feb 28).
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
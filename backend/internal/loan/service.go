@@ -0,0 +1,242 @@
+package loan
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/fee"
+	"banking-app/backend/internal/transactions"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxMonths is the longest term a loan can be taken out for.
+const MaxMonths = 360
+
+type Service struct {
+	repo         *Repository
+	accounts     *account.Repository
+	customers    *customer.Service
+	transactions *transactions.Service
+	fees         *fee.Service
+}
+
+func NewService(repo *Repository, accounts *account.Repository, customers *customer.Service, txs *transactions.Service, fees *fee.Service) *Service {
+	return &Service{
+		repo:         repo,
+		accounts:     accounts,
+		customers:    customers,
+		transactions: txs,
+		fees:         fees,
+	}
+}
+
+// Apply records a customer's loan application. The loan is paid out into,
+// and repaid from, one of the customer's own accounts, which also decides
+// the bank that lends the money. Only verified customers can apply.
+func (s *Service) Apply(customerID, accountID, principal int64, months int, method Method) (*Loan, error) {
+	if principal <= 0 {
+		return nil, fmt.Errorf("loan amount must be positive")
+	}
+	if months < 1 || months > MaxMonths {
+		return nil, fmt.Errorf("term must be between 1 and %d months", MaxMonths)
+	}
+	if err := s.customers.CheckVerified(customerID); err != nil {
+		return nil, err
+	}
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.CustomerID != customerID {
+		return nil, fmt.Errorf("account %d does not belong to customer %d", accountID, customerID)
+	}
+
+	loan, err := s.repo.Create(acc.BankID, customerID, accountID, principal, months, method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply for loan: %w", err)
+	}
+
+	return loan, nil
+}
+
+// Approve sets the rate of a pending loan, pays the principal out to the
+// customer and generates its repayment schedule. The customer must still be
+// verified.
+func (s *Service) Approve(id, rateBps int64) (*Loan, error) {
+	if rateBps < 0 || rateBps > 10000 {
+		return nil, fmt.Errorf("rate must be between 0%% and 100%%")
+	}
+
+	loan, err := s.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.customers.CheckVerified(loan.CustomerID); err != nil {
+		return nil, err
+	}
+
+	// the loan is saved as approved before the money goes out, so a failed
+	// save cannot leave it pending to be paid out again
+	now := time.Now()
+	approved := *loan
+	approved.RateBps = rateBps
+	approved.Status = StatusActive
+	approved.DecidedAt = &now
+	approved.Schedule = Amortise(loan.Principal, rateBps, loan.Months, loan.Method, now)
+	if err := s.repo.Update(&approved); err != nil {
+		return nil, fmt.Errorf("failed to approve loan: %w", err)
+	}
+
+	tx, err := s.transactions.Disburse(loan.AccountID, loan.Principal, fmt.Sprintf("loan %d", loan.ID))
+	if tx == nil {
+		// nothing was paid out, the loan waits for a decision again
+		if rerr := s.repo.Update(loan); rerr != nil {
+			return nil, fmt.Errorf("%w; failed to set loan %d back to pending: %v", err, loan.ID, rerr)
+		}
+		return nil, err
+	}
+
+	return &approved, err
+}
+
+func (s *Service) Reject(id int64, reason string) (*Loan, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required to reject a loan")
+	}
+
+	loan, err := s.pending(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	loan.Status = StatusRejected
+	loan.Note = reason
+	loan.DecidedAt = &now
+
+	if err := s.repo.Update(loan); err != nil {
+		return nil, fmt.Errorf("failed to reject loan: %w", err)
+	}
+
+	return loan, nil
+}
+
+func (s *Service) pending(id int64) (*Loan, error) {
+	loan, err := s.GetLoan(id)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != StatusPending {
+		return nil, fmt.Errorf("loan %d is already %s", id, loan.Status)
+	}
+	return loan, nil
+}
+
+func (s *Service) GetLoan(id int64) (*Loan, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid loan ID: %d", id)
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *Service) GetCustomerLoans(customerID int64) []*Loan {
+	loans := []*Loan{}
+	for _, loan := range s.repo.GetAll() {
+		if loan.CustomerID == customerID {
+			loans = append(loans, loan)
+		}
+	}
+	return loans
+}
+
+func (s *Service) GetBankLoans(bankID int64) []*Loan {
+	loans := []*Loan{}
+	for _, loan := range s.repo.GetAll() {
+		if loan.BankID == bankID {
+			loans = append(loans, loan)
+		}
+	}
+	return loans
+}
+
+func (s *Service) GetAllLoans() []*Loan {
+	return s.repo.GetAll()
+}
+
+// CollectionResult is what happened to one installment during a collection
+// run.
+type CollectionResult struct {
+	LoanID      int64  `json:"loanId"`
+	Installment int    `json:"installment"`
+	Amount      int64  `json:"amount"`
+	Collected   bool   `json:"collected"`
+	Penalty     int64  `json:"penalty,omitempty"` // added by this run
+	Reason      string `json:"reason,omitempty"`  // why it was not collected
+}
+
+// Collect takes every installment that is due by the given moment from the
+// borrower's account, oldest first. An installment the account cannot cover
+// is late: it gets the bank's late-payment penalty once, and the installments
+// after it wait until it is paid. Installments already paid are skipped, so
+// it is safe to run again. With a bankID only that bank's loans are
+// collected, 0 collects them all.
+func (s *Service) Collect(bankID int64, at time.Time) ([]CollectionResult, error) {
+	var results []CollectionResult
+
+	for _, loan := range s.repo.GetAll() {
+		if loan.Status != StatusActive || (bankID != 0 && loan.BankID != bankID) {
+			continue
+		}
+
+		for i := range loan.Schedule {
+			inst := &loan.Schedule[i]
+			if inst.Paid() {
+				continue
+			}
+			if inst.DueDate.After(at) {
+				break
+			}
+
+			result := CollectionResult{LoanID: loan.ID, Installment: inst.Number}
+
+			memo := fmt.Sprintf("loan %d installment %d", loan.ID, inst.Number)
+			err := s.transactions.Repay(loan.AccountID, inst.Principal, inst.Interest, inst.Penalty, memo)
+			if errors.Is(err, transactions.ErrInsufficientFunds) {
+				if inst.Penalty == 0 {
+					inst.Penalty = s.fees.Quote(loan.BankID, fee.TypeLatePayment, inst.Principal+inst.Interest)
+					result.Penalty = inst.Penalty
+				}
+				result.Amount = inst.Amount()
+				result.Reason = "insufficient funds"
+				results = append(results, result)
+				break
+			}
+			if err != nil {
+				// keep what this run already collected from the loan
+				if uerr := s.repo.Update(loan); uerr != nil {
+					return results, fmt.Errorf("loan %d: %w; failed to save loan: %v", loan.ID, err, uerr)
+				}
+				return results, fmt.Errorf("loan %d: %w", loan.ID, err)
+			}
+
+			now := time.Now()
+			inst.PaidAt = &now
+			result.Amount = inst.Amount()
+			result.Collected = true
+			results = append(results, result)
+		}
+
+		if loan.NextDue() == nil {
+			loan.Status = StatusRepaid
+		}
+
+		if err := s.repo.Update(loan); err != nil {
+			return results, fmt.Errorf("failed to save loan %d: %w", loan.ID, err)
+		}
+	}
+
+	return results, nil
+}
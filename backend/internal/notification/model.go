@@ -0,0 +1,24 @@
+package notification
+
+import "time"
+
+// Notification is a message for a customer, shown in the customer menu until
+// they have read it. AccountID is the account it is about, if any.
+type Notification struct {
+	ID         int64     `json:"id"`
+	CustomerID int64     `json:"customerid"`
+	AccountID  int64     `json:"accountid,omitempty"`
+	Message    string    `json:"message"`
+	Read       bool      `json:"read"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewNotification(id, customerID, accountID int64, message string) *Notification {
+	return &Notification{
+		ID:         id,
+		CustomerID: customerID,
+		AccountID:  accountID,
+		Message:    message,
+		CreatedAt:  time.Now(),
+	}
+}
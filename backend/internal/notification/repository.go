@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath      string
+	mutex         sync.RWMutex
+	nextID        int64
+	notifications []*Notification // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath:      filePath,
+		nextID:        1,
+		notifications: []*Notification{},
+	}
+
+	if err := storage.LoadCollection(filePath, "notifications", &repo.notifications); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, n := range repo.notifications {
+		if n.ID >= repo.nextID {
+			repo.nextID = n.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "notifications", r.notifications); err != nil {
+		return fmt.Errorf("failed to save notification data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(customerID, accountID int64, message string) (*Notification, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	n := NewNotification(r.nextID, customerID, accountID, message)
+	r.notifications = append(r.notifications, n)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (r *Repository) GetByCustomerID(customerID int64) []*Notification {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	notifications := []*Notification{}
+	for _, n := range r.notifications {
+		if n.CustomerID == customerID {
+			copied := *n
+			notifications = append(notifications, &copied)
+		}
+	}
+
+	return notifications
+}
+
+// MarkRead marks every notification of the customer as read.
+func (r *Repository) MarkRead(customerID int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, n := range r.notifications {
+		if n.CustomerID == customerID {
+			n.Read = true
+		}
+	}
+
+	return r.saveData()
+}
@@ -0,0 +1,36 @@
+package notification
+
+import "fmt"
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) Notify(customerID, accountID int64, message string) error {
+	if _, err := s.repo.Create(customerID, accountID, message); err != nil {
+		return fmt.Errorf("failed to notify customer %d: %w", customerID, err)
+	}
+	return nil
+}
+
+// GetCustomerNotifications returns the customer's notifications, oldest
+// first, or only the ones not read yet.
+func (s *Service) GetCustomerNotifications(customerID int64, unreadOnly bool) []*Notification {
+	notifications := []*Notification{}
+	for _, n := range s.repo.GetByCustomerID(customerID) {
+		if !unreadOnly || !n.Read {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications
+}
+
+func (s *Service) MarkRead(customerID int64) error {
+	return s.repo.MarkRead(customerID)
+}
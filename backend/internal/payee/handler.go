@@ -0,0 +1,123 @@
+package payee
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/money"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service  *Service
+	accounts *account.Service
+	con      *console.Console
+}
+
+func NewHandler(service *Service, accounts *account.Service, con *console.Console) *Handler {
+	return &Handler{
+		service:  service,
+		accounts: accounts,
+		con:      con,
+	}
+}
+
+func (h *Handler) HandleList(customerID int64) {
+	payees := h.service.GetCustomerPayees(customerID)
+	if len(payees) == 0 {
+		h.con.Println("You have no saved payees.")
+		return
+	}
+
+	h.con.Println("ID\tPayee\tAccount number")
+	for _, p := range payees {
+		h.con.Printf("%d\t%s\t%s\n", p.ID, p.Label(), accountno.Group(p.AccountNumber()))
+	}
+}
+
+func (h *Handler) HandleAdd(customerID int64) {
+	name, _ := h.con.Prompt("Payee name: ")
+	nickname, _ := h.con.Prompt("Nickname (optional): ")
+	number, _ := h.con.Prompt("Account number: ")
+
+	payee, err := h.service.AddPayee(customerID, name, nickname, number)
+	if err != nil {
+		h.con.Printf("Error adding payee: %v\n", err)
+		return
+	}
+
+	h.con.Printf("Payee %s saved.\n", payee.Label())
+}
+
+func (h *Handler) HandleRemove(customerID int64) {
+	h.HandleList(customerID)
+	idStr, _ := h.con.Prompt("Payee ID to remove: ")
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.con.Printf("Invalid ID format: %s\n", idStr)
+		return
+	}
+
+	if err := h.service.RemovePayee(customerID, id); err != nil {
+		h.con.Printf("Error removing payee: %v\n", err)
+		return
+	}
+
+	h.con.Println("Payee removed.")
+}
+
+// HandleTransfer sends money to a payee picked from the customer's list,
+// showing the fee before asking to confirm.
+func (h *Handler) HandleTransfer(customerID int64) {
+	if len(h.service.GetCustomerPayees(customerID)) == 0 {
+		h.con.Println("Save a payee first.")
+		return
+	}
+
+	h.HandleList(customerID)
+	payeeStr, _ := h.con.Prompt("Pay to payee ID: ")
+	fromNumber, _ := h.con.Prompt("From account number: ")
+	amountStr, _ := h.con.Prompt("Amount: ")
+	memo, _ := h.con.Prompt("Memo (optional): ")
+
+	payeeID, err := strconv.ParseInt(payeeStr, 10, 64)
+	if err != nil {
+		h.con.Printf("Invalid payee ID: %s\n", payeeStr)
+		return
+	}
+	from, err := h.accounts.GetAccountByNumber(fromNumber)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	amount, err := money.Parse(amountStr)
+	if err != nil {
+		h.con.Printf("Invalid amount: %v\n", err)
+		return
+	}
+
+	charge, err := h.service.QuoteTransfer(customerID, from.ID, amount)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	h.con.Printf("Transfer %s, fee %s.\n", money.Format(amount), money.Format(charge))
+	if answer, _ := h.con.Prompt("Proceed? [y/N]: "); strings.ToLower(answer) != "y" && strings.ToLower(answer) != "yes" {
+		h.con.Println("Cancelled.")
+		return
+	}
+
+	tx, err := h.service.Transfer(customerID, from.ID, payeeID, amount, memo)
+	if err != nil {
+		h.con.Printf("Error transferring: %v\n", err)
+		return
+	}
+
+	h.con.Printf("Sent %s to %s (transaction %d).\n", money.Format(tx.Amount), tx.Payee, tx.Id)
+	if tx.Status == transactions.StatusHeld {
+		h.con.Println("The payment is being reviewed by the bank and will arrive once it is approved.")
+	}
+}
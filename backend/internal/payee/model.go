@@ -0,0 +1,54 @@
+package payee
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"time"
+)
+
+// Payee is an account a customer has saved to send money to. AccountID is
+// the payee's account, at bank BankID.
+type Payee struct {
+	ID         int64     `json:"id"`
+	CustomerID int64     `json:"customerid"`
+	Name       string    `json:"name"`
+	Nickname   string    `json:"nickname,omitempty"`
+	BankID     int64     `json:"bankid"`
+	AccountID  int64     `json:"accountid"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// AccountNumber is the account number of the payee's account.
+func (p *Payee) AccountNumber() string {
+	// a payee is saved from an existing account, whose IDs always fit
+	number, _ := accountno.New(p.BankID, p.AccountID)
+	return number
+}
+
+// Label is how the payee is shown, the nickname if it has one.
+func (p *Payee) Label() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Name
+}
+
+// Policy protects customers from transfers to payees that were just added,
+// which is what someone who has taken over an account would do. Transfers
+// above LargeTransfer (cents) to a payee are refused until CoolingOff has
+// passed since it was saved. Zero turns the check off.
+type Policy struct {
+	CoolingOff    time.Duration `json:"coolingOff"`
+	LargeTransfer int64         `json:"largeTransfer"`
+}
+
+func NewPayee(id, customerID int64, name, nickname string, bankID, accountID int64) *Payee {
+	return &Payee{
+		ID:         id,
+		CustomerID: customerID,
+		Name:       name,
+		Nickname:   nickname,
+		BankID:     bankID,
+		AccountID:  accountID,
+		CreatedAt:  time.Now(),
+	}
+}
@@ -0,0 +1,98 @@
+package payee
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	payees   []*Payee // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		payees:   []*Payee{},
+	}
+
+	if err := storage.LoadCollection(filePath, "payees", &repo.payees); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, payee := range repo.payees {
+		if payee.ID >= repo.nextID {
+			repo.nextID = payee.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "payees", r.payees); err != nil {
+		return fmt.Errorf("failed to save payee data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(customerID int64, name, nickname string, bankID, accountID int64) (*Payee, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	payee := NewPayee(r.nextID, customerID, name, nickname, bankID, accountID)
+	r.payees = append(r.payees, payee)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+func (r *Repository) Delete(id int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, payee := range r.payees {
+		if payee.ID == id {
+			r.payees = append(r.payees[:i], r.payees[i+1:]...)
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("payee with ID %d not found", id)
+}
+
+func (r *Repository) GetByID(id int64) (*Payee, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, payee := range r.payees {
+		if payee.ID == id {
+			return payee, nil
+		}
+	}
+
+	return nil, fmt.Errorf("payee with ID %d not found", id)
+}
+
+func (r *Repository) GetByCustomerID(customerID int64) []*Payee {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	payees := []*Payee{}
+	for _, payee := range r.payees {
+		if payee.CustomerID == customerID {
+			payees = append(payees, payee)
+		}
+	}
+
+	return payees
+}
@@ -0,0 +1,132 @@
+package payee
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Service struct {
+	repo         *Repository
+	accounts     *account.Repository
+	transactions *transactions.Service
+	screening    *sanctions.Service
+	policy       Policy
+}
+
+func NewService(repo *Repository, accounts *account.Repository, txs *transactions.Service, screening *sanctions.Service, policy Policy) *Service {
+	return &Service{
+		repo:         repo,
+		accounts:     accounts,
+		transactions: txs,
+		screening:    screening,
+		policy:       policy,
+	}
+}
+
+// AddPayee saves a beneficiary for a customer after checking the account
+// number for typos and that the account exists. The bank is the one the
+// account number belongs to.
+func (s *Service) AddPayee(customerID int64, name, nickname, number string) (*Payee, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("invalid customer ID: %d", customerID)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("payee name cannot be empty")
+	}
+
+	bankID, accountID, err := accountno.Parse(number)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil || acc.Internal() || acc.BankID != bankID {
+		return nil, fmt.Errorf("account %s does not exist", accountno.Group(number))
+	}
+
+	for _, existing := range s.repo.GetByCustomerID(customerID) {
+		if existing.AccountID == accountID {
+			return nil, fmt.Errorf("account %s is already saved as payee %q", accountno.Group(number), existing.Label())
+		}
+	}
+
+	payee, err := s.repo.Create(customerID, name, nickname, bankID, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add payee: %w", err)
+	}
+
+	return payee, nil
+}
+
+func (s *Service) RemovePayee(customerID, id int64) error {
+	payee, err := s.GetPayee(customerID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(payee.ID)
+}
+
+// GetPayee returns one of the customer's payees.
+func (s *Service) GetPayee(customerID, id int64) (*Payee, error) {
+	payee, err := s.repo.GetByID(id)
+	if err != nil || payee.CustomerID != customerID {
+		return nil, fmt.Errorf("payee with ID %d not found", id)
+	}
+	return payee, nil
+}
+
+func (s *Service) GetCustomerPayees(customerID int64) []*Payee {
+	return s.repo.GetByCustomerID(customerID)
+}
+
+// QuoteTransfer returns the fees of a transfer from one of the customer's
+// accounts, so they can be shown before the customer confirms.
+func (s *Service) QuoteTransfer(customerID, fromID, amount int64) (int64, error) {
+	if _, err := s.customerAccount(customerID, fromID); err != nil {
+		return 0, err
+	}
+	return s.transactions.QuoteTransfer(fromID, amount)
+}
+
+// Transfer sends money from one of the customer's accounts to one of their
+// saved payees, once the payee's cooling-off period allows the amount.
+func (s *Service) Transfer(customerID, fromID, payeeID, amount int64, memo string) (*transactions.Transaction, error) {
+	payee, err := s.GetPayee(customerID, payeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.customerAccount(customerID, fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowed := payee.CreatedAt.Add(s.policy.CoolingOff); s.policy.LargeTransfer > 0 &&
+		amount > s.policy.LargeTransfer && time.Now().Before(allowed) {
+		return nil, fmt.Errorf("payee %q was added recently, transfers above %s to it are allowed from %s",
+			payee.Label(), money.Format(s.policy.LargeTransfer), allowed.Format("2006-01-02 15:04"))
+	}
+
+	subject := sanctions.Subject{Kind: sanctions.KindPayee, BankID: from.BankID, CustomerID: customerID, PayeeID: payee.ID, Name: payee.Name}
+	if err := s.screening.Screen(subject); err != nil {
+		return nil, err
+	}
+
+	return s.transactions.TransferToPayee(fromID, payee.AccountID, amount, payee.Name, memo)
+}
+
+func (s *Service) customerAccount(customerID, accountID int64) (*account.Account, error) {
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.CustomerID != customerID {
+		return nil, fmt.Errorf("account %d does not belong to customer %d", accountID, customerID)
+	}
+	return acc, nil
+}
@@ -0,0 +1,50 @@
+package reconcile
+
+import (
+	"banking-app/backend/internal/account"
+	"time"
+)
+
+// AccountDifference is an account whose stored balance is not what its
+// transactions add up to. Difference is Stored minus Ledger.
+type AccountDifference struct {
+	AccountID  int64        `json:"accountId"`
+	Number     string       `json:"number"`
+	Type       account.Type `json:"type"`
+	Stored     int64        `json:"stored"`
+	Ledger     int64        `json:"ledger"`
+	Difference int64        `json:"difference"`
+}
+
+// LedgerDifference is a general ledger account whose balance is not what the
+// bank accounts it stands for add up to by their transactions. Difference is
+// Actual minus Expected, on the account's normal side.
+type LedgerDifference struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Expected   int64  `json:"expected"`
+	Actual     int64  `json:"actual"`
+	Difference int64  `json:"difference"`
+}
+
+// Run is one reconciliation of a bank, kept as its audit trail. A repairing
+// run books each balance difference as the correction transaction in
+// Transactions and the general ledger differences as the journal entries in
+// Entries.
+type Run struct {
+	ID                 int64               `json:"id"`
+	BankID             int64               `json:"bankid"`
+	RunBy              int64               `json:"runBy"`
+	RunAt              time.Time           `json:"runAt"`
+	Accounts           int                 `json:"accounts"`
+	AccountDifferences []AccountDifference `json:"accountDifferences"`
+	LedgerDifferences  []LedgerDifference  `json:"ledgerDifferences"`
+	Repaired           bool                `json:"repaired,omitempty"`
+	Transactions       []int64             `json:"transactions,omitempty"`
+	Entries            []int64             `json:"entries,omitempty"`
+}
+
+// Clean reports whether the run found nothing to repair.
+func (r *Run) Clean() bool {
+	return len(r.AccountDifferences) == 0 && len(r.LedgerDifferences) == 0
+}
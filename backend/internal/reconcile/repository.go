@@ -0,0 +1,88 @@
+package reconcile
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	runs     []*Run // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		runs:     []*Run{},
+	}
+
+	if err := storage.LoadCollection(filePath, "reconciliations", &repo.runs); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, r := range repo.runs {
+		if r.ID >= repo.nextID {
+			repo.nextID = r.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "reconciliations", r.runs); err != nil {
+		return fmt.Errorf("failed to save reconciliation data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(run Run) (*Run, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	run.ID = r.nextID
+	r.runs = append(r.runs, &run)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	copied := run
+	return &copied, nil
+}
+
+func (r *Repository) Update(run *Run) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.runs {
+		if existing.ID == run.ID {
+			copied := *run
+			r.runs[i] = &copied
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("reconciliation with ID %d not found", run.ID)
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Run {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	runs := []*Run{}
+	for _, run := range r.runs {
+		if run.BankID == bankID {
+			copied := *run
+			runs = append(runs, &copied)
+		}
+	}
+
+	return runs
+}
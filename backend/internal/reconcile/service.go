@@ -0,0 +1,163 @@
+package reconcile
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/gl"
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"time"
+)
+
+type Service struct {
+	repo     *Repository
+	accounts *account.Repository
+	txs      *transactions.Service
+	ledger   *gl.Service
+	days     transactions.BusinessDays
+}
+
+func NewService(repo *Repository, accounts *account.Repository, txs *transactions.Service, ledger *gl.Service, days transactions.BusinessDays) *Service {
+	return &Service{
+		repo:     repo,
+		accounts: accounts,
+		txs:      txs,
+		ledger:   ledger,
+		days:     days,
+	}
+}
+
+// Reconcile rebuilds the balance of every account of a bank from its
+// transactions and compares it with the stored balance, then compares the
+// bank's general ledger with the accounts it stands for. With repair each
+// balance difference is posted as a correction, an adjustment transaction
+// with the bank's suspense account that names the run, and each general
+// ledger difference is booked against cash on the bank's business date.
+// Every run is saved, made by the given user, and a repair that fails part
+// way keeps what it booked so far in the saved run.
+func (s *Service) Reconcile(bankID, runBy int64, repair bool) (*Run, error) {
+	ledger := map[int64]int64{}
+	for _, tx := range s.txs.GetAllTransactions() {
+		ledger[tx.FromAccountID] -= tx.Amount
+		ledger[tx.ToAccountID] += tx.Amount
+	}
+
+	run := Run{
+		BankID:             bankID,
+		RunBy:              runBy,
+		RunAt:              time.Now(),
+		AccountDifferences: []AccountDifference{},
+		LedgerDifferences:  []LedgerDifference{},
+	}
+
+	// what each general ledger account should hold by the transactions
+	expected := map[string]int64{}
+	for _, acc := range s.accounts.GetAll() {
+		if acc.BankID != bankID {
+			continue
+		}
+		run.Accounts++
+
+		balance := ledger[acc.ID]
+		if acc.Balance != balance {
+			run.AccountDifferences = append(run.AccountDifferences, AccountDifference{
+				AccountID:  acc.ID,
+				Number:     acc.Number,
+				Type:       acc.Type,
+				Stored:     acc.Balance,
+				Ledger:     balance,
+				Difference: acc.Balance - balance,
+			})
+		}
+
+		switch acc.Type {
+		case account.TypeLoan:
+			// the loan account is minus what is lent out
+			expected[gl.CodeLoans] -= balance
+		case account.TypeSuspense:
+			expected[gl.CodeSuspense] += balance
+		case account.TypeIncome:
+			expected[gl.CodeRetainedEarnings] += balance
+		default:
+			expected[gl.CodeDeposits] += balance
+		}
+	}
+
+	if chart := s.ledger.GetChart(bankID); len(chart) > 0 {
+		actual := map[string]int64{}
+		names := map[string]string{}
+		for _, b := range s.ledger.TrialBalance(bankID).Accounts {
+			code := b.Code
+			// the opening entry keeps the income collected before the
+			// general ledger as retained earnings, so the income account
+			// stands for both
+			if b.Category == gl.CategoryIncome {
+				code = gl.CodeRetainedEarnings
+			}
+			actual[code] += b.Net()
+			if b.Code == code {
+				names[code] = b.Name
+			}
+		}
+		names[gl.CodeRetainedEarnings] = "Retained earnings and income"
+
+		for _, code := range []string{gl.CodeLoans, gl.CodeDeposits, gl.CodeSuspense, gl.CodeRetainedEarnings} {
+			if actual[code] != expected[code] {
+				run.LedgerDifferences = append(run.LedgerDifferences, LedgerDifference{
+					Code:       code,
+					Name:       names[code],
+					Expected:   expected[code],
+					Actual:     actual[code],
+					Difference: actual[code] - expected[code],
+				})
+			}
+		}
+	}
+
+	// the run is saved before repairing so the corrections can name it
+	saved, err := s.repo.Create(run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save reconciliation: %w", err)
+	}
+	if !repair || saved.Clean() {
+		return saved, nil
+	}
+
+	repairErr := s.repair(saved)
+	if err := s.repo.Update(saved); err != nil {
+		return nil, fmt.Errorf("failed to save reconciliation %d: %w", saved.ID, err)
+	}
+	if repairErr != nil {
+		return nil, fmt.Errorf("reconciliation %d saved with the repairs made before it failed: %w", saved.ID, repairErr)
+	}
+	return saved, nil
+}
+
+func (s *Service) repair(run *Run) error {
+	id := run.ID
+	day := s.days.BusinessDate(run.BankID)
+
+	for _, d := range run.AccountDifferences {
+		memo := fmt.Sprintf("reconciliation %d: balance correction", id)
+		tx, err := s.txs.Correct(d.AccountID, d.Difference, memo)
+		if err != nil {
+			return err
+		}
+		run.Transactions = append(run.Transactions, tx.Id)
+	}
+
+	for _, d := range run.LedgerDifferences {
+		memo := fmt.Sprintf("reconciliation %d: %s adjustment", id, d.Name)
+		entry, err := s.ledger.Adjust(run.BankID, d.Code, -d.Difference, day, memo)
+		if err != nil {
+			return fmt.Errorf("failed to book adjustment of %s: %w", d.Code, err)
+		}
+		run.Entries = append(run.Entries, entry.ID)
+	}
+
+	run.Repaired = true
+	return nil
+}
+
+func (s *Service) GetBankRuns(bankID int64) []*Run {
+	return s.repo.GetByBankID(bankID)
+}
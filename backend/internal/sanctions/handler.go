@@ -0,0 +1,64 @@
+package sanctions
+
+import (
+	"banking-app/backend/pkg/console"
+	"banking-app/backend/pkg/money"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+// HandleQueue lets a bank operator go through the bank's open watchlist
+// hits.
+func (h *Handler) HandleQueue(bankID, reviewerID int64) {
+	cases := h.service.GetBankCases(bankID, StatusOpen)
+	if len(cases) == 0 {
+		h.con.Println("No open watchlist hits.")
+		return
+	}
+
+	h.con.Printf("%d open watchlist hit(s):\n", len(cases))
+	h.con.Println("ID\tKind\tName\tWatchlist entry\tScore")
+	for _, c := range cases {
+		h.con.Printf("%d\t%s\t%s\t%s\t%s%%\n", c.ID, c.Subject.Kind, c.Subject.Name, c.EntryName, money.Format(c.ScoreBps))
+	}
+
+	idStr, _ := h.con.Prompt("Case ID to review (empty to go back): ")
+	if idStr == "" {
+		return
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.con.Printf("Invalid ID format: %s\n", idStr)
+		return
+	}
+
+	decision, _ := h.con.Prompt("Clear as a different person or confirm as a true match? (clear/confirm, empty to skip): ")
+	switch strings.ToLower(decision) {
+	case "clear":
+		note, _ := h.con.Prompt("Note (optional): ")
+		if _, err := h.service.Clear(bankID, id, reviewerID, note); err != nil {
+			h.con.Printf("Error: %v\n", err)
+			return
+		}
+		h.con.Printf("✅ Case %d cleared.\n", id)
+	case "confirm":
+		note, _ := h.con.Prompt("Why is it a true match? ")
+		if _, err := h.service.Confirm(bankID, id, reviewerID, note); err != nil {
+			h.con.Printf("Error: %v\n", err)
+			return
+		}
+		h.con.Printf("Case %d confirmed.\n", id)
+	}
+}
@@ -0,0 +1,99 @@
+package sanctions
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// similarity scores how alike two names are in basis points, 10000 being
+// the same name. Names are compared as written and with their words sorted,
+// so "Petrov Ivan" scores the same as "Ivan Petrov".
+func similarity(a, b string) int64 {
+	a, b = normalize(a), normalize(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	return max(jaroWinkler(a, b), jaroWinkler(sortWords(a), sortWords(b)))
+}
+
+// normalize lowercases a name and turns punctuation into single spaces.
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func sortWords(name string) string {
+	words := strings.Fields(name)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// jaroWinkler is the Jaro-Winkler similarity of two strings in basis points.
+// It favours strings that share a prefix, which suits names with typos or
+// different transliterations of the same name. As in the standard algorithm
+// the prefix only counts once the strings are alike, a Jaro score above 0.7,
+// so short names that merely start the same are not pushed up.
+func jaroWinkler(s1, s2 string) int64 {
+	a, b := []rune(s1), []rune(s2)
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := max(len(a), len(b))/2 - 1
+	window = max(window, 0)
+
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := max(0, i-window), min(len(b), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// transpositions are matched characters that are out of order
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	score := jaro
+	if jaro > 0.7 {
+		score += float64(prefix) * 0.1 * (1 - jaro)
+	}
+
+	return int64(score*10000 + 0.5)
+}
@@ -0,0 +1,88 @@
+package sanctions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		min, max int64
+	}{
+		{"same name", "Ivan Petrov", "Ivan Petrov", 10000, 10000},
+		{"case and punctuation", "ivan  petrov!", "IVAN PETROV", 10000, 10000},
+		{"reordered words", "Petrov Ivan", "Ivan Petrov", 10000, 10000},
+		{"dropped letter", "Ivan Petrv", "Ivan Petrov", 9500, 9999},
+		{"transliteration", "Ivan Petroff", "Ivan Petrov", 9000, 9999},
+		{"two typos", "Iwan Petrow", "Ivan Petrov", 8500, 9499},
+		{"different person", "Jane Smith", "Ivan Petrov", 0, 6999},
+		// Jaro is below 0.7, so the shared prefix adds nothing: 6889
+		// instead of 7511
+		{"short names sharing a prefix", "Ana", "Anton", 6889, 6889},
+		{"empty name", "", "Ivan Petrov", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := similarity(tt.a, tt.b)
+			if got < tt.min || got > tt.max {
+				t.Errorf("similarity(%q, %q) = %d, want %d to %d", tt.a, tt.b, got, tt.min, tt.max)
+			}
+			if back := similarity(tt.b, tt.a); back != got {
+				t.Errorf("similarity(%q, %q) = %d, but %d the other way round", tt.a, tt.b, got, back)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.csv")
+	list := "id,name,aliases,list\n" +
+		"EX-1,Ivan Petrov,Ivan Petroff;Jean Petrov,EXAMPLE\n" +
+		"EX-2,Maria Gonzalez,,EXAMPLE\n"
+	if err := os.WriteFile(path, []byte(list), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	edge := similarity("Iwan Petrow", "Ivan Petrov")
+
+	tests := []struct {
+		name      string
+		query     string
+		threshold int64
+		wantID    string // "" for no match
+		wantName  string
+	}{
+		{"reordered words", "Petrov Ivan", 9000, "EX-1", "Ivan Petrov"},
+		{"typo", "Maria Gonzales", 9000, "EX-2", "Maria Gonzalez"},
+		{"alias", "Jean Petrov", 9000, "EX-1", "Jean Petrov"},
+		{"nobody", "Jane Smith", 9000, "", ""},
+		{"at the threshold", "Iwan Petrow", edge, "EX-1", "Ivan Petrov"},
+		{"just under the threshold", "Iwan Petrow", edge + 1, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(nil, Settings{Watchlist: path, ThresholdBps: tt.threshold})
+			matches, err := s.Matches(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantID == "" {
+				if len(matches) != 0 {
+					t.Errorf("Matches(%q) = %+v, want none", tt.query, matches)
+				}
+				return
+			}
+			if len(matches) == 0 {
+				t.Fatalf("Matches(%q) found nothing, want %s", tt.query, tt.wantID)
+			}
+			if best := matches[0]; best.Entry.ID != tt.wantID || best.Name != tt.wantName {
+				t.Errorf("Matches(%q) best = %s %q, want %s %q", tt.query, best.Entry.ID, best.Name, tt.wantID, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package sanctions
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entry is a person or organisation on the watchlist. List is the sanctions
+// list or programme it comes from.
+type Entry struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	List    string   `json:"list,omitempty"`
+}
+
+// Kind is what was being screened.
+type Kind string
+
+const (
+	// KindCustomer is a customer joining a bank or sending their details.
+	KindCustomer Kind = "customer"
+	// KindPayee is a payee being paid.
+	KindPayee Kind = "payee"
+)
+
+// Subject is a name screened against the watchlist and who it belongs to.
+// CustomerID is 0 for someone who is not a customer yet, PayeeID is only set
+// for payees.
+type Subject struct {
+	Kind       Kind   `json:"kind"`
+	BankID     int64  `json:"bankid"`
+	UserID     int64  `json:"userid,omitempty"`
+	CustomerID int64  `json:"customerid,omitempty"`
+	PayeeID    int64  `json:"payeeid,omitempty"`
+	Name       string `json:"name"`
+}
+
+type Status string
+
+const (
+	StatusOpen Status = "open"
+	// StatusCleared cases were a different person, the name is no longer
+	// blocked for this subject.
+	StatusCleared Status = "cleared"
+	// StatusConfirmed cases were a true match, the name stays blocked.
+	StatusConfirmed Status = "confirmed"
+)
+
+func ParseStatus(s string) (Status, error) {
+	switch Status(s) {
+	case StatusOpen, StatusCleared, StatusConfirmed:
+		return Status(s), nil
+	}
+	return "", fmt.Errorf("unknown case status %q (expected open, cleared or confirmed)", s)
+}
+
+// Case is a watchlist hit waiting for the bank to review it. ScoreBps is how
+// similar the names are, in basis points.
+type Case struct {
+	ID         int64      `json:"id"`
+	Subject    Subject    `json:"subject"`
+	EntryID    string     `json:"entryId"`
+	EntryName  string     `json:"entryName"`
+	List       string     `json:"list,omitempty"`
+	ScoreBps   int64      `json:"scoreBps"`
+	Status     Status     `json:"status"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ReviewedBy int64      `json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+	Note       string     `json:"note,omitempty"`
+}
+
+// Match is a watchlist entry similar to a screened name. Name is the entry's
+// name or alias that matched best.
+type Match struct {
+	Entry    Entry  `json:"entry"`
+	Name     string `json:"name"`
+	ScoreBps int64  `json:"scoreBps"`
+}
+
+// Settings are where the watchlist is and how similar a name must be to an
+// entry, in basis points, to be a hit. An empty Watchlist turns screening
+// off.
+type Settings struct {
+	Watchlist    string `json:"watchlist"`
+	ThresholdBps int64  `json:"thresholdBps"`
+}
@@ -0,0 +1,102 @@
+package sanctions
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	cases    []*Case // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		cases:    []*Case{},
+	}
+
+	if err := storage.LoadCollection(filePath, "screeningCases", &repo.cases); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, c := range repo.cases {
+		if c.ID >= repo.nextID {
+			repo.nextID = c.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "screeningCases", r.cases); err != nil {
+		return fmt.Errorf("failed to save screening case data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(c Case) (*Case, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c.ID = r.nextID
+	r.cases = append(r.cases, &c)
+	r.nextID++
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	copied := c
+	return &copied, nil
+}
+
+func (r *Repository) Update(c *Case) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.cases {
+		if existing.ID == c.ID {
+			copied := *c
+			r.cases[i] = &copied
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("screening case with ID %d not found", c.ID)
+}
+
+func (r *Repository) GetByID(id int64) (*Case, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, c := range r.cases {
+		if c.ID == id {
+			copied := *c
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("screening case with ID %d not found", id)
+}
+
+func (r *Repository) GetByBankID(bankID int64) []*Case {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	cases := []*Case{}
+	for _, c := range r.cases {
+		if c.Subject.BankID == bankID {
+			copied := *c
+			cases = append(cases, &copied)
+		}
+	}
+
+	return cases
+}
@@ -0,0 +1,184 @@
+package sanctions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrListed is returned, wrapped with the name and the case, when a name
+// matches the watchlist and the bank has not cleared the match.
+var ErrListed = errors.New("name matches the sanctions watchlist")
+
+type Service struct {
+	repo      *Repository
+	watchlist *Watchlist // nil when screening is off
+	threshold int64
+}
+
+func NewService(repo *Repository, settings Settings) *Service {
+	s := &Service{
+		repo:      repo,
+		threshold: settings.ThresholdBps,
+	}
+	if settings.Watchlist != "" {
+		s.watchlist = NewWatchlist(settings.Watchlist)
+	}
+	return s
+}
+
+// Watchlist returns the loaded watchlist, nil when screening is off.
+func (s *Service) Watchlist() *Watchlist {
+	return s.watchlist
+}
+
+// Matches returns the watchlist entries at least as similar to name as the
+// threshold, best match first.
+func (s *Service) Matches(name string) ([]Match, error) {
+	if s.watchlist == nil {
+		return []Match{}, nil
+	}
+	entries, err := s.watchlist.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{}
+	for _, entry := range entries {
+		best := Match{Entry: entry}
+		for _, candidate := range append([]string{entry.Name}, entry.Aliases...) {
+			if score := similarity(name, candidate); score > best.ScoreBps {
+				best.Name, best.ScoreBps = candidate, score
+			}
+		}
+		if best.ScoreBps >= s.threshold {
+			matches = append(matches, best)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].ScoreBps > matches[j].ScoreBps })
+
+	return matches, nil
+}
+
+// Screen checks a name before the action it is for goes ahead. A hit opens
+// a case for the bank and blocks the action until the bank clears the case;
+// a confirmed case keeps blocking it. When the watchlist cannot be read the
+// action is blocked too.
+func (s *Service) Screen(subject Subject) error {
+	matches, err := s.Matches(subject.Name)
+	if err != nil {
+		return fmt.Errorf("failed to screen %q: %w", subject.Name, err)
+	}
+
+	for _, m := range matches {
+		existing := s.findCase(subject, m.Entry)
+		if existing != nil && existing.Status == StatusCleared {
+			continue
+		}
+		if existing != nil {
+			return fmt.Errorf("%w: %q is similar to %q, see case %d", ErrListed, subject.Name, m.Name, existing.ID)
+		}
+
+		c, err := s.repo.Create(Case{
+			Subject:   subject,
+			EntryID:   entryKey(m.Entry),
+			EntryName: m.Entry.Name,
+			List:      m.Entry.List,
+			ScoreBps:  m.ScoreBps,
+			Status:    StatusOpen,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open screening case: %w", err)
+		}
+		return fmt.Errorf("%w: %q is similar to %q, case %d is open for review", ErrListed, subject.Name, m.Name, c.ID)
+	}
+
+	return nil
+}
+
+// GetBankCases returns the bank's cases with the given status, all of them
+// when status is empty.
+func (s *Service) GetBankCases(bankID int64, status Status) []*Case {
+	cases := []*Case{}
+	for _, c := range s.repo.GetByBankID(bankID) {
+		if status == "" || c.Status == status {
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+func (s *Service) GetCase(id int64) (*Case, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid screening case ID: %d", id)
+	}
+	return s.repo.GetByID(id)
+}
+
+// Clear closes a case as a different person, so the subject can go ahead
+// when they try again.
+func (s *Service) Clear(bankID, id, reviewerID int64, note string) (*Case, error) {
+	return s.close(bankID, id, StatusCleared, reviewerID, note)
+}
+
+// Confirm closes a case as a true match, the subject stays blocked.
+func (s *Service) Confirm(bankID, id, reviewerID int64, note string) (*Case, error) {
+	if strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("give a note on why the name is a true match")
+	}
+	return s.close(bankID, id, StatusConfirmed, reviewerID, note)
+}
+
+func (s *Service) close(bankID, id int64, status Status, reviewerID int64, note string) (*Case, error) {
+	c, err := s.GetCase(id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Subject.BankID != bankID {
+		return nil, fmt.Errorf("screening case %d is not a case of bank %d", id, bankID)
+	}
+	if c.Status != StatusOpen {
+		return nil, fmt.Errorf("screening case %d is already %s", id, c.Status)
+	}
+
+	now := time.Now()
+	c.Status = status
+	c.ReviewedBy = reviewerID
+	c.ReviewedAt = &now
+	c.Note = strings.TrimSpace(note)
+
+	if err := s.repo.Update(c); err != nil {
+		return nil, fmt.Errorf("failed to update screening case: %w", err)
+	}
+	return c, nil
+}
+
+// findCase returns the latest case for the same subject and entry, nil if
+// there is none.
+func (s *Service) findCase(subject Subject, entry Entry) *Case {
+	var found *Case
+	for _, c := range s.repo.GetByBankID(subject.BankID) {
+		if c.EntryID == entryKey(entry) && sameSubject(c.Subject, subject) {
+			found = c
+		}
+	}
+	return found
+}
+
+// sameSubject is whether two screenings were of the same name for the same
+// person joining the bank or the same payee.
+func sameSubject(a, b Subject) bool {
+	return a.Kind == b.Kind && a.BankID == b.BankID && a.UserID == b.UserID && a.PayeeID == b.PayeeID &&
+		normalize(a.Name) == normalize(b.Name)
+}
+
+// entryKey identifies an entry, by its name for lists without IDs.
+func entryKey(e Entry) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Name
+}
@@ -0,0 +1,180 @@
+package sanctions
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Watchlist is the list of names loaded from a CSV or XML file. The file is
+// read again whenever it has changed, so a new list takes effect without
+// restarting.
+//
+// CSV files have a header row with the columns id, name, aliases and list;
+// aliases are separated by semicolons. XML files look like:
+//
+//	<watchlist>
+//	  <entry id="EX-1" list="EXAMPLE">
+//	    <name>Ivan Petrov</name>
+//	    <alias>Ivan Petroff</alias>
+//	  </entry>
+//	</watchlist>
+type Watchlist struct {
+	path     string
+	mutex    sync.Mutex
+	modTime  time.Time
+	entries  []Entry
+	loadedAt time.Time
+}
+
+func NewWatchlist(path string) *Watchlist {
+	return &Watchlist{path: path}
+}
+
+func (w *Watchlist) Path() string {
+	return w.path
+}
+
+// Entries returns the current list, reading the file again if it changed
+// since it was last read.
+func (w *Watchlist) Entries() ([]Entry, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watchlist: %w", err)
+	}
+	if w.entries != nil && info.ModTime().Equal(w.modTime) {
+		return w.entries, nil
+	}
+
+	entries, err := readWatchlist(w.path)
+	if err != nil {
+		return nil, err
+	}
+
+	w.entries = entries
+	w.modTime = info.ModTime()
+	w.loadedAt = time.Now()
+	return w.entries, nil
+}
+
+// LoadedAt is when the file was last read.
+func (w *Watchlist) LoadedAt() time.Time {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.loadedAt
+}
+
+func readWatchlist(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	var entries []Entry
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		entries, err = parseCSV(data)
+	case ".xml":
+		entries, err = parseXML(data)
+	default:
+		return nil, fmt.Errorf("unsupported watchlist format %q, use .csv or .xml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+func parseCSV(data []byte) ([]Entry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header row: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("the header has no name column")
+	}
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	entries := []Entry{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entry := Entry{
+			ID:   field(record, "id"),
+			Name: field(record, "name"),
+			List: field(record, "list"),
+		}
+		if entry.Name == "" {
+			line, _ := r.FieldPos(0)
+			return nil, fmt.Errorf("line %d: name cannot be empty", line)
+		}
+		for _, alias := range strings.Split(field(record, "aliases"), ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseXML(data []byte) ([]Entry, error) {
+	var doc struct {
+		Entries []struct {
+			ID      string   `xml:"id,attr"`
+			List    string   `xml:"list,attr"`
+			Name    string   `xml:"name"`
+			Aliases []string `xml:"alias"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for i, e := range doc.Entries {
+		entry := Entry{ID: strings.TrimSpace(e.ID), Name: strings.TrimSpace(e.Name), List: strings.TrimSpace(e.List)}
+		if entry.Name == "" {
+			return nil, fmt.Errorf("entry %d: name cannot be empty", i+1)
+		}
+		for _, alias := range e.Aliases {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
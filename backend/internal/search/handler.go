@@ -0,0 +1,37 @@
+package search
+
+import (
+	"banking-app/backend/pkg/console"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+// HandleSearch asks the user what to look for and lists the best matches
+// they may see.
+func (h *Handler) HandleSearch(userID int64) {
+	query, _ := h.con.Prompt("Search for: ")
+
+	results, err := h.service.Search(userID, query, "", 20)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	if len(results) == 0 {
+		h.con.Println("Nothing found.")
+		return
+	}
+
+	for _, r := range results {
+		h.con.Printf("%-12s %-6d %-32s %s\n", r.Kind, r.ID, r.Title, r.Detail)
+	}
+}
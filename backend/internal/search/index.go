@@ -0,0 +1,266 @@
+package search
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// key identifies a document in the index.
+type key struct {
+	kind Kind
+	id   int64
+}
+
+// Index is an inverted index from words to the documents that contain them.
+// It is not safe for concurrent use, Service guards it.
+type Index struct {
+	docs     map[key]*Document
+	words    map[key][]string
+	postings map[string]map[key]struct{}
+
+	// sorted is every word in the index in order, for prefix matching. It is
+	// rebuilt on the next search after a word is added or removed.
+	sorted []string
+	dirty  bool
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     map[key]*Document{},
+		words:    map[key][]string{},
+		postings: map[string]map[key]struct{}{},
+	}
+}
+
+// Put adds a document, replacing the one of the same kind and ID.
+func (ix *Index) Put(doc Document) {
+	k := key{doc.Kind, doc.ID}
+	if old, ok := ix.docs[k]; ok && reflect.DeepEqual(*old, doc) {
+		return
+	}
+	ix.Remove(doc.Kind, doc.ID)
+
+	var words []string
+	for _, text := range doc.Text {
+		words = append(words, tokenize(text)...)
+	}
+	sort.Strings(words)
+	words = slices.Compact(words)
+
+	for _, w := range words {
+		docs, ok := ix.postings[w]
+		if !ok {
+			docs = map[key]struct{}{}
+			ix.postings[w] = docs
+			ix.dirty = true
+		}
+		docs[k] = struct{}{}
+	}
+	ix.docs[k] = &doc
+	ix.words[k] = words
+}
+
+func (ix *Index) Remove(kind Kind, id int64) {
+	k := key{kind, id}
+	for _, w := range ix.words[k] {
+		delete(ix.postings[w], k)
+		if len(ix.postings[w]) == 0 {
+			delete(ix.postings, w)
+			ix.dirty = true
+		}
+	}
+	delete(ix.docs, k)
+	delete(ix.words, k)
+}
+
+// IDs lists the IDs of the documents of a kind.
+func (ix *Index) IDs(kind Kind) []int64 {
+	var ids []int64
+	for k := range ix.docs {
+		if k.kind == kind {
+			ids = append(ids, k.id)
+		}
+	}
+	return ids
+}
+
+// Search finds the documents that match every word of the query and that
+// keep returns true for, best first.
+func (ix *Index) Search(query string, keep func(*Document) bool, limit int) []Result {
+	words := tokenize(query)
+	if len(words) == 0 {
+		return nil
+	}
+	if ix.dirty {
+		ix.sorted = ix.sorted[:0]
+		for w := range ix.postings {
+			ix.sorted = append(ix.sorted, w)
+		}
+		sort.Strings(ix.sorted)
+		ix.dirty = false
+	}
+
+	var scores map[key]int
+	for _, w := range words {
+		matched := map[key]int{}
+		for term, m := range ix.expand(w) {
+			for k := range ix.postings[term] {
+				if scores != nil {
+					if _, ok := scores[k]; !ok {
+						continue
+					}
+				}
+				matched[k] = max(matched[k], int(m))
+			}
+		}
+		for k := range matched {
+			matched[k] += scores[k]
+		}
+		scores = matched
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	var results []Result
+	for k, score := range scores {
+		doc := ix.docs[k]
+		if keep != nil && !keep(doc) {
+			continue
+		}
+		results = append(results, Result{Kind: doc.Kind, ID: doc.ID, Title: doc.Title, Detail: doc.Detail, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Kind != b.Kind {
+			return kindOrder(a.Kind) < kindOrder(b.Kind)
+		}
+		// newest first, mostly matters for transactions
+		return a.ID > b.ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// expand lists the words in the index a query word matches and how well.
+func (ix *Index) expand(w string) map[string]Match {
+	terms := map[string]Match{}
+	for i := sort.SearchStrings(ix.sorted, w); i < len(ix.sorted) && strings.HasPrefix(ix.sorted[i], w); i++ {
+		terms[ix.sorted[i]] = MatchPrefix
+	}
+	if _, ok := ix.postings[w]; ok {
+		terms[w] = MatchExact
+	}
+
+	// short words and numbers have too many neighbours to guess at
+	allowed := typos(w)
+	if allowed == 0 {
+		return terms
+	}
+	for _, term := range ix.sorted {
+		if _, ok := terms[term]; ok {
+			continue
+		}
+		if abs(utf8.RuneCountInString(term)-utf8.RuneCountInString(w)) <= allowed && distance(w, term, allowed) <= allowed {
+			terms[term] = MatchFuzzy
+		}
+	}
+	return terms
+}
+
+// typos is how many edits a query word may be away from a word it matches.
+func typos(w string) int {
+	if strings.IndexFunc(w, unicode.IsLetter) < 0 {
+		return 0
+	}
+	switch n := utf8.RuneCountInString(w); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// distance is the Levenshtein distance between a and b, or most+1 once it is
+// known to be more than most.
+func distance(a, b string, most int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			best = min(best, cur[j])
+		}
+		if best > most {
+			return most + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// tokenize splits text into lowercase words of letters and digits. Runs of
+// digit groups, such as an account number written in blocks, are also kept
+// joined so the number can be searched for with or without spaces.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var joined, run []string
+	flush := func() {
+		if len(run) > 1 {
+			joined = append(joined, strings.Join(run, ""))
+		}
+		run = run[:0]
+	}
+	for _, w := range words {
+		if isNumber(w) {
+			run = append(run, w)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return append(words, joined...)
+}
+
+func isNumber(w string) bool {
+	return strings.IndexFunc(w, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
+
+func kindOrder(k Kind) int {
+	for i, kind := range kinds {
+		if kind == k {
+			return i
+		}
+	}
+	return len(kinds)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
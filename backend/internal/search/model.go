@@ -0,0 +1,83 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Kind is the type of record a result points at.
+type Kind string
+
+const (
+	KindBank        Kind = "bank"
+	KindCustomer    Kind = "customer"
+	KindUser        Kind = "user"
+	KindAccount     Kind = "account"
+	KindTransaction Kind = "transaction"
+)
+
+// kinds lists every kind in the order results of equal score are shown.
+var kinds = []Kind{KindCustomer, KindAccount, KindUser, KindBank, KindTransaction}
+
+func ParseKind(s string) (Kind, error) {
+	if k := Kind(s); slices.Contains(kinds, k) {
+		return k, nil
+	}
+	return "", fmt.Errorf("unknown kind %q (expected bank, customer, user, account or transaction)", s)
+}
+
+// Match is how well a word of the query matched a word of a record.
+type Match int
+
+const (
+	// MatchFuzzy words are one or two typos apart.
+	MatchFuzzy Match = 1
+	// MatchPrefix words start with the query word.
+	MatchPrefix Match = 2
+	MatchExact  Match = 3
+)
+
+// Audience is who may see a record: everyone, or the users, the operators of
+// the banks and the customers listed.
+type Audience struct {
+	Everyone    bool
+	UserIDs     []int64
+	BankIDs     []int64
+	CustomerIDs []int64
+}
+
+// Caller is who is searching. A bank operator has BankID set, a customer
+// CustomerID.
+type Caller struct {
+	UserID     int64
+	BankID     int64
+	CustomerID int64
+}
+
+func (a Audience) allows(c Caller) bool {
+	return a.Everyone ||
+		slices.Contains(a.UserIDs, c.UserID) ||
+		(c.BankID != 0 && slices.Contains(a.BankIDs, c.BankID)) ||
+		(c.CustomerID != 0 && slices.Contains(a.CustomerIDs, c.CustomerID))
+}
+
+// Document is a record as the index sees it. Text holds the fields that are
+// searched, Title and Detail are what a result shows.
+type Document struct {
+	Kind     Kind
+	ID       int64
+	Title    string
+	Detail   string
+	Text     []string
+	Audience Audience
+}
+
+// Result is a record that matched every word of the query. Score adds up
+// how well each word matched, higher is better.
+type Result struct {
+	Kind   Kind   `json:"kind"`
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Score  int    `json:"score"`
+}
@@ -0,0 +1,211 @@
+package search
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/internal/user"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Service searches banks, customers, users, accounts and transactions. The
+// index is brought up to date before every search: records of the small
+// collections that changed are indexed again, transactions only from the last
+// one indexed, as they are never edited.
+type Service struct {
+	users        *user.Service
+	banks        *bank.Service
+	customers    *customer.Service
+	accounts     *account.Service
+	transactions *transactions.Service
+
+	mutex  sync.Mutex
+	index  *Index
+	lastTx int64
+}
+
+func NewService(users *user.Service, banks *bank.Service, customers *customer.Service, accounts *account.Service, txs *transactions.Service) *Service {
+	return &Service{
+		users:        users,
+		banks:        banks,
+		customers:    customers,
+		accounts:     accounts,
+		transactions: txs,
+		index:        NewIndex(),
+	}
+}
+
+// Caller works out what a user may see from their role: bank operators see
+// their bank's customers and everything of theirs, customers their own
+// accounts and transactions. Everyone sees the banks and their own user.
+func (s *Service) Caller(userID int64) (Caller, error) {
+	u, err := s.users.GetUser(userID)
+	if err != nil {
+		return Caller{}, err
+	}
+
+	c := Caller{UserID: u.ID}
+	switch u.Role {
+	case user.RoleBank:
+		if b, err := s.banks.GetBankByUserID(u.ID); err == nil {
+			c.BankID = b.ID
+		}
+	case user.RoleCustomer:
+		if cust, err := s.customers.GetCustomerByUserID(u.ID); err == nil {
+			c.CustomerID = cust.ID
+		}
+	}
+	return c, nil
+}
+
+// Search finds what the user may see that matches every word of the query.
+// Words match exactly, as the start of a word, or with a typo or two. An
+// empty kind searches every kind, limit 0 returns every result.
+func (s *Service) Search(userID int64, query string, kind Kind, limit int) ([]Result, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+	caller, err := s.Caller(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.refresh()
+	return s.index.Search(query, func(doc *Document) bool {
+		return (kind == "" || doc.Kind == kind) && doc.Audience.allows(caller)
+	}, limit), nil
+}
+
+func (s *Service) refresh() {
+	banks := map[int64]*bank.Bank{}
+	var docs []Document
+	for _, b := range s.banks.GetAllBanks() {
+		banks[b.ID] = b
+		docs = append(docs, Document{
+			Kind:     KindBank,
+			ID:       b.ID,
+			Title:    b.Name,
+			Detail:   fmt.Sprintf("bank %d", b.ID),
+			Text:     []string{b.Name},
+			Audience: Audience{Everyone: true},
+		})
+	}
+
+	customers := map[int64]*customer.Customer{}
+	byUser := map[int64]*customer.Customer{}
+	for _, c := range s.customers.GetAllCustomers() {
+		customers[c.ID] = c
+		byUser[c.UserID] = c
+		text := []string{c.Name}
+		if c.Details != nil && c.Details.FullName != c.Name {
+			text = append(text, c.Details.FullName)
+		}
+		docs = append(docs, Document{
+			Kind:     KindCustomer,
+			ID:       c.ID,
+			Title:    c.Name,
+			Detail:   fmt.Sprintf("%s, %s", bankName(banks, c.BankID), c.Status),
+			Text:     text,
+			Audience: Audience{BankIDs: []int64{c.BankID}, CustomerIDs: []int64{c.ID}},
+		})
+	}
+
+	for _, u := range s.users.GetAllUsers() {
+		audience := Audience{UserIDs: []int64{u.ID}}
+		if c, ok := byUser[u.ID]; ok {
+			audience.BankIDs = []int64{c.BankID}
+		}
+		docs = append(docs, Document{
+			Kind:     KindUser,
+			ID:       u.ID,
+			Title:    u.Username,
+			Detail:   string(u.Role),
+			Text:     []string{u.Username},
+			Audience: audience,
+		})
+	}
+
+	accounts := map[int64]*account.Account{}
+	for _, acc := range s.accounts.GetAllAccounts() {
+		accounts[acc.ID] = acc
+		owner := "internal"
+		if c, ok := customers[acc.CustomerID]; ok {
+			owner = c.Name
+		}
+		audience := Audience{BankIDs: []int64{acc.BankID}}
+		if !acc.Internal() {
+			audience.CustomerIDs = []int64{acc.CustomerID}
+		}
+		docs = append(docs, Document{
+			Kind:     KindAccount,
+			ID:       acc.ID,
+			Title:    accountno.Group(acc.Number),
+			Detail:   fmt.Sprintf("%s %s, %s", acc.Type, owner, bankName(banks, acc.BankID)),
+			Text:     []string{accountno.Group(acc.Number), owner},
+			Audience: audience,
+		})
+	}
+
+	// drop what was deleted since the last search
+	seen := map[key]bool{}
+	for _, doc := range docs {
+		seen[key{doc.Kind, doc.ID}] = true
+		s.index.Put(doc)
+	}
+	for _, kind := range []Kind{KindBank, KindCustomer, KindUser, KindAccount} {
+		for _, id := range s.index.IDs(kind) {
+			if !seen[key{kind, id}] {
+				s.index.Remove(kind, id)
+			}
+		}
+	}
+
+	for _, tx := range s.transactions.GetAllTransactions() {
+		if tx.Id <= s.lastTx {
+			continue
+		}
+		s.lastTx = tx.Id
+
+		text := []string{tx.Payer, tx.Payee, tx.Memo}
+		var audience Audience
+		for _, id := range []int64{tx.FromAccountID, tx.ToAccountID} {
+			acc, ok := accounts[id]
+			if !ok {
+				continue
+			}
+			text = append(text, accountno.Group(acc.Number))
+			audience.BankIDs = append(audience.BankIDs, acc.BankID)
+			if !acc.Internal() {
+				audience.CustomerIDs = append(audience.CustomerIDs, acc.CustomerID)
+			}
+		}
+
+		detail := fmt.Sprintf("%s %s %s", tx.CreatedAt.Format("2006-01-02"), tx.Type, money.Format(tx.Amount))
+		if tx.Memo != "" {
+			detail += ": " + tx.Memo
+		}
+		s.index.Put(Document{
+			Kind:     KindTransaction,
+			ID:       tx.Id,
+			Title:    tx.Payer + " → " + tx.Payee,
+			Detail:   detail,
+			Text:     text,
+			Audience: audience,
+		})
+	}
+}
+
+func bankName(banks map[int64]*bank.Bank, id int64) string {
+	if b, ok := banks[id]; ok && b.Name != "" {
+		return b.Name
+	}
+	return fmt.Sprintf("bank %d", id)
+}
@@ -0,0 +1,39 @@
+package seed
+
+// Word lists the generator picks from. They are made up and short on
+// purpose, the data should look plausible rather than real.
+var (
+	firstNames = []string{
+		"Ada", "Ben", "Chloe", "Dev", "Elena", "Farid", "Grace", "Hugo", "Iris", "Jonas",
+		"Kira", "Leo", "Maya", "Nils", "Olive", "Pavel", "Quinn", "Rosa", "Sami", "Tara",
+		"Uma", "Victor", "Wren", "Xavier", "Yara", "Zane",
+	}
+	lastNames = []string{
+		"Abbott", "Brennan", "Castillo", "Dawson", "Ekström", "Fischer", "Gallo", "Hughes",
+		"Iyer", "Jensen", "Kowalski", "Lindqvist", "Moreau", "Novak", "Okafor", "Petrov",
+		"Quint", "Rossi", "Schmidt", "Tanaka", "Ueda", "Varga", "Walsh", "Young", "Zimmer",
+	}
+	streets = []string{
+		"Mill", "Church", "Station", "Harbour", "Orchard", "Bridge", "Market", "Chapel",
+		"Elm", "Victoria", "Park", "Kings",
+	}
+	towns = []string{
+		"Ashford", "Brookfield", "Carlow", "Dunmore", "Eastwick", "Fairhaven", "Glenbrook",
+		"Hollowell", "Inverleigh", "Kingsbridge",
+	}
+	countries = []string{"GB", "IE", "NL", "DE", "FR"}
+
+	bankNames = []string{
+		"Harbour", "Northgate", "Riverside", "Summit", "Meadow", "Beacon", "Oakridge",
+		"Lantern", "Cobalt", "Granite",
+	}
+	employers = []string{
+		"Brightworks Ltd", "Cedar Logistics", "Fernhill School", "Ironleaf Studios",
+		"Lakeside Clinic", "Northwind Foods", "Pinecrest Council", "Quayside Marine",
+	}
+	merchants = []string{
+		"Corner Grocer", "Daily Bread Bakery", "FreshMart", "Metro Transit", "Bean There Cafe",
+		"PageTurner Books", "Spark Electronics", "Green Pharmacy", "Fuel Stop", "Cinema Nova",
+		"Streamly", "Trail Outfitters",
+	}
+)
@@ -0,0 +1,346 @@
+package seed
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/interest"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/internal/user"
+	"banking-app/backend/pkg/accountno"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// Size is how much data to generate: banks, customers spread over them, and
+// days of transaction history.
+type Size struct {
+	Banks     int `json:"banks"`
+	Customers int `json:"customers"`
+	Days      int `json:"days"`
+}
+
+// Presets are the sizes developers and load tests share.
+var Presets = map[string]Size{
+	"small":  {Banks: 2, Customers: 20, Days: 90},
+	"medium": {Banks: 5, Customers: 250, Days: 180},
+	"large":  {Banks: 10, Customers: 1000, Days: 365},
+}
+
+// DefaultEnd is the last day of history unless asked otherwise, fixed so the
+// same seed gives the same data on any day.
+var DefaultEnd = time.Date(2025, time.December, 31, 0, 0, 0, 0, time.Local)
+
+// Options are what to generate. The same options always give the same data.
+// Every user gets Password, which must be given.
+type Options struct {
+	Size
+	Seed     uint64
+	End      time.Time
+	Password string
+}
+
+// Data is a generated database. Every account balance is what its
+// transactions add up to. The history has no interest in it, so the savings
+// accounts have their interest accrued through the end of it, and end of
+// day does not pay them for the days before.
+type Data struct {
+	Users        []user.User
+	Banks        []bank.Bank
+	Customers    []customer.Customer
+	Products     []interest.Product
+	Accounts     []account.Account
+	Accruals     []interest.Accrual
+	Transactions []transactions.Transaction
+}
+
+// generator holds the state of one run.
+type generator struct {
+	rng      *rand.Rand
+	data     *Data
+	password string
+
+	balances map[int64]int64 // account ID -> cents
+	income   map[int64]int64 // bank ID -> income account ID
+	people   []*person
+}
+
+// person is a generated customer and their habits.
+type person struct {
+	customer *customer.Customer
+	checking *account.Account
+	savings  *account.Account
+	employer string
+	payday   int   // day of the month the salary comes in
+	salary   int64 // cents
+	rent     int64 // cents, paid on the 1st to the landlord
+	landlord *person
+	spend    float64 // chance of a card payment on a given day
+}
+
+// event is something a person does at a moment of a day. It returns the
+// transactions it made, nil when the money was not there.
+type event struct {
+	at  time.Time
+	run func(at time.Time) []transactions.Transaction
+}
+
+// Generate builds a database of opts.Banks banks sharing opts.Customers
+// customers and opts.Days days of history up to opts.End.
+func Generate(opts Options) (*Data, error) {
+	if opts.Banks < 1 || opts.Customers < 1 || opts.Days < 1 {
+		return nil, fmt.Errorf("banks, customers and days must all be at least 1")
+	}
+	if opts.Password == "" {
+		return nil, fmt.Errorf("a password for the users is needed")
+	}
+	// every bank has an income account and every customer up to two accounts
+	if opts.Banks > accountno.MaxBankID || int64(opts.Banks)+2*int64(opts.Customers) > accountno.MaxAccountID {
+		return nil, fmt.Errorf("too many banks or customers for their account numbers")
+	}
+	if opts.End.IsZero() {
+		opts.End = DefaultEnd
+	}
+	end := time.Date(opts.End.Year(), opts.End.Month(), opts.End.Day(), 0, 0, 0, 0, time.Local)
+	start := end.AddDate(0, 0, -(opts.Days - 1))
+
+	g := &generator{
+		rng:      rand.New(rand.NewPCG(opts.Seed, 0x5eed)),
+		data:     &Data{},
+		password: opts.Password,
+		balances: map[int64]int64{},
+		income:   map[int64]int64{},
+	}
+
+	g.banks(opts.Banks, start)
+	g.customers(opts.Customers, opts.Banks, start)
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		g.day(day)
+	}
+
+	for i := range g.data.Accounts {
+		acc := &g.data.Accounts[i]
+		acc.Balance = g.balances[acc.ID]
+		if acc.ProductID != 0 {
+			g.data.Accruals = append(g.data.Accruals, interest.Accrual{AccountID: acc.ID, AccruedThrough: end})
+		}
+	}
+	return g.data, nil
+}
+
+func (g *generator) banks(count int, start time.Time) {
+	for i := 1; i <= count; i++ {
+		userID := int64(len(g.data.Users) + 1)
+		g.data.Users = append(g.data.Users, user.User{
+			ID:       userID,
+			Username: fmt.Sprintf("bank%02d", i),
+			Password: g.password,
+			Role:     user.RoleBank,
+		})
+
+		name := bankNames[(i-1)%len(bankNames)] + " Bank"
+		if i > len(bankNames) {
+			name = fmt.Sprintf("%s %d", name, (i-1)/len(bankNames)+1)
+		}
+		b := bank.NewBank(int64(i), userID, name)
+		g.data.Banks = append(g.data.Banks, *b)
+
+		g.data.Products = append(g.data.Products, *interest.NewProduct(int64(i), b.ID, "Easy Saver", int64(50+g.rng.IntN(11)*25), interest.Actual365))
+
+		income := g.account(b.ID, 0, account.TypeIncome, 0, start.AddDate(-1, 0, 0))
+		g.income[b.ID] = income.ID
+	}
+}
+
+func (g *generator) customers(count, banks int, start time.Time) {
+	for i := 1; i <= count; i++ {
+		userID := int64(len(g.data.Users) + 1)
+		g.data.Users = append(g.data.Users, user.User{
+			ID:       userID,
+			Username: fmt.Sprintf("customer%05d", i),
+			Password: g.password,
+			Role:     user.RoleCustomer,
+		})
+
+		bankID := int64((i-1)%banks + 1)
+		operator := g.data.Banks[bankID-1].UserID
+		name := pick(g.rng, firstNames) + " " + pick(g.rng, lastNames)
+		c := customer.NewCustomer(int64(i), userID, bankID, name)
+
+		// customers joined up to two years before the history starts
+		joined := start.AddDate(0, 0, -g.rng.IntN(730)-1).Add(time.Duration(9+g.rng.IntN(8)) * time.Hour)
+		c.Details = &customer.Details{
+			FullName:    name,
+			DateOfBirth: time.Date(1950+g.rng.IntN(55), time.Month(1+g.rng.IntN(12)), 1+g.rng.IntN(28), 0, 0, 0, 0, time.UTC),
+			Address:     fmt.Sprintf("%d %s Street, %s", 1+g.rng.IntN(200), pick(g.rng, streets), pick(g.rng, towns)),
+			Document: customer.Document{
+				Type:      customer.DocumentPassport,
+				Number:    fmt.Sprintf("%c%c%07d", 'A'+g.rng.IntN(26), 'A'+g.rng.IntN(26), g.rng.IntN(10000000)),
+				Country:   pick(g.rng, countries),
+				ExpiresAt: time.Date(start.Year()+1+g.rng.IntN(9), time.Month(1+g.rng.IntN(12)), 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		c.Status = customer.StatusVerified
+		c.History = []customer.Event{
+			{At: joined, Status: customer.StatusPending, By: userID},
+			{At: joined.Add(time.Duration(1+g.rng.IntN(48)) * time.Hour), Status: customer.StatusVerified, By: operator},
+		}
+		g.data.Customers = append(g.data.Customers, *c)
+
+		p := &person{
+			customer: &g.data.Customers[len(g.data.Customers)-1],
+			employer: pick(g.rng, employers),
+			payday:   1 + g.rng.IntN(28),
+			salary:   int64(1500+g.rng.IntN(4500)) * 100,
+			spend:    0.2 + g.rng.Float64()*0.5,
+		}
+		opened := joined.Add(72 * time.Hour)
+		p.checking = g.account(bankID, c.ID, account.TypeChecking, 0, opened)
+		if g.rng.IntN(100) < 45 {
+			p.savings = g.account(bankID, c.ID, account.TypeSavings, bankID, opened.Add(time.Hour))
+		}
+		if g.rng.IntN(100) < 60 {
+			p.rent = p.salary * int64(25+g.rng.IntN(15)) / 100 / 100 * 100
+		}
+		g.people = append(g.people, p)
+	}
+
+	// everyone who pays rent pays it to someone else, at any bank
+	for _, p := range g.people {
+		if p.rent > 0 && len(g.people) > 1 {
+			for p.landlord == nil || p.landlord == p {
+				p.landlord = g.people[g.rng.IntN(len(g.people))]
+			}
+		}
+	}
+
+	// accounts open with a cash deposit, paid in the order they were opened
+	var openings []event
+	for _, p := range g.people {
+		for _, acc := range []*account.Account{p.checking, p.savings} {
+			if acc == nil {
+				continue
+			}
+			id, amount := acc.ID, int64(100+g.rng.IntN(9900))*100
+			if acc.Type == account.TypeChecking {
+				amount /= 4
+			}
+			openings = append(openings, event{acc.CreatedAt, func(at time.Time) []transactions.Transaction {
+				return g.pay(0, id, amount, transactions.TypeDeposit, "cash", label(id), "opening deposit", at)
+			}})
+		}
+	}
+	g.run(openings)
+}
+
+// account opens an account. The data is built by value, so callers get a
+// pointer they must not keep across another call.
+func (g *generator) account(bankID, customerID int64, t account.Type, productID int64, at time.Time) *account.Account {
+	// Generate has checked that every ID fits an account number
+	acc, _ := account.NewAccount(int64(len(g.data.Accounts)+1), bankID, customerID, t, productID)
+	acc.CreatedAt = at
+	g.data.Accounts = append(g.data.Accounts, *acc)
+	return acc
+}
+
+// day plays out one day of every person's habits in time order.
+func (g *generator) day(day time.Time) {
+	var events []event
+	at := func(hour int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(g.rng.IntN(3600))*time.Second)
+	}
+
+	for _, p := range g.people {
+		p := p
+		if day.Day() == p.payday {
+			events = append(events, event{at(6), func(at time.Time) []transactions.Transaction {
+				return g.pay(0, p.checking.ID, p.salary, transactions.TypeDeposit, p.employer, label(p.checking.ID), "salary", at)
+			}})
+			if p.savings != nil {
+				share := p.salary * int64(5+g.rng.IntN(11)) / 100 / 100 * 100
+				events = append(events, event{at(20), func(at time.Time) []transactions.Transaction {
+					return g.pay(p.checking.ID, p.savings.ID, share, transactions.TypeTransfer, label(p.checking.ID), label(p.savings.ID), "savings", at)
+				}})
+			}
+		}
+		if day.Day() == 1 && p.landlord != nil {
+			events = append(events, event{at(8), func(at time.Time) []transactions.Transaction {
+				memo := "rent " + day.Format("January 2006")
+				return g.pay(p.checking.ID, p.landlord.checking.ID, p.rent, transactions.TypeTransfer, label(p.checking.ID), p.landlord.customer.Name, memo, at)
+			}})
+		}
+		if g.rng.Float64() < p.spend {
+			merchant := pick(g.rng, merchants)
+			amount := int64(300 + g.rng.IntN(11700))
+			events = append(events, event{at(9 + g.rng.IntN(12)), func(at time.Time) []transactions.Transaction {
+				return g.pay(p.checking.ID, 0, amount, transactions.TypeCard, label(p.checking.ID), merchant, "", at)
+			}})
+		}
+		if g.rng.IntN(100) < 4 {
+			amount := int64(2+g.rng.IntN(19)) * 1000
+			events = append(events, event{at(10 + g.rng.IntN(10)), func(at time.Time) []transactions.Transaction {
+				return g.withdraw(p, amount, at)
+			}})
+		}
+	}
+
+	g.run(events)
+}
+
+// run plays events in time order.
+func (g *generator) run(events []event) {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+	for _, e := range events {
+		g.data.Transactions = append(g.data.Transactions, e.run(e.at)...)
+	}
+}
+
+// withdraw is a cash withdrawal and the bank's fee for it.
+func (g *generator) withdraw(p *person, amount int64, at time.Time) []transactions.Transaction {
+	const charge = 150
+	if g.balances[p.checking.ID] < amount+charge {
+		return nil
+	}
+	txs := g.pay(p.checking.ID, 0, amount, transactions.TypeWithdrawal, label(p.checking.ID), "cash", "", at)
+	memo := fmt.Sprintf("withdrawal fee for transaction %d", txs[0].Id)
+	income := g.income[p.customer.BankID]
+	return append(txs, g.pay(p.checking.ID, income, charge, transactions.TypeFee, label(p.checking.ID), "bank fee", memo, at)...)
+}
+
+// pay records a transaction if the paying account has the money, 0 is
+// outside the bank on either side.
+func (g *generator) pay(fromID, toID, amount int64, t transactions.Type, payer, payee, memo string, at time.Time) []transactions.Transaction {
+	if fromID != 0 && g.balances[fromID] < amount {
+		return nil
+	}
+	if fromID != 0 {
+		g.balances[fromID] -= amount
+	}
+	if toID != 0 {
+		g.balances[toID] += amount
+	}
+
+	return []transactions.Transaction{{
+		Id:            int64(len(g.data.Transactions) + 1),
+		Payer:         payer,
+		Payee:         payee,
+		Type:          t,
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount,
+		Memo:          memo,
+		CreatedAt:     at,
+		BusinessDate:  at.Format(time.DateOnly),
+	}}
+}
+
+func label(accountID int64) string {
+	return fmt.Sprintf("account %d", accountID)
+}
+
+func pick(rng *rand.Rand, words []string) string {
+	return words[rng.IntN(len(words))]
+}
@@ -0,0 +1,113 @@
+package standingorder
+
+import (
+	"fmt"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily      Frequency = "daily"
+	Weekly     Frequency = "weekly"
+	Monthly    Frequency = "monthly" // on day N of every month
+	EndOfMonth Frequency = "end-of-month"
+)
+
+func ParseFrequency(s string) (Frequency, error) {
+	switch Frequency(s) {
+	case Daily, Weekly, Monthly, EndOfMonth:
+		return Frequency(s), nil
+	}
+	return "", fmt.Errorf("unknown frequency %q (expected daily, weekly, monthly or end-of-month)", s)
+}
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusPaused    Status = "paused"
+	StatusCancelled Status = "cancelled"
+	StatusFinished  Status = "finished" // past its end date
+)
+
+// MaxAttempts is how many runs try a payment the account cannot cover
+// before that payment is given up.
+const MaxAttempts = 3
+
+// Order is a customer's instruction to transfer Amount from one of their
+// accounts every period between StartDate and EndDate (nil for no end).
+// Day is the day of the month for monthly orders; days past the end of a
+// short month fall on its last day.
+//
+// NextDue is the date of the next payment by the schedule. When that is a
+// weekend or a holiday of the bank, daily payments are skipped and other
+// payments are made on the next business day. Attempts counts the failed tries of the
+// payment due at NextDue.
+type Order struct {
+	ID            int64      `json:"id"`
+	CustomerID    int64      `json:"customerid"`
+	FromAccountID int64      `json:"fromAccountId"`
+	ToAccountID   int64      `json:"toAccountId"`
+	Amount        int64      `json:"amount"`
+	Memo          string     `json:"memo,omitempty"`
+	Frequency     Frequency  `json:"frequency"`
+	Day           int        `json:"day,omitempty"`
+	StartDate     time.Time  `json:"startDate"`
+	EndDate       *time.Time `json:"endDate,omitempty"`
+	NextDue       time.Time  `json:"nextDue"`
+	Attempts      int        `json:"attempts,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	Status        Status     `json:"status"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// first is the first payment date on or after the start date.
+func (o *Order) first() time.Time {
+	start := o.StartDate
+	switch o.Frequency {
+	case Monthly:
+		due := dayOfMonth(start.Year(), start.Month(), o.Day, start.Location())
+		if due.Before(start) {
+			due = dayOfMonth(start.Year(), start.Month()+1, o.Day, start.Location())
+		}
+		return due
+	case EndOfMonth:
+		return dayOfMonth(start.Year(), start.Month(), 31, start.Location())
+	}
+	return start
+}
+
+// after is the payment date that follows due.
+func (o *Order) after(due time.Time) time.Time {
+	switch o.Frequency {
+	case Daily:
+		return due.AddDate(0, 0, 1)
+	case Weekly:
+		return due.AddDate(0, 0, 7)
+	case Monthly:
+		return dayOfMonth(due.Year(), due.Month()+1, o.Day, due.Location())
+	default:
+		return dayOfMonth(due.Year(), due.Month()+1, 31, due.Location())
+	}
+}
+
+// dayOfMonth is the given day of a month, or the month's last day if it is
+// shorter.
+func dayOfMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	last := first.AddDate(0, 1, -1)
+	if day > last.Day() {
+		day = last.Day()
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// RunResult is what a run did with one payment of a standing order.
+type RunResult struct {
+	OrderID       int64     `json:"orderId"`
+	Due           time.Time `json:"due"`
+	Outcome       string    `json:"outcome"` // paid, retry, missed, failed or skipped
+	TransactionID int64     `json:"transactionId,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+}
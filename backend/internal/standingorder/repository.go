@@ -0,0 +1,102 @@
+package standingorder
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath string
+	mutex    sync.RWMutex
+	nextID   int64
+	orders   []*Order // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath: filePath,
+		nextID:   1,
+		orders:   []*Order{},
+	}
+
+	if err := storage.LoadCollection(filePath, "standingOrders", &repo.orders); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, order := range repo.orders {
+		if order.ID >= repo.nextID {
+			repo.nextID = order.ID + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "standingOrders", r.orders); err != nil {
+		return fmt.Errorf("failed to save standing order data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(order Order) (*Order, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	order.ID = r.nextID
+	r.nextID++
+	r.orders = append(r.orders, &order)
+
+	if err := r.saveData(); err != nil {
+		return nil, err
+	}
+
+	copied := order
+	return &copied, nil
+}
+
+// Update replaces the stored order with the same ID.
+func (r *Repository) Update(order *Order) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.orders {
+		if existing.ID == order.ID {
+			copied := *order
+			r.orders[i] = &copied
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("standing order with ID %d not found", order.ID)
+}
+
+// GetByID returns a copy of the order, changes are stored with Update.
+func (r *Repository) GetByID(id int64) (*Order, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, order := range r.orders {
+		if order.ID == id {
+			copied := *order
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("standing order with ID %d not found", id)
+}
+
+func (r *Repository) GetAll() []*Order {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	orders := make([]*Order, 0, len(r.orders))
+	for _, order := range r.orders {
+		copied := *order
+		orders = append(orders, &copied)
+	}
+
+	return orders
+}
@@ -0,0 +1,259 @@
+package standingorder
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/calendar"
+	"banking-app/backend/internal/notification"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/money"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Service struct {
+	repo          *Repository
+	accounts      *account.Repository
+	transactions  *transactions.Service
+	calendar      *calendar.Service
+	notifications *notification.Service
+}
+
+func NewService(repo *Repository, accounts *account.Repository, txs *transactions.Service, cal *calendar.Service, notifications *notification.Service) *Service {
+	return &Service{
+		repo:          repo,
+		accounts:      accounts,
+		transactions:  txs,
+		calendar:      cal,
+		notifications: notifications,
+	}
+}
+
+// Create stores a standing order from one of a customer's accounts. start
+// and end are days; end may be nil for an order that runs until cancelled.
+func (s *Service) Create(fromID, toID, amount int64, memo string, frequency Frequency, day int, start time.Time, end *time.Time) (*Order, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if fromID == toID {
+		return nil, fmt.Errorf("cannot transfer to the same account")
+	}
+	if frequency == Monthly && (day < 1 || day > 31) {
+		return nil, fmt.Errorf("a monthly order needs a day between 1 and 31")
+	}
+	if frequency != Monthly && day != 0 {
+		return nil, fmt.Errorf("only monthly orders have a day of the month")
+	}
+	if end != nil && end.Before(start) {
+		return nil, fmt.Errorf("end date cannot be before the start date")
+	}
+
+	from, err := s.accounts.GetByID(fromID)
+	if err != nil {
+		return nil, err
+	}
+	if from.Internal() {
+		return nil, fmt.Errorf("internal bank accounts cannot be used directly")
+	}
+	if _, err := s.accounts.GetByID(toID); err != nil {
+		return nil, err
+	}
+
+	order := Order{
+		CustomerID:    from.CustomerID,
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount,
+		Memo:          memo,
+		Frequency:     frequency,
+		Day:           day,
+		StartDate:     start,
+		EndDate:       end,
+		Status:        StatusActive,
+		CreatedAt:     time.Now(),
+	}
+	order.NextDue = order.first()
+
+	created, err := s.repo.Create(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create standing order: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) Pause(id int64) (*Order, error) {
+	return s.setStatus(id, StatusActive, StatusPaused)
+}
+
+func (s *Service) Resume(id int64) (*Order, error) {
+	return s.setStatus(id, StatusPaused, StatusActive)
+}
+
+func (s *Service) Cancel(id int64) (*Order, error) {
+	order, err := s.GetOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == StatusCancelled || order.Status == StatusFinished {
+		return nil, fmt.Errorf("standing order %d is already %s", id, order.Status)
+	}
+	return s.setStatus(id, order.Status, StatusCancelled)
+}
+
+func (s *Service) setStatus(id int64, from, to Status) (*Order, error) {
+	order, err := s.GetOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != from {
+		return nil, fmt.Errorf("standing order %d is %s", id, order.Status)
+	}
+
+	order.Status = to
+	if err := s.repo.Update(order); err != nil {
+		return nil, fmt.Errorf("failed to update standing order: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *Service) GetOrder(id int64) (*Order, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid standing order ID: %d", id)
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *Service) GetCustomerOrders(customerID int64) []*Order {
+	orders := []*Order{}
+	for _, order := range s.repo.GetAll() {
+		if order.CustomerID == customerID {
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+func (s *Service) GetAllOrders() []*Order {
+	return s.repo.GetAll()
+}
+
+// Run makes every payment of the active standing orders that is due on or
+// before today, catching up on days the runner did not run. A payment the
+// account cannot cover is tried again on the next run, up to MaxAttempts
+// times, before it is given up; the payments after it wait meanwhile. Any
+// other failure gives the payment up straight away. The customer is
+// notified of every payment given up. With a bankID only the orders paying
+// out of that bank's accounts are run, 0 runs them all.
+func (s *Service) Run(bankID int64, today time.Time) ([]RunResult, error) {
+	var results []RunResult
+
+	for _, order := range s.repo.GetAll() {
+		if order.Status != StatusActive {
+			continue
+		}
+		if bankID != 0 {
+			from, err := s.accounts.GetByID(order.FromAccountID)
+			if err != nil {
+				return results, err
+			}
+			if from.BankID != bankID {
+				continue
+			}
+		}
+
+		orderResults, err := s.runOrder(order, today)
+		results = append(results, orderResults...)
+
+		if updateErr := s.repo.Update(order); updateErr != nil && err == nil {
+			err = fmt.Errorf("failed to save standing order %d: %w", order.ID, updateErr)
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+func (s *Service) runOrder(order *Order, today time.Time) ([]RunResult, error) {
+	var results []RunResult
+
+	from, err := s.accounts.GetByID(order.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for order.Status == StatusActive {
+		if order.EndDate != nil && order.NextDue.After(*order.EndDate) {
+			order.Status = StatusFinished
+			break
+		}
+
+		due := order.NextDue
+		result := RunResult{OrderID: order.ID, Due: due}
+
+		runOn := s.calendar.NextBusinessDay(from.BankID, due)
+		if order.Frequency == Daily && !runOn.Equal(due) {
+			// a daily payment is not doubled up on the next business day
+			if due.After(today) {
+				break
+			}
+			result.Outcome = "skipped"
+			result.Reason = "not a business day"
+			results = append(results, result)
+			order.NextDue = order.after(due)
+			continue
+		}
+		if runOn.After(today) {
+			break
+		}
+
+		memo := order.Memo
+		if memo == "" {
+			memo = fmt.Sprintf("standing order %d", order.ID)
+		}
+
+		tx, err := s.transactions.Transfer(order.FromAccountID, order.ToAccountID, order.Amount, memo)
+		switch {
+		case err == nil:
+			result.Outcome = "paid"
+			result.TransactionID = tx.Id
+		case errors.Is(err, transactions.ErrInsufficientFunds) && order.Attempts+1 < MaxAttempts:
+			order.Attempts++
+			order.LastError = err.Error()
+			result.Outcome = "retry"
+			result.Reason = err.Error()
+			results = append(results, result)
+			return results, nil
+		case tx != nil:
+			// the money moved, only recording a fee or notification failed
+			result.Outcome = "paid"
+			result.TransactionID = tx.Id
+			order.NextDue = order.after(due)
+			order.Attempts = 0
+			order.LastError = ""
+			return append(results, result), err
+		default:
+			result.Outcome = "failed"
+			if errors.Is(err, transactions.ErrInsufficientFunds) {
+				result.Outcome = "missed"
+			}
+			result.Reason = err.Error()
+
+			msg := fmt.Sprintf("The standing order payment of %s from account %d due %s was not made: %v",
+				money.Format(order.Amount), order.FromAccountID, due.Format(time.DateOnly), err)
+			if err := s.notifications.Notify(order.CustomerID, order.FromAccountID, msg); err != nil {
+				return append(results, result), err
+			}
+		}
+
+		results = append(results, result)
+		order.NextDue = order.after(due)
+		order.Attempts = 0
+		order.LastError = ""
+	}
+
+	return results, nil
+}
@@ -0,0 +1,159 @@
+package statement
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// camtNamespace is the version of ISO 20022 bank to customer statement the
+// export follows.
+const camtNamespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Code   string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount camtAmount `xml:"Amt"`
+	Sign   string     `xml:"CdtDbtInd"`
+	Date   string     `xml:"Dt>Dt"`
+}
+
+type camtSum struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtParty struct {
+	Name string `xml:"Nm"`
+}
+
+type camtEntry struct {
+	Ref         string     `xml:"NtryRef"`
+	Amount      camtAmount `xml:"Amt"`
+	Sign        string     `xml:"CdtDbtInd"`
+	Status      string     `xml:"Sts"`
+	BookingDate string     `xml:"BookgDt>DtTm"`
+	ValueDate   string     `xml:"ValDt>Dt"`
+	ServicerRef string     `xml:"AcctSvcrRef"`
+	Code        string     `xml:"BkTxCd>Prtry>Cd"`
+	Details     struct {
+		EndToEndID string     `xml:"Refs>EndToEndId"`
+		Debtor     *camtParty `xml:"RltdPties>Dbtr,omitempty"`
+		Creditor   *camtParty `xml:"RltdPties>Cdtr,omitempty"`
+		Remittance string     `xml:"RmtInf>Ustrd,omitempty"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+type camtDocument struct {
+	XMLName   xml.Name `xml:"Document"`
+	Namespace string   `xml:"xmlns,attr"`
+	Header    struct {
+		MessageID string `xml:"MsgId"`
+		CreatedAt string `xml:"CreDtTm"`
+	} `xml:"BkToCstmrStmt>GrpHdr"`
+	Statement struct {
+		ID        string        `xml:"Id"`
+		CreatedAt string        `xml:"CreDtTm"`
+		From      string        `xml:"FrToDt>FrDtTm"`
+		To        string        `xml:"FrToDt>ToDtTm"`
+		Account   string        `xml:"Acct>Id>Othr>Id"`
+		Currency  string        `xml:"Acct>Ccy"`
+		Owner     string        `xml:"Acct>Ownr>Nm"`
+		Servicer  string        `xml:"Acct>Svcr>FinInstnId>Nm,omitempty"`
+		Balances  []camtBalance `xml:"Bal"`
+		Total     camtSum       `xml:"TxsSummry>TtlNtries"`
+		Credits   camtSum       `xml:"TxsSummry>TtlCdtNtries"`
+		Debits    camtSum       `xml:"TxsSummry>TtlDbtNtries"`
+		Entries   []camtEntry   `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+// writeCAMT053 writes the statement as an ISO 20022 camt.053 bank to
+// customer statement, the format accounting software imports.
+func writeCAMT053(w io.Writer, st *Statement) error {
+	id := fmt.Sprintf("STMT-%d-%s", st.Account.ID, st.To.Format("20060102"))
+
+	doc := camtDocument{Namespace: camtNamespace}
+	doc.Header.MessageID = id
+	doc.Header.CreatedAt = st.CreatedAt.Format(time.RFC3339)
+
+	s := &doc.Statement
+	s.ID = id
+	s.CreatedAt = doc.Header.CreatedAt
+	s.From = st.From.Format(time.RFC3339)
+	s.To = st.To.AddDate(0, 0, 1).Add(-time.Second).Format(time.RFC3339)
+	s.Account = accountno.Normalize(st.Account.Number)
+	s.Currency = st.Currency
+	s.Owner = st.Holder
+	s.Servicer = st.BankName
+	s.Balances = []camtBalance{
+		camtBal("OPBD", st.Opening, st.Currency, st.From),
+		camtBal("CLBD", st.Closing, st.Currency, st.To),
+	}
+
+	var credits, debits int
+	for _, l := range st.Lines {
+		amount, sign := camtSigned(l.Amount, st.Currency)
+		e := camtEntry{
+			Ref:         fmt.Sprint(l.TransactionID),
+			Amount:      amount,
+			Sign:        sign,
+			Status:      "BOOK",
+			BookingDate: l.BookedAt.In(time.Local).Format(time.RFC3339),
+			ValueDate:   l.ValueDate,
+			ServicerRef: fmt.Sprint(l.TransactionID),
+			Code:        string(l.Type),
+		}
+		e.Details.EndToEndID = "NOTPROVIDED"
+		e.Details.Remittance = l.Memo
+		if l.Counterparty != "" {
+			// the counterparty is who paid in, or who was paid
+			if sign == "CRDT" {
+				e.Details.Debtor = &camtParty{Name: l.Counterparty}
+			} else {
+				e.Details.Creditor = &camtParty{Name: l.Counterparty}
+			}
+		}
+		if sign == "CRDT" {
+			credits++
+		} else {
+			debits++
+		}
+		s.Entries = append(s.Entries, e)
+	}
+	s.Total = camtSum{Count: len(st.Lines), Sum: money.Format(st.Credits + st.Debits)}
+	s.Credits = camtSum{Count: credits, Sum: money.Format(st.Credits)}
+	s.Debits = camtSum{Count: debits, Sum: money.Format(st.Debits)}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write camt.053: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func camtBal(code string, balance int64, currency string, day time.Time) camtBalance {
+	amount, sign := camtSigned(balance, currency)
+	return camtBalance{Code: code, Amount: amount, Sign: sign, Date: day.Format(time.DateOnly)}
+}
+
+// camtSigned splits a signed amount into the unsigned amount and the credit
+// or debit indicator camt.053 uses.
+func camtSigned(cents int64, currency string) (camtAmount, string) {
+	if cents < 0 {
+		return camtAmount{Currency: currency, Value: money.Format(-cents)}, "DBIT"
+	}
+	return camtAmount{Currency: currency, Value: money.Format(cents)}, "CRDT"
+}
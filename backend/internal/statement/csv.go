@@ -0,0 +1,34 @@
+package statement
+
+import (
+	"banking-app/backend/pkg/money"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+// writeCSV writes one row per transaction with a header row, which
+// spreadsheets and accounting tools import. Amounts are signed.
+func writeCSV(w io.Writer, st *Statement) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"date", "value_date", "transaction_id", "type", "counterparty", "memo", "amount", "balance", "currency"}}
+	for _, l := range st.Lines {
+		rows = append(rows, []string{
+			l.BookedAt.Format(time.DateOnly),
+			l.ValueDate,
+			fmt.Sprint(l.TransactionID),
+			string(l.Type),
+			l.Counterparty,
+			l.Memo,
+			money.Format(l.Amount),
+			money.Format(l.Balance),
+			st.Currency,
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package statement
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/console"
+	"fmt"
+	"os"
+	"time"
+)
+
+type Handler struct {
+	service *Service
+	con     *console.Console
+}
+
+func NewHandler(service *Service, con *console.Console) *Handler {
+	return &Handler{
+		service: service,
+		con:     con,
+	}
+}
+
+// HandleExport lets a customer export the statement of one of their
+// accounts, shown on screen or saved to a file.
+func (h *Handler) HandleExport(customerID int64) {
+	number, _ := h.con.Prompt("Account number: ")
+	fromStr, _ := h.con.Prompt("From (YYYY-MM-DD, empty for since opening): ")
+	toStr, _ := h.con.Prompt("To (YYYY-MM-DD, empty for today): ")
+	formatStr, _ := h.con.Prompt("Format (text, csv, ofx or camt053) [text]: ")
+
+	acc, err := h.service.accounts.GetAccountByNumber(number)
+	if err != nil || acc.CustomerID != customerID {
+		h.con.Printf("You have no account %s\n", number)
+		return
+	}
+	var from, to time.Time
+	if fromStr != "" {
+		if from, err = time.ParseInLocation(time.DateOnly, fromStr, time.Local); err != nil {
+			h.con.Printf("Invalid date: %s\n", fromStr)
+			return
+		}
+	}
+	if toStr != "" {
+		if to, err = time.ParseInLocation(time.DateOnly, toStr, time.Local); err != nil {
+			h.con.Printf("Invalid date: %s\n", toStr)
+			return
+		}
+	}
+	if formatStr == "" {
+		formatStr = string(FormatText)
+	}
+	format, err := ParseFormat(formatStr)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+
+	st, err := h.service.Build(acc.ID, from, to)
+	if err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+
+	path, _ := h.con.Prompt("Save to file (empty to show it here): ")
+	if path == "" {
+		if err := h.service.Write(h.con, st, format); err != nil {
+			h.con.Printf("Error: %v\n", err)
+		}
+		return
+	}
+
+	if err := h.save(path, st, format); err != nil {
+		h.con.Printf("Error: %v\n", err)
+		return
+	}
+	h.con.Printf("Statement of %s saved to %s\n", accountno.Group(acc.Number), path)
+}
+
+func (h *Handler) save(path string, st *Statement, format Format) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := h.service.Write(f, st, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
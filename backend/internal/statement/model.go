@@ -0,0 +1,91 @@
+package statement
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"time"
+)
+
+// Settings are what every exported statement shares. Currency is the ISO
+// 4217 code of the amounts, which OFX and camt.053 need spelled out.
+type Settings struct {
+	Currency string `json:"currency"`
+}
+
+type Format string
+
+const (
+	FormatText    Format = "text"
+	FormatCSV     Format = "csv"
+	FormatOFX     Format = "ofx"
+	FormatCAMT053 Format = "camt053"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatText, FormatCSV, FormatOFX, FormatCAMT053:
+		return Format(s), nil
+	case "camt.053":
+		return FormatCAMT053, nil
+	}
+	return "", fmt.Errorf("unknown statement format %q (expected %q, %q, %q or %q)", s, FormatText, FormatCSV, FormatOFX, FormatCAMT053)
+}
+
+// Extension is the usual file extension of the format.
+func (f Format) Extension() string {
+	switch f {
+	case FormatCSV:
+		return "csv"
+	case FormatOFX:
+		return "ofx"
+	case FormatCAMT053:
+		return "xml"
+	}
+	return "txt"
+}
+
+// Line is one transaction as the account holder sees it. Amount is negative
+// for money leaving the account, Balance is the balance after it. BookedAt is
+// when it was posted, ValueDate the business day it was posted into.
+type Line struct {
+	TransactionID int64             `json:"transactionId"`
+	BookedAt      time.Time         `json:"bookedAt"`
+	ValueDate     string            `json:"valueDate"`
+	Type          transactions.Type `json:"type"`
+	Counterparty  string            `json:"counterparty"`
+	Memo          string            `json:"memo,omitempty"`
+	Amount        int64             `json:"amount"`
+	Balance       int64             `json:"balance"`
+}
+
+// Pending is money held on the account, such as a card payment not captured
+// yet. It is not booked, so it is in none of the balances, but it cannot be
+// spent.
+type Pending struct {
+	HoldID    int64      `json:"holdId"`
+	PlacedAt  time.Time  `json:"placedAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Reference string     `json:"reference"`
+	Amount    int64      `json:"amount"`
+}
+
+// Statement lists the transactions of an account booked on the days From
+// through To, with the balances before and after them. A statement running
+// to today also lists the holds pending when it was produced.
+type Statement struct {
+	Account   account.Account `json:"account"`
+	BankName  string          `json:"bankName"`
+	Holder    string          `json:"holder"`
+	Currency  string          `json:"currency"`
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Opening   int64           `json:"opening"`
+	Closing   int64           `json:"closing"`
+	Debits    int64           `json:"debits"`
+	Credits   int64           `json:"credits"`
+	Lines     []Line          `json:"lines"`
+	Pending   []Pending       `json:"pending"`
+	Held      int64           `json:"held"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
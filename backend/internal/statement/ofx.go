@@ -0,0 +1,124 @@
+package statement
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ofxTime is the date and time layout of OFX.
+const ofxTime = "20060102150405"
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FITID  string `xml:"FITID"`
+	Name   string `xml:"NAME,omitempty"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	Signon  struct {
+		Status   ofxStatus `xml:"SONRS>STATUS"`
+		Server   string    `xml:"SONRS>DTSERVER"`
+		Language string    `xml:"SONRS>LANGUAGE"`
+	} `xml:"SIGNONMSGSRSV1"`
+	Statement struct {
+		TRNUID    string           `xml:"TRNUID"`
+		Status    ofxStatus        `xml:"STATUS"`
+		Currency  string           `xml:"STMTRS>CURDEF"`
+		BankID    string           `xml:"STMTRS>BANKACCTFROM>BANKID"`
+		AccountID string           `xml:"STMTRS>BANKACCTFROM>ACCTID"`
+		Type      string           `xml:"STMTRS>BANKACCTFROM>ACCTTYPE"`
+		Start     string           `xml:"STMTRS>BANKTRANLIST>DTSTART"`
+		End       string           `xml:"STMTRS>BANKTRANLIST>DTEND"`
+		Lines     []ofxTransaction `xml:"STMTRS>BANKTRANLIST>STMTTRN"`
+		Ledger    ofxBalance       `xml:"STMTRS>LEDGERBAL"`
+	} `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+// writeOFX writes the statement as an OFX 2.2 bank statement response, the
+// format personal finance tools import.
+func writeOFX(w io.Writer, st *Statement) error {
+	var doc ofxDocument
+	doc.Signon.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	doc.Signon.Server = st.CreatedAt.Format(ofxTime)
+	doc.Signon.Language = "ENG"
+
+	s := &doc.Statement
+	s.TRNUID = fmt.Sprintf("%d-%s", st.Account.ID, st.CreatedAt.Format(ofxTime))
+	s.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	s.Currency = st.Currency
+	s.BankID = fmt.Sprintf("%04d", st.Account.BankID)
+	s.AccountID = accountno.Normalize(st.Account.Number)
+	s.Type = "CHECKING"
+	if st.Account.Type == account.TypeSavings {
+		s.Type = "SAVINGS"
+	}
+	s.Start = st.From.Format(ofxTime)
+	s.End = st.To.AddDate(0, 0, 1).Add(-time.Second).Format(ofxTime)
+	s.Lines = []ofxTransaction{}
+	for _, l := range st.Lines {
+		s.Lines = append(s.Lines, ofxTransaction{
+			Type:   ofxType(l),
+			Posted: l.BookedAt.In(time.Local).Format(ofxTime),
+			Amount: money.Format(l.Amount),
+			FITID:  fmt.Sprint(l.TransactionID),
+			Name:   truncate(l.Counterparty, 32),
+			Memo:   l.Memo,
+		})
+	}
+	s.Ledger = ofxBalance{Amount: money.Format(st.Closing), AsOf: s.End}
+
+	if _, err := io.WriteString(w, xml.Header+`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write OFX: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ofxType is the OFX transaction type of a statement line.
+func ofxType(l Line) string {
+	switch l.Type {
+	case transactions.TypeDeposit:
+		return "DEP"
+	case transactions.TypeWithdrawal:
+		return "CASH"
+	case transactions.TypeTransfer:
+		return "XFER"
+	case transactions.TypeFee:
+		return "FEE"
+	case transactions.TypeInterest, transactions.TypeLoanInterest, transactions.TypeOverdraftInterest:
+		return "INT"
+	case transactions.TypeCard:
+		return "POS"
+	case transactions.TypeRepayment:
+		return "PAYMENT"
+	}
+	if l.Amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
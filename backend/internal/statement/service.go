@@ -0,0 +1,168 @@
+package statement
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/internal/transactions"
+	"fmt"
+	"io"
+	"time"
+)
+
+type Service struct {
+	accounts  *account.Service
+	customers *customer.Service
+	banks     *bank.Service
+	txs       *transactions.Service
+	holds     *hold.Service
+	settings  Settings
+}
+
+func NewService(accounts *account.Service, customers *customer.Service, banks *bank.Service, txs *transactions.Service, holds *hold.Service, settings Settings) *Service {
+	return &Service{
+		accounts:  accounts,
+		customers: customers,
+		banks:     banks,
+		txs:       txs,
+		holds:     holds,
+		settings:  settings,
+	}
+}
+
+// Build makes the statement of an account for the days from through to. A
+// zero from starts when the account was opened, a zero to ends today. The
+// balances are rebuilt from the ledger back from the current balance.
+func (s *Service) Build(accountID int64, from, to time.Time) (*Statement, error) {
+	acc, err := s.accounts.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.IsZero() {
+		from = acc.CreatedAt.In(time.Local)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from = truncateDay(from)
+	to = truncateDay(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("the statement period starts after it ends")
+	}
+	end := to.AddDate(0, 0, 1)
+
+	st := &Statement{
+		Account:   *acc,
+		Currency:  s.settings.Currency,
+		From:      from,
+		To:        to,
+		Lines:     []Line{},
+		Pending:   []Pending{},
+		CreatedAt: time.Now(),
+	}
+	if b, err := s.banks.GetBank(acc.BankID); err == nil {
+		st.BankName = b.Name
+	}
+	st.Holder = st.BankName
+	if !acc.Internal() {
+		c, err := s.customers.GetCustomer(acc.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		st.Holder = c.Name
+	}
+
+	// undo everything booked since the start of the period to find the
+	// opening balance
+	st.Opening = acc.Balance
+	txs := s.txs.GetAccountTransactions(acc.ID)
+	for _, tx := range txs {
+		if !tx.CreatedAt.Before(from) {
+			st.Opening -= signed(tx, acc.ID)
+		}
+	}
+
+	balance := st.Opening
+	for _, tx := range txs {
+		if tx.CreatedAt.Before(from) || !tx.CreatedAt.Before(end) {
+			continue
+		}
+		amount := signed(tx, acc.ID)
+		balance += amount
+		if amount < 0 {
+			st.Debits -= amount
+		} else {
+			st.Credits += amount
+		}
+
+		line := Line{
+			TransactionID: tx.Id,
+			BookedAt:      tx.CreatedAt,
+			ValueDate:     tx.BusinessDate,
+			Type:          tx.Type,
+			Counterparty:  tx.Payer,
+			Memo:          tx.Memo,
+			Amount:        amount,
+			Balance:       balance,
+		}
+		if tx.FromAccountID == acc.ID {
+			line.Counterparty = tx.Payee
+		}
+		if line.ValueDate == "" {
+			line.ValueDate = tx.CreatedAt.In(time.Local).Format(time.DateOnly)
+		}
+		st.Lines = append(st.Lines, line)
+	}
+	st.Closing = balance
+
+	// holds are only known as they stand now, so only a statement running
+	// to today shows them
+	if !to.Before(truncateDay(time.Now())) {
+		for _, h := range s.holds.GetAccountHolds(acc.ID, true) {
+			st.Pending = append(st.Pending, Pending{
+				HoldID:    h.ID,
+				PlacedAt:  h.CreatedAt,
+				ExpiresAt: h.ExpiresAt,
+				Reference: h.Reference,
+				Amount:    h.Amount,
+			})
+			st.Held += h.Amount
+		}
+	}
+
+	return st, nil
+}
+
+// Write writes a statement in the given format.
+func (s *Service) Write(w io.Writer, st *Statement, format Format) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, st)
+	case FormatOFX:
+		return writeOFX(w, st)
+	case FormatCAMT053:
+		return writeCAMT053(w, st)
+	}
+	return writeText(w, st)
+}
+
+// signed is the amount of a transaction for the given account, negative
+// when it leaves the account. A transfer between the same account counts
+// as both.
+func signed(tx *transactions.Transaction, accountID int64) int64 {
+	var amount int64
+	if tx.ToAccountID == accountID {
+		amount += tx.Amount
+	}
+	if tx.FromAccountID == accountID {
+		amount -= tx.Amount
+	}
+	return amount
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,92 @@
+package statement
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"banking-app/backend/pkg/money"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// textWidth is the width of the printable layout, which fits a portrait page
+// in a fixed-width font.
+const textWidth = 92
+
+// writeText lays the statement out as plain text for printing.
+func writeText(w io.Writer, st *Statement) error {
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintln(b, centre("STATEMENT OF ACCOUNT"))
+	fmt.Fprintln(b, centre(st.BankName))
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "Account holder:  %s\n", st.Holder)
+	fmt.Fprintf(b, "Account number:  %s (%s)\n", accountno.Group(st.Account.Number), st.Account.Type)
+	fmt.Fprintf(b, "Period:          %s to %s\n", st.From.Format(time.DateOnly), st.To.Format(time.DateOnly))
+	fmt.Fprintf(b, "Currency:        %s\n", st.Currency)
+	fmt.Fprintln(b)
+
+	fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s  %10s  %12s\n", "Date", "Value date", "Description", "Debit", "Credit", "Balance")
+	fmt.Fprintln(b, strings.Repeat("-", textWidth))
+	fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s  %10s  %12s\n", st.From.Format(time.DateOnly), "", "Opening balance", "", "", money.Format(st.Opening))
+	for _, l := range st.Lines {
+		debit, credit := "", money.Format(l.Amount)
+		if l.Amount < 0 {
+			debit, credit = money.Format(-l.Amount), ""
+		}
+		fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s  %10s  %12s\n", l.BookedAt.Format(time.DateOnly), l.ValueDate,
+			truncate(describe(l), 36), debit, credit, money.Format(l.Balance))
+	}
+	fmt.Fprintln(b, strings.Repeat("-", textWidth))
+	fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s  %10s  %12s\n", st.To.Format(time.DateOnly), "", "Closing balance",
+		money.Format(st.Debits), money.Format(st.Credits), money.Format(st.Closing))
+	if len(st.Pending) > 0 {
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "Pending, not yet booked:")
+		fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s\n", "Placed", "Expires", "Reference", "Held")
+		for _, p := range st.Pending {
+			expires := ""
+			if p.ExpiresAt != nil {
+				expires = p.ExpiresAt.In(time.Local).Format(time.DateOnly)
+			}
+			fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s\n", p.PlacedAt.In(time.Local).Format(time.DateOnly), expires,
+				truncate(p.Reference, 36), money.Format(p.Amount))
+		}
+		fmt.Fprintln(b, strings.Repeat("-", textWidth))
+		fmt.Fprintf(b, "%-10s  %-10s  %-36s  %10s  %10s  %12s\n", "", "", "Available balance",
+			money.Format(st.Held), "", money.Format(st.Closing-st.Held))
+	}
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "%d transaction(s). Produced %s.\n", len(st.Lines), st.CreatedAt.Format("2006-01-02 15:04"))
+
+	return b.Flush()
+}
+
+// describe is the one-line description of a transaction, what it was and
+// who with.
+func describe(l Line) string {
+	desc := string(l.Type)
+	if l.Counterparty != "" {
+		desc += " " + l.Counterparty
+	}
+	if l.Memo != "" {
+		desc += ": " + l.Memo
+	}
+	return desc
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func centre(s string) string {
+	if pad := (textWidth - len([]rune(s))) / 2; pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
@@ -1,7 +1,134 @@
 package transactions
 
+import (
+	"banking-app/backend/internal/account"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeDeposit    Type = "deposit"
+	TypeWithdrawal Type = "withdrawal"
+	TypeTransfer   Type = "transfer"
+	TypeFee        Type = "fee"
+	TypeInterest   Type = "interest"
+
+	TypeDisbursement Type = "disbursement"
+	TypeRepayment    Type = "repayment"
+	TypeLoanInterest Type = "loan-interest"
+
+	TypeOverdraftInterest Type = "overdraft-interest"
+
+	// TypeRelease pays a held transaction out of the suspense account to
+	// where it was going, TypeReturn gives the money back to the payer.
+	TypeRelease Type = "release"
+	TypeReturn  Type = "return"
+
+	// TypeCard is a captured card purchase, paid out of the bank to the
+	// merchant.
+	TypeCard Type = "card"
+
+	// TypeCorrection books a balance difference found by a reconciliation
+	// against the bank's suspense account.
+	TypeCorrection Type = "correction"
+)
+
+// Status is set on transactions held for review. Transactions that were
+// never held have no status.
+type Status string
+
+const (
+	StatusHeld     Status = "held"
+	StatusReleased Status = "released"
+	StatusReturned Status = "returned"
+)
+
+// Transaction is one entry in the ledger. FromAccountID is 0 for money coming
+// in from outside the bank (cash deposits), ToAccountID is 0 for money leaving
+// it (cash withdrawals). Amount is in cents.
 type Transaction struct {
-	Id    int64  `json:"id"`
-	Payer string `json:"payer"`
-	Payee string `json:"payee"`
+	Id            int64     `json:"id"`
+	Payer         string    `json:"payer"`
+	Payee         string    `json:"payee"`
+	Type          Type      `json:"type"`
+	FromAccountID int64     `json:"fromAccountId"`
+	ToAccountID   int64     `json:"toAccountId"`
+	Amount        int64     `json:"amount"`
+	Memo          string    `json:"memo,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+
+	// A held transaction pays into the bank's suspense account instead.
+	// HeldFor is the account the money goes to once released, 0 for cash.
+	Status  Status `json:"status,omitempty"`
+	HeldFor int64  `json:"heldFor,omitempty"`
+
+	// BusinessDate is the business day of the bank the transaction was
+	// posted into (YYYY-MM-DD), Adjustment is set when that day had already
+	// been closed.
+	BusinessDate string `json:"businessDate,omitempty"`
+	Adjustment   bool   `json:"adjustment,omitempty"`
+
+	// Reverses is the transaction this one takes back, when a posting of
+	// several transactions failed part way.
+	Reverses int64 `json:"reverses,omitempty"`
+}
+
+// deltas are the balance changes of the transaction, leaving out the cash
+// side of deposits and withdrawals.
+func (tx *Transaction) deltas() map[int64]int64 {
+	deltas := map[int64]int64{}
+	if tx.FromAccountID != 0 {
+		deltas[tx.FromAccountID] -= tx.Amount
+	}
+	if tx.ToAccountID != 0 {
+		deltas[tx.ToAccountID] += tx.Amount
+	}
+	return deltas
+}
+
+// BusinessDays tells which business day each bank is on. Days before a
+// bank's business date have been closed by its end-of-day run, and a bank
+// is frozen while the run is going.
+type BusinessDays interface {
+	BusinessDate(bankID int64) time.Time
+	Closed(bankID int64, day time.Time) bool
+	Frozen(bankID int64) (bool, error)
+}
+
+// A Flag is a monitoring rule that a transaction matched.
+type Flag struct {
+	Rule   string
+	Reason string
+	Hold   bool // keep the money in suspense until the alert is reviewed
+}
+
+// Screener checks transactions against anti-money-laundering rules. Screen
+// runs before a transaction is posted, acc is the customer account it takes
+// money out of or pays into. Report is given the posted transaction and the
+// flags Screen returned.
+type Screener interface {
+	Screen(tx *Transaction, acc *account.Account) []Flag
+	Report(tx *Transaction, acc *account.Account, flags []Flag) error
+}
+
+// Journal books every posted transaction in the general ledger.
+type Journal interface {
+	Post(tx *Transaction) error
+}
+
+// Limits caps single transactions, in cents. Zero means no limit.
+type Limits struct {
+	MaxDeposit    int64 `json:"maxDeposit"`
+	MaxWithdrawal int64 `json:"maxWithdrawal"`
+	MaxTransfer   int64 `json:"maxTransfer"`
+}
+
+// MaintenanceResult is the outcome of the monthly maintenance fee for one
+// account.
+type MaintenanceResult struct {
+	AccountID int64  `json:"accountId"`
+	Fee       int64  `json:"fee"`
+	Charged   bool   `json:"charged"`
+	Reason    string `json:"reason,omitempty"` // why it was not charged
 }
@@ -0,0 +1,133 @@
+package transactions
+
+import (
+	"banking-app/backend/pkg/storage"
+	"fmt"
+	"sync"
+)
+
+type Repository struct {
+	filePath     string
+	mutex        sync.RWMutex
+	nextID       int64
+	transactions []*Transaction // Cache for in-memory operations
+}
+
+func NewRepository(filePath string) (*Repository, error) {
+	repo := &Repository{
+		filePath:     filePath,
+		nextID:       1,
+		transactions: []*Transaction{},
+	}
+
+	if err := storage.LoadCollection(filePath, "transactions", &repo.transactions); err != nil {
+		return nil, err
+	}
+
+	// find the highest ID to set nextID correctly
+	for _, tx := range repo.transactions {
+		if tx.Id >= repo.nextID {
+			repo.nextID = tx.Id + 1
+		}
+	}
+
+	return repo, nil
+}
+
+func (r *Repository) saveData() error {
+	if err := storage.SaveCollection(r.filePath, "transactions", r.transactions); err != nil {
+		return fmt.Errorf("failed to save transaction data: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) Create(tx *Transaction) (*Transaction, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tx.Id = r.nextID
+	r.transactions = append(r.transactions, tx)
+	if err := r.saveData(); err != nil {
+		r.transactions = r.transactions[:len(r.transactions)-1]
+		return nil, err
+	}
+	r.nextID++
+
+	return tx, nil
+}
+
+// Delete takes back a transaction that could not be posted in full.
+func (r *Repository) Delete(id int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, tx := range r.transactions {
+		if tx.Id == id {
+			r.transactions = append(r.transactions[:i], r.transactions[i+1:]...)
+			if err := r.saveData(); err != nil {
+				r.transactions = append(r.transactions[:i], append([]*Transaction{tx}, r.transactions[i:]...)...)
+				return err
+			}
+			if id == r.nextID-1 {
+				r.nextID--
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("transaction with ID %d not found", id)
+}
+
+func (r *Repository) SetStatus(id int64, status Status) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, tx := range r.transactions {
+		if tx.Id == id {
+			tx.Status = status
+			return r.saveData()
+		}
+	}
+
+	return fmt.Errorf("transaction with ID %d not found", id)
+}
+
+func (r *Repository) GetByID(id int64) (*Transaction, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, tx := range r.transactions {
+		if tx.Id == id {
+			return tx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction with ID %d not found", id)
+}
+
+// GetByAccountID returns every transaction that moved money in or out of the
+// account, oldest first.
+func (r *Repository) GetByAccountID(accountID int64) []*Transaction {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	txs := []*Transaction{}
+	for _, tx := range r.transactions {
+		if tx.FromAccountID == accountID || tx.ToAccountID == accountID {
+			txs = append(txs, tx)
+		}
+	}
+
+	return txs
+}
+
+func (r *Repository) GetAll() []*Transaction {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	// Return a copy to avoid external modification
+	txs := make([]*Transaction, len(r.transactions))
+	copy(txs, r.transactions)
+
+	return txs
+}
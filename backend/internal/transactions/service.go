@@ -0,0 +1,1012 @@
+package transactions
+
+import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/fee"
+	"banking-app/backend/internal/limit"
+	"banking-app/backend/internal/notification"
+	"banking-app/backend/pkg/money"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Service struct {
+	repo          *Repository
+	accounts      *account.Repository
+	limits        Limits
+	fees          *fee.Service
+	notifications *notification.Service
+
+	// customers must be verified to deposit, withdraw or transfer out.
+	customers *customer.Service
+
+	// customerLimits are the per-bank and per-customer caps on outflow, on
+	// top of the single-transaction limits every account has.
+	customerLimits *limit.Service
+	screener       Screener
+	journal        Journal
+
+	// postings go into the business date of the bank, or into day when
+	// the service was made with On
+	days       BusinessDays
+	day        *time.Time
+	adjustment bool
+
+	// endOfDay is set on the service the end-of-day jobs post through, the
+	// only one that can post into a bank while its day is frozen
+	endOfDay bool
+}
+
+// ErrDayClosed is returned, wrapped with the day, when posting into a
+// business day that has been closed without the adjustment flag.
+var ErrDayClosed = errors.New("business day is closed")
+
+// ErrDayFrozen is returned, wrapped with the bank, when posting into a bank
+// while its end of day is running.
+var ErrDayFrozen = errors.New("end of day is running")
+
+func NewService(repo *Repository, accounts *account.Repository, customers *customer.Service, limits Limits, fees *fee.Service, notifications *notification.Service, customerLimits *limit.Service, screener Screener, journal Journal, days BusinessDays) *Service {
+	return &Service{
+		repo:           repo,
+		accounts:       accounts,
+		limits:         limits,
+		fees:           fees,
+		notifications:  notifications,
+		customers:      customers,
+		customerLimits: customerLimits,
+		screener:       screener,
+		journal:        journal,
+		days:           days,
+	}
+}
+
+// On returns the service posting into an earlier business day of a bank,
+// e.g. for a correction. A day that has been closed needs adjustment set,
+// the postings are then marked as adjustments.
+func (s *Service) On(bankID int64, day time.Time, adjustment bool) (*Service, error) {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	if current := s.days.BusinessDate(bankID); day.After(current) {
+		return nil, fmt.Errorf("cannot post into %s, the business date of bank %d is %s", day.Format(time.DateOnly), bankID, current.Format(time.DateOnly))
+	}
+	if err := s.checkOpen(bankID, day, adjustment); err != nil {
+		return nil, err
+	}
+
+	dated := *s
+	dated.day = &day
+	dated.adjustment = adjustment
+	return &dated, nil
+}
+
+// EndOfDay returns the service the end-of-day jobs post through. It posts
+// like s, but also into a bank whose day is frozen for closing.
+func (s *Service) EndOfDay() *Service {
+	eod := *s
+	eod.endOfDay = true
+	return &eod
+}
+
+func (s *Service) Deposit(accountID, amount int64, memo string) (*Transaction, error) {
+	if err := checkAmount(amount, s.limits.MaxDeposit); err != nil {
+		return nil, err
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Internal() {
+		return nil, errInternalAccount
+	}
+	if err := s.customers.CheckVerified(acc.CustomerID); err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Payer:       "cash",
+		Payee:       accountLabel(accountID),
+		Type:        TypeDeposit,
+		ToAccountID: accountID,
+		Amount:      amount,
+		Memo:        memo,
+	}
+	flags, err := s.screen(tx, acc)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deposit: %w", err)
+	}
+	if err := s.report(tx, acc, flags); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// QuoteWithdrawal returns the fees Withdraw would charge, including the
+// overdraft fee if it overdraws the account, so they can be shown before the
+// customer confirms.
+func (s *Service) QuoteWithdrawal(accountID, amount int64) (int64, error) {
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return 0, err
+	}
+	charge := s.fees.Quote(acc.BankID, fee.TypeWithdrawal, amount)
+	return charge + s.overdraftFee(acc, amount+charge), nil
+}
+
+func (s *Service) Withdraw(accountID, amount int64, memo string) (*Transaction, error) {
+	if err := checkAmount(amount, s.limits.MaxWithdrawal); err != nil {
+		return nil, err
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Internal() {
+		return nil, errInternalAccount
+	}
+	if err := s.customers.CheckVerified(acc.CustomerID); err != nil {
+		return nil, err
+	}
+	if err := s.checkLimits(acc, amount, false); err != nil {
+		return nil, err
+	}
+
+	charge := s.fees.Quote(acc.BankID, fee.TypeWithdrawal, amount)
+	overdraft := s.overdraftFee(acc, amount+charge)
+	if err := checkFunds(acc, amount+charge+overdraft); err != nil {
+		return nil, err
+	}
+
+	incomeID, err := s.incomeAccount(acc.BankID, charge+overdraft)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Payer:         accountLabel(accountID),
+		Payee:         "cash",
+		Type:          TypeWithdrawal,
+		FromAccountID: accountID,
+		Amount:        amount,
+		Memo:          memo,
+	}
+	flags, err := s.screen(tx, acc)
+	if err != nil {
+		return nil, err
+	}
+
+	before := acc.Balance
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw: %w", err)
+	}
+	if err := s.report(tx, acc, flags); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.chargeFee(accountID, incomeID, charge, fmt.Sprintf("withdrawal fee for transaction %d", tx.Id)); err != nil {
+		return tx, err
+	}
+	if _, err := s.chargeFee(accountID, incomeID, overdraft, fmt.Sprintf("overdraft fee for transaction %d", tx.Id)); err != nil {
+		return tx, err
+	}
+
+	return tx, s.notifyOverdrawn(accountID, before)
+}
+
+// QuoteTransfer returns the fees Transfer would charge the payer, including
+// the overdraft fee if it overdraws the account, so they can be shown before
+// the customer confirms.
+func (s *Service) QuoteTransfer(fromID, amount int64) (int64, error) {
+	from, err := s.accounts.GetByID(fromID)
+	if err != nil {
+		return 0, err
+	}
+	charge := s.fees.Quote(from.BankID, fee.TypeTransfer, amount)
+	return charge + s.overdraftFee(from, amount+charge), nil
+}
+
+func (s *Service) Transfer(fromID, toID, amount int64, memo string) (*Transaction, error) {
+	return s.transfer(fromID, toID, amount, memo, accountLabel(toID))
+}
+
+// TransferToPayee is Transfer to a customer's saved payee, recorded under the
+// payee's name.
+func (s *Service) TransferToPayee(fromID, toID, amount int64, payee, memo string) (*Transaction, error) {
+	return s.transfer(fromID, toID, amount, memo, payee)
+}
+
+func (s *Service) transfer(fromID, toID, amount int64, memo, payee string) (*Transaction, error) {
+	if err := checkAmount(amount, s.limits.MaxTransfer); err != nil {
+		return nil, err
+	}
+	if fromID == toID {
+		return nil, fmt.Errorf("cannot transfer to the same account")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	from, err := s.accounts.GetByID(fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.accounts.GetByID(toID)
+	if err != nil {
+		return nil, err
+	}
+	if from.Internal() || to.Internal() {
+		return nil, errInternalAccount
+	}
+	if err := s.customers.CheckVerified(from.CustomerID); err != nil {
+		return nil, err
+	}
+	if err := s.checkLimits(from, amount, true); err != nil {
+		return nil, err
+	}
+
+	// the payer's bank charges the fee
+	charge := s.fees.Quote(from.BankID, fee.TypeTransfer, amount)
+	overdraft := s.overdraftFee(from, amount+charge)
+	if err := checkFunds(from, amount+charge+overdraft); err != nil {
+		return nil, err
+	}
+
+	incomeID, err := s.incomeAccount(from.BankID, charge+overdraft)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Payer:         accountLabel(fromID),
+		Payee:         payee,
+		Type:          TypeTransfer,
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount,
+		Memo:          memo,
+	}
+	flags, err := s.screen(tx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	before := from.Balance
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer: %w", err)
+	}
+	if err := s.report(tx, from, flags); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.chargeFee(fromID, incomeID, charge, fmt.Sprintf("transfer fee for transaction %d", tx.Id)); err != nil {
+		return tx, err
+	}
+	if _, err := s.chargeFee(fromID, incomeID, overdraft, fmt.Sprintf("overdraft fee for transaction %d", tx.Id)); err != nil {
+		return tx, err
+	}
+
+	return tx, s.notifyOverdrawn(fromID, before)
+}
+
+// ChargeMaintenance bills every customer account the monthly maintenance fee
+// of its bank for the given month (YYYY-MM). Accounts already billed for the
+// month are skipped, so it is safe to run again. With a bankID only that
+// bank's accounts are billed, 0 bills them all.
+func (s *Service) ChargeMaintenance(bankID int64, month string) ([]MaintenanceResult, error) {
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return nil, fmt.Errorf("invalid month %q, expected YYYY-MM", month)
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	var results []MaintenanceResult
+	for _, acc := range s.accounts.GetAll() {
+		if acc.Internal() || (bankID != 0 && acc.BankID != bankID) {
+			continue
+		}
+
+		result := MaintenanceResult{AccountID: acc.ID, Fee: s.fees.Quote(acc.BankID, fee.TypeMaintenance, acc.Balance)}
+		switch {
+		case result.Fee == 0:
+			result.Reason = "no maintenance fee"
+		case s.fees.MaintenanceCharged(acc.ID, month):
+			result.Reason = "already charged"
+		case acc.Available() < result.Fee:
+			result.Reason = "insufficient funds"
+		}
+		if result.Reason != "" {
+			results = append(results, result)
+			continue
+		}
+
+		incomeID, err := s.incomeAccount(acc.BankID, result.Fee)
+		if err != nil {
+			return results, err
+		}
+		before := acc.Balance
+		if _, err := s.chargeFee(acc.ID, incomeID, result.Fee, "maintenance fee for "+month); err != nil {
+			return results, fmt.Errorf("failed to charge maintenance fee: %w", err)
+		}
+		if err := s.fees.RecordMaintenanceCharge(acc.ID, month); err != nil {
+			return results, err
+		}
+		if err := s.notifyOverdrawn(acc.ID, before); err != nil {
+			return results, err
+		}
+
+		result.Charged = true
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// PostInterest credits capitalised interest to an account. at is the day the
+// interest belongs to, which is in the past when missed days are backfilled.
+func (s *Service) PostInterest(accountID, amount int64, at time.Time, memo string) (*Transaction, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Transaction{
+		Payer:       "bank interest",
+		Payee:       accountLabel(accountID),
+		Type:        TypeInterest,
+		ToAccountID: accountID,
+		Amount:      amount,
+		Memo:        memo,
+		CreatedAt:   at,
+	}
+	if err := s.dateAt(tx, acc.BankID, at); err != nil {
+		return nil, err
+	}
+
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post interest: %w", err)
+	}
+
+	return tx, nil
+}
+
+// CardPurchase posts a captured card payment to a merchant. The funds were
+// reserved by a hold when the purchase was authorised, so the purchase is not
+// checked against the balance again and the caller settles the hold after.
+func (s *Service) CardPurchase(accountID, amount int64, merchant, memo string) (*Transaction, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Internal() {
+		return nil, errInternalAccount
+	}
+
+	before := acc.Balance
+	tx := &Transaction{
+		Payer:         accountLabel(accountID),
+		Payee:         merchant,
+		Type:          TypeCard,
+		FromAccountID: accountID,
+		Amount:        amount,
+		Memo:          memo,
+	}
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post card purchase: %w", err)
+	}
+
+	return tx, s.notifyOverdrawn(accountID, before)
+}
+
+// Disburse pays a loan out of the bank's loan account into the account of a
+// verified customer.
+func (s *Service) Disburse(accountID, amount int64, memo string) (*Transaction, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Internal() {
+		return nil, errInternalAccount
+	}
+	if err := s.customers.CheckVerified(acc.CustomerID); err != nil {
+		return nil, err
+	}
+
+	loans, err := s.accounts.GetOrCreateInternal(acc.BankID, account.TypeLoan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open loan account: %w", err)
+	}
+
+	tx, err := s.post(&Transaction{
+		Payer:         "bank loan",
+		Payee:         accountLabel(accountID),
+		Type:          TypeDisbursement,
+		FromAccountID: loans.ID,
+		ToAccountID:   accountID,
+		Amount:        amount,
+		Memo:          memo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to disburse loan: %w", err)
+	}
+	return tx, nil
+}
+
+// Correct books the difference between the stored balance of an account and
+// what its transactions add up to, as found by a reconciliation, so that
+// they agree again. The account's balance already holds the difference, so
+// it is posted as an adjustment with the bank's suspense account and only
+// the suspense balance moves; there the difference waits to be looked
+// into. A difference of the suspense account itself is booked against
+// cash. Difference is the stored balance minus the transactions.
+func (s *Service) Correct(accountID, difference int64, memo string) (*Transaction, error) {
+	if difference == 0 {
+		return nil, fmt.Errorf("there is no difference to correct")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var otherID int64
+	other := "cash"
+	if acc.Type != account.TypeSuspense {
+		suspense, err := s.accounts.GetOrCreateInternal(acc.BankID, account.TypeSuspense)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open suspense account: %w", err)
+		}
+		otherID, other = suspense.ID, accountLabel(suspense.ID)
+	}
+
+	tx := &Transaction{
+		Payer:         other,
+		Payee:         accountLabel(accountID),
+		Type:          TypeCorrection,
+		FromAccountID: otherID,
+		ToAccountID:   accountID,
+		Amount:        difference,
+		Memo:          memo,
+		Adjustment:    true,
+	}
+	if difference < 0 {
+		tx.Payer, tx.Payee = tx.Payee, tx.Payer
+		tx.FromAccountID, tx.ToAccountID = tx.ToAccountID, tx.FromAccountID
+		tx.Amount = -difference
+	}
+
+	deltas := tx.deltas()
+	delete(deltas, accountID)
+	if err := s.accounts.Adjust(deltas); err != nil {
+		return nil, fmt.Errorf("failed to correct account %d: %w", accountID, err)
+	}
+	recorded, err := s.record(tx)
+	if err != nil {
+		for id := range deltas {
+			deltas[id] = -deltas[id]
+		}
+		if uerr := s.accounts.Adjust(deltas); uerr != nil {
+			return nil, fmt.Errorf("%w; failed to undo the balance change: %v", err, uerr)
+		}
+		return nil, fmt.Errorf("failed to correct account %d: %w", accountID, err)
+	}
+	return recorded, nil
+}
+
+// Repay collects a loan installment from the customer's account. The
+// principal goes back to the bank's loan account, the interest and any
+// late-payment penalty to its income account, each as its own transaction.
+// If one of them cannot be posted the ones before it are reversed, so the
+// installment is either collected whole or not at all.
+func (s *Service) Repay(accountID, principal, interest, penalty int64, memo string) error {
+	if principal < 0 || interest < 0 || penalty < 0 {
+		return fmt.Errorf("amounts cannot be negative")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return err
+	}
+	if acc.Internal() {
+		return errInternalAccount
+	}
+	if err := checkFunds(acc, principal+interest+penalty); err != nil {
+		return err
+	}
+
+	loans, err := s.accounts.GetOrCreateInternal(acc.BankID, account.TypeLoan)
+	if err != nil {
+		return fmt.Errorf("failed to open loan account: %w", err)
+	}
+	incomeID, err := s.incomeAccount(acc.BankID, interest+penalty)
+	if err != nil {
+		return err
+	}
+
+	legs := []*Transaction{{
+		Payer:         accountLabel(accountID),
+		Payee:         "bank loan",
+		Type:          TypeRepayment,
+		FromAccountID: accountID,
+		ToAccountID:   loans.ID,
+		Amount:        principal,
+		Memo:          memo,
+	}, {
+		Payer:         accountLabel(accountID),
+		Payee:         "bank loan interest",
+		Type:          TypeLoanInterest,
+		FromAccountID: accountID,
+		ToAccountID:   incomeID,
+		Amount:        interest,
+		Memo:          memo,
+	}, {
+		Payer:         accountLabel(accountID),
+		Payee:         "bank fee",
+		Type:          TypeFee,
+		FromAccountID: accountID,
+		ToAccountID:   incomeID,
+		Amount:        penalty,
+		Memo:          "late-payment penalty, " + memo,
+	}}
+
+	before := acc.Balance
+	var posted []*Transaction
+	for _, leg := range legs {
+		if leg.Amount == 0 {
+			continue
+		}
+		tx, err := s.post(leg)
+		if err != nil {
+			return s.reverse(posted, fmt.Errorf("failed to collect repayment: %w", err))
+		}
+		posted = append(posted, tx)
+	}
+
+	return s.notifyOverdrawn(accountID, before)
+}
+
+// reverse takes back the transactions already posted of a posting that
+// failed with err, newest first. Each is reversed by a transaction of the
+// same type the other way round, so it books against the same general
+// ledger accounts. The returned error is err, with any reversal that failed.
+func (s *Service) reverse(posted []*Transaction, err error) error {
+	for i := len(posted) - 1; i >= 0; i-- {
+		if _, rerr := s.reverseOne(posted[i]); rerr != nil {
+			err = fmt.Errorf("%w; failed to reverse transaction %d: %v", err, posted[i].Id, rerr)
+		}
+	}
+	return err
+}
+
+func (s *Service) reverseOne(tx *Transaction) (*Transaction, error) {
+	return s.post(&Transaction{
+		Payer:         tx.Payee,
+		Payee:         tx.Payer,
+		Type:          tx.Type,
+		FromAccountID: tx.ToAccountID,
+		ToAccountID:   tx.FromAccountID,
+		Amount:        tx.Amount,
+		Memo:          fmt.Sprintf("reversal of transaction %d", tx.Id),
+		Reverses:      tx.Id,
+	})
+}
+
+// ChargeOverdraftInterest debits capitalised overdraft interest to the bank's
+// income account. Like PostInterest, at is the day the interest belongs to.
+// It is charged even when it takes the account past its overdraft limit.
+func (s *Service) ChargeOverdraftInterest(accountID, amount int64, at time.Time, memo string) (*Transaction, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	incomeID, err := s.incomeAccount(acc.BankID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Payer:         accountLabel(accountID),
+		Payee:         "bank overdraft interest",
+		Type:          TypeOverdraftInterest,
+		FromAccountID: accountID,
+		ToAccountID:   incomeID,
+		Amount:        amount,
+		Memo:          memo,
+		CreatedAt:     at,
+	}
+	if err := s.dateAt(tx, acc.BankID, at); err != nil {
+		return nil, err
+	}
+
+	before := acc.Balance
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to charge overdraft interest: %w", err)
+	}
+
+	return tx, s.notifyOverdrawn(accountID, before)
+}
+
+func (s *Service) GetAccountTransactions(accountID int64) []*Transaction {
+	return s.repo.GetByAccountID(accountID)
+}
+
+func (s *Service) GetAllTransactions() []*Transaction {
+	return s.repo.GetAll()
+}
+
+// post moves the balances of a transaction and records it. The balances are
+// moved back if it cannot be recorded, so they never change without a ledger
+// and general ledger entry. Callers hold LockPostings from checking the
+// funds until the posting is done.
+func (s *Service) post(tx *Transaction) (*Transaction, error) {
+	deltas := tx.deltas()
+	if err := s.accounts.Adjust(deltas); err != nil {
+		return nil, err
+	}
+
+	recorded, err := s.record(tx)
+	if err != nil {
+		for id := range deltas {
+			deltas[id] = -deltas[id]
+		}
+		if uerr := s.accounts.Adjust(deltas); uerr != nil {
+			return nil, fmt.Errorf("%w; failed to undo the balance change: %v", err, uerr)
+		}
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// record stores a transaction in the ledger and journals it in the general
+// ledger of the banks it touches, or neither. Transactions not dated by the
+// caller are dated now.
+func (s *Service) record(tx *Transaction) (*Transaction, error) {
+	if tx.CreatedAt.IsZero() {
+		tx.CreatedAt = time.Now()
+	}
+	if err := s.date(tx); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repo.Create(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record transaction: %w", err)
+	}
+	if err := s.journal.Post(tx); err != nil {
+		err = fmt.Errorf("failed to journal transaction %d: %w", tx.Id, err)
+		if derr := s.repo.Delete(tx.Id); derr != nil {
+			return nil, fmt.Errorf("%w; failed to take the transaction back: %v", err, derr)
+		}
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// date sets the business day a transaction is posted into, the day of the
+// bank of the account it takes money from (or pays into, for money from
+// outside the bank). It is refused while either bank is frozen.
+func (s *Service) date(tx *Transaction) error {
+	var bankID int64
+	for _, id := range []int64{tx.ToAccountID, tx.FromAccountID} {
+		if id == 0 {
+			continue
+		}
+		acc, err := s.accounts.GetByID(id)
+		if err != nil {
+			continue
+		}
+		if err := s.checkFrozen(acc.BankID); err != nil {
+			return err
+		}
+		bankID = acc.BankID
+	}
+
+	switch {
+	case tx.BusinessDate != "" || bankID == 0:
+	case s.day != nil:
+		tx.BusinessDate = s.day.Format(time.DateOnly)
+		tx.Adjustment = s.adjustment
+	default:
+		tx.BusinessDate = s.days.BusinessDate(bankID).Format(time.DateOnly)
+	}
+	return nil
+}
+
+// checkOpen refuses posting into a closed business day unless it is an
+// adjustment.
+func (s *Service) checkOpen(bankID int64, day time.Time, adjustment bool) error {
+	if s.days.Closed(bankID, day) && !adjustment {
+		return fmt.Errorf("%w: %s has been closed for bank %d, post it as an adjustment", ErrDayClosed, day.Format(time.DateOnly), bankID)
+	}
+	return s.checkFrozen(bankID)
+}
+
+// checkFrozen refuses posting into a bank while its end of day is running,
+// except for the end-of-day jobs themselves.
+func (s *Service) checkFrozen(bankID int64) error {
+	if s.endOfDay {
+		return nil
+	}
+	frozen, err := s.days.Frozen(bankID)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		return fmt.Errorf("%w for bank %d, try again when it is done", ErrDayFrozen, bankID)
+	}
+	return nil
+}
+
+// dateAt sets the business day of a transaction dated by the caller, such
+// as interest belonging to a past day. A closed day is refused unless the
+// service was made with the adjustment flag.
+func (s *Service) dateAt(tx *Transaction, bankID int64, at time.Time) error {
+	if err := s.checkOpen(bankID, at, s.adjustment); err != nil {
+		return err
+	}
+	tx.BusinessDate = at.Format(time.DateOnly)
+	tx.Adjustment = s.days.Closed(bankID, at)
+	return nil
+}
+
+// screen runs the anti-money-laundering rules on a transaction about to be
+// posted. If a rule it matches holds it, the transaction is changed to pay
+// into the bank's suspense account.
+func (s *Service) screen(tx *Transaction, acc *account.Account) ([]Flag, error) {
+	flags := s.screener.Screen(tx, acc)
+	for _, f := range flags {
+		if !f.Hold {
+			continue
+		}
+		suspense, err := s.accounts.GetOrCreateInternal(acc.BankID, account.TypeSuspense)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open suspense account: %w", err)
+		}
+		tx.Status = StatusHeld
+		tx.HeldFor = tx.ToAccountID
+		tx.ToAccountID = suspense.ID
+		break
+	}
+	return flags, nil
+}
+
+// report raises the alerts of a posted transaction. If they cannot be
+// raised the transaction is reversed, a held one would otherwise wait in
+// suspense with no alert for anyone to review.
+func (s *Service) report(tx *Transaction, acc *account.Account, flags []Flag) error {
+	err := s.screener.Report(tx, acc, flags)
+	if err == nil {
+		return nil
+	}
+	if _, rerr := s.reverseOne(tx); rerr != nil {
+		return fmt.Errorf("%w; failed to reverse transaction %d: %v", err, tx.Id, rerr)
+	}
+	if tx.Status == StatusHeld {
+		if serr := s.repo.SetStatus(tx.Id, StatusReturned); serr != nil {
+			return fmt.Errorf("%w; failed to update transaction %d: %v", err, tx.Id, serr)
+		}
+	}
+	return err
+}
+
+// Release pays a held transaction out of the suspense account to where it
+// was going.
+func (s *Service) Release(id int64, memo string) (*Transaction, error) {
+	return s.settle(id, StatusReleased, memo)
+}
+
+// Return gives the money of a held transaction back to the payer, as cash
+// for a cash deposit. Fees it was charged are not refunded.
+func (s *Service) Return(id int64, memo string) (*Transaction, error) {
+	return s.settle(id, StatusReturned, memo)
+}
+
+func (s *Service) settle(id int64, status Status, memo string) (*Transaction, error) {
+	unlock := s.accounts.LockPostings()
+	defer unlock()
+
+	held, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if held.Status != StatusHeld {
+		return nil, fmt.Errorf("transaction %d is not held", id)
+	}
+
+	tx := &Transaction{
+		Payer:         "suspense",
+		Payee:         held.Payee,
+		Type:          TypeRelease,
+		FromAccountID: held.ToAccountID,
+		ToAccountID:   held.HeldFor,
+		Amount:        held.Amount,
+		Memo:          memo,
+	}
+	if status == StatusReturned {
+		tx.Payee = held.Payer
+		tx.Type = TypeReturn
+		tx.ToAccountID = held.FromAccountID
+	}
+
+	tx, err = s.post(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle transaction %d: %w", id, err)
+	}
+	if err := s.repo.SetStatus(id, status); err != nil {
+		return tx, fmt.Errorf("failed to update transaction %d: %w", id, err)
+	}
+
+	return tx, nil
+}
+
+// incomeAccount returns the ID of the bank's income account that fees are
+// paid into, or 0 when there is no fee to pay.
+func (s *Service) incomeAccount(bankID, charge int64) (int64, error) {
+	if charge == 0 {
+		return 0, nil
+	}
+
+	income, err := s.accounts.GetOrCreateInternal(bankID, account.TypeIncome)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open income account: %w", err)
+	}
+
+	return income.ID, nil
+}
+
+// chargeFee posts a fee as its own transaction from the customer's account
+// to the bank's income account.
+func (s *Service) chargeFee(accountID, incomeID, amount int64, memo string) (*Transaction, error) {
+	if amount == 0 {
+		return nil, nil
+	}
+
+	return s.post(&Transaction{
+		Payer:         accountLabel(accountID),
+		Payee:         "bank fee",
+		Type:          TypeFee,
+		FromAccountID: accountID,
+		ToAccountID:   incomeID,
+		Amount:        amount,
+		Memo:          memo,
+	})
+}
+
+var errInternalAccount = fmt.Errorf("internal bank accounts cannot be used directly")
+
+// checkLimits applies the account holder's limits to taking amount out of
+// one of their accounts.
+func (s *Service) checkLimits(acc *account.Account, amount int64, transfer bool) error {
+	now := time.Now()
+	return s.customerLimits.Check(acc.BankID, acc.CustomerID, amount, transfer, s.Usage(acc.CustomerID, now), now)
+}
+
+// Usage adds up what a customer has withdrawn and transferred out of all of
+// their accounts today and this month, and counts their transfers of the
+// last hour. Fees are not counted.
+func (s *Service) Usage(customerID int64, now time.Time) limit.Usage {
+	var usage limit.Usage
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	hourAgo := now.Add(-time.Hour)
+
+	for _, acc := range s.accounts.GetByCustomerID(customerID) {
+		for _, tx := range s.repo.GetByAccountID(acc.ID) {
+			if tx.FromAccountID != acc.ID || (tx.Type != TypeWithdrawal && tx.Type != TypeTransfer) {
+				continue
+			}
+			if !tx.CreatedAt.Before(month) {
+				usage.ThisMonth += tx.Amount
+			}
+			if !tx.CreatedAt.Before(today) {
+				usage.Today += tx.Amount
+			}
+			if tx.Type == TypeTransfer && tx.CreatedAt.After(hourAgo) {
+				usage.TransfersLastHour++
+			}
+		}
+	}
+
+	return usage
+}
+
+// ErrInsufficientFunds is returned, wrapped with the account and what it has
+// available, when a debit would take an account past its overdraft limit.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+func checkFunds(acc *account.Account, amount int64) error {
+	if acc.Available() < amount {
+		return fmt.Errorf("%w in account %d: %s available", ErrInsufficientFunds, acc.ID, money.Format(acc.Available()))
+	}
+	return nil
+}
+
+// overdraftFee is the bank's overdraft fee when debiting amount takes an
+// account from a positive balance to a negative one, and 0 otherwise.
+func (s *Service) overdraftFee(acc *account.Account, amount int64) int64 {
+	if acc.Balance < 0 || acc.Balance >= amount {
+		return 0
+	}
+	return s.fees.Quote(acc.BankID, fee.TypeOverdraft, amount-acc.Balance)
+}
+
+// notifyOverdrawn tells the customer when a debit has taken their account
+// from a balance of before to below zero.
+func (s *Service) notifyOverdrawn(accountID, before int64) error {
+	acc, err := s.accounts.GetByID(accountID)
+	if err != nil || before < 0 || acc.Balance >= 0 {
+		return err
+	}
+
+	msg := fmt.Sprintf("Account %d is overdrawn, the balance is %s (overdraft limit %s)",
+		acc.ID, money.Format(acc.Balance), money.Format(acc.OverdraftLimit))
+	return s.notifications.Notify(acc.CustomerID, acc.ID, msg)
+}
+
+func checkAmount(amount, max int64) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if max > 0 && amount > max {
+		return fmt.Errorf("amount %s exceeds the limit of %s", money.Format(amount), money.Format(max))
+	}
+	return nil
+}
+
+func accountLabel(id int64) string {
+	return fmt.Sprintf("account %d", id)
+}
@@ -0,0 +1,84 @@
+package transactions
+
+import (
+	"banking-app/backend/internal/account"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openDays is a bank that is always on today, never closed or frozen.
+type openDays struct{}
+
+func (openDays) BusinessDate(int64) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+func (openDays) Closed(int64, time.Time) bool { return false }
+func (openDays) Frozen(int64) (bool, error)   { return false, nil }
+
+// failingJournal fails to book the fail-th transaction it is given.
+type failingJournal struct {
+	posts int
+	fail  int
+}
+
+func (j *failingJournal) Post(tx *Transaction) error {
+	j.posts++
+	if j.posts == j.fail {
+		return errors.New("journal is full")
+	}
+	return nil
+}
+
+func TestRepayReversesLegsWhenOneFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.json")
+	repo, err := NewRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts, err := account.NewRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := accounts.Create(1, 1, account.TypeChecking, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := accounts.Adjust(map[int64]int64{acc.ID: 100000}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the principal is booked, the interest is not
+	journal := &failingJournal{fail: 2}
+	s := NewService(repo, accounts, nil, Limits{}, nil, nil, nil, nil, journal, openDays{})
+
+	err = s.Repay(acc.ID, 9000, 500, 0, "loan 1 installment 1")
+	if err == nil || !strings.Contains(err.Error(), "journal is full") {
+		t.Fatalf("Repay = %v, want the failed interest", err)
+	}
+
+	for _, a := range accounts.GetAll() {
+		want := int64(0)
+		if a.ID == acc.ID {
+			want = 100000
+		}
+		if a.Balance != want {
+			t.Errorf("%s account %d balance = %d, want %d", a.Type, a.ID, a.Balance, want)
+		}
+	}
+
+	txs := repo.GetAll()
+	if len(txs) != 2 {
+		t.Fatalf("got %d transactions, want the principal and its reversal", len(txs))
+	}
+	principal, reversal := txs[0], txs[1]
+	if principal.Type != TypeRepayment || principal.Amount != 9000 {
+		t.Errorf("first transaction = %+v, want the principal", principal)
+	}
+	if reversal.Reverses != principal.Id || reversal.FromAccountID != principal.ToAccountID || reversal.ToAccountID != acc.ID {
+		t.Errorf("second transaction = %+v, want the reversal of %d", reversal, principal.Id)
+	}
+}
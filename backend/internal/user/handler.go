@@ -1,34 +1,28 @@
 package user
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"banking-app/backend/pkg/console"
 	"strings"
 )
 
 type Handler struct {
 	service *Service
+	con     *console.Console
 }
 
-func NewHandler(s *Service) *Handler {
-	return &Handler{service: s}
+func NewHandler(s *Service, con *console.Console) *Handler {
+	return &Handler{service: s, con: con}
 }
 
 func (h *Handler) Register() {
-	scanner := bufio.NewReader(os.Stdin)
-
-	fmt.Print("Enter username: ")
-	username, _ := scanner.ReadString('\n')
-	username = strings.TrimSpace(username)
-
-	fmt.Print("Enter password: ")
-	password, _ := scanner.ReadString('\n')
-	password = strings.TrimSpace(password)
-
-	fmt.Print("Enter role [bank/customer]: ")
-	roleStr, _ := scanner.ReadString('\n')
-	roleStr = strings.ToLower(strings.TrimSpace(roleStr))
+	username, _ := h.con.Prompt("Enter username: ")
+	password, err := h.con.PromptNewSecret("Enter password: ", "Confirm password: ")
+	if err != nil {
+		h.con.Println("Error:", err)
+		return
+	}
+	roleStr, _ := h.con.Prompt("Enter role [bank/customer]: ")
+	roleStr = strings.ToLower(roleStr)
 
 	var role Role
 	if roleStr == "bank" {
@@ -39,29 +33,22 @@ func (h *Handler) Register() {
 
 	user, err := h.service.Register(username, password, role)
 	if err != nil {
-		fmt.Println("Error:", err)
+		h.con.Println("Error:", err)
 		return
 	}
 
-	fmt.Println("User registered:", user.Username, "with role:", user.Role)
+	h.con.Println("User registered:", user.Username, "with role:", user.Role)
 }
 
 func (h *Handler) Login() *User {
-	scanner := bufio.NewReader(os.Stdin)
-
-	fmt.Print("Enter username: ")
-	username, _ := scanner.ReadString('\n')
-	username = strings.TrimSpace(username)
-
-	fmt.Print("Enter password: ")
-	password, _ := scanner.ReadString('\n')
-	password = strings.TrimSpace(password)
+	username, _ := h.con.Prompt("Enter username: ")
+	password, _ := h.con.PromptSecret("Enter password: ")
 
 	user, err := h.service.Login(username, password)
 	if err != nil {
 		return nil
 	}
 
-	fmt.Println("Welcome", user.Username)
+	h.con.Println("Welcome", user.Username)
 	return &user
 }
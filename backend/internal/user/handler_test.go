@@ -0,0 +1,68 @@
+package user
+
+import (
+	"banking-app/backend/pkg/console"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "db.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewService(repo, PasswordPolicy{MinLength: 8, RequireDigit: true})
+}
+
+func TestHandlerRegisterAndLogin(t *testing.T) {
+	s := newTestService(t)
+	var out strings.Builder
+	con := console.New(strings.NewReader(strings.Join([]string{
+		"alice", "S3cret-pass", "S3cret-pass", "bank", // register
+		"alice", "S3cret-pass", // login
+	}, "\n")), &out)
+	h := NewHandler(s, con)
+
+	h.Register()
+	if !strings.Contains(out.String(), "User registered: alice with role: bank") {
+		t.Fatalf("register printed %q", out.String())
+	}
+
+	u := h.Login()
+	if u == nil {
+		t.Fatalf("login failed, printed %q", out.String())
+	}
+	if u.Username != "alice" || u.Role != RoleBank {
+		t.Errorf("logged in as %v, want alice with role bank", u)
+	}
+}
+
+func TestHandlerRegisterRefusesMismatchedPasswords(t *testing.T) {
+	s := newTestService(t)
+	var out strings.Builder
+	con := console.New(strings.NewReader("bob\nS3cret-pass\nS3cret-typo\ncustomer\n"), &out)
+
+	NewHandler(s, con).Register()
+
+	if !strings.Contains(out.String(), "do not match") {
+		t.Errorf("register printed %q, want the mismatch reported", out.String())
+	}
+	if _, err := s.Login("bob", "S3cret-pass"); err == nil {
+		t.Error("bob was registered with mismatched passwords")
+	}
+}
+
+func TestHandlerLoginWrongPassword(t *testing.T) {
+	s := newTestService(t)
+	if _, err := s.Register("carol", "S3cret-pass", RoleCustomer); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	con := console.New(strings.NewReader("carol\nwrong-pass1\n"), &out)
+
+	if u := NewHandler(s, con).Login(); u != nil {
+		t.Errorf("logged in with the wrong password as %v", u)
+	}
+}
@@ -1,5 +1,10 @@
 package user
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Role string
 
 const (
@@ -13,3 +18,38 @@ type User struct {
 	Password string `json:"password"`
 	Role     Role   `json:"role"`
 }
+
+// String leaves the password out, so printing a user with %v or in an error
+// message never shows it.
+func (u User) String() string {
+	return fmt.Sprintf("User{ID: %d, Username: %s, Role: %s}", u.ID, u.Username, u.Role)
+}
+
+func ParseRole(s string) (Role, error) {
+	switch Role(s) {
+	case RoleBank, RoleCustomer:
+		return Role(s), nil
+	}
+	return "", fmt.Errorf("unknown role %q (expected %q or %q)", s, RoleBank, RoleCustomer)
+}
+
+// PasswordPolicy is checked when a user registers. Existing users keep
+// whatever password they already have.
+type PasswordPolicy struct {
+	MinLength    int  `json:"minLength"`
+	RequireDigit bool `json:"requireDigit"`
+	RequireUpper bool `json:"requireUpper"`
+}
+
+func (p PasswordPolicy) Validate(password string) error {
+	if len(password) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if p.RequireDigit && !strings.ContainsAny(password, "0123456789") {
+		return fmt.Errorf("password must contain a digit")
+	}
+	if p.RequireUpper && strings.ToLower(password) == password {
+		return fmt.Errorf("password must contain an uppercase letter")
+	}
+	return nil
+}
@@ -1,22 +1,15 @@
 package user
 
 import (
-	"banking-app/backend/internal/bank"
-	"banking-app/backend/internal/customer"
-	"encoding/json"
+	"banking-app/backend/pkg/storage"
 	"errors"
-	"os"
 	"sync"
 )
 
 type Repository struct {
-	file string
-	mu   sync.RWMutex
-	data struct {
-		Banks     []*bank.Bank         `json:"banks"`
-		Customers []*customer.Customer `json:"customers"`
-		Users     []User               `json:"users"`
-	}
+	file   string
+	mu     sync.RWMutex
+	users  []User
 	nextID int64
 }
 
@@ -33,31 +26,15 @@ func (r *Repository) load() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	f, err := os.Open(r.file)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// file not found, init empty
-			r.data.Users = []User{}
-			r.data.Banks = []*bank.Bank{}
-			r.data.Customers = []*customer.Customer{}
-			return nil
-		}
-		return err
-	}
-	defer f.Close()
-
-	if err := json.NewDecoder(f).Decode(&r.data); err != nil {
+	// file or collection not found leaves the list empty
+	r.users = []User{}
+	if err := storage.LoadCollection(r.file, "users", &r.users); err != nil {
 		return err
 	}
 
-	// Ensure Users array exists even if not in file
-	if r.data.Users == nil {
-		r.data.Users = []User{}
-	}
-
 	// Set nextID based on the highest existing user ID
 	r.nextID = 1
-	for _, user := range r.data.Users {
+	for _, user := range r.users {
 		if user.ID >= r.nextID {
 			r.nextID = user.ID + 1
 		}
@@ -67,30 +44,23 @@ func (r *Repository) load() error {
 }
 
 func (r *Repository) save() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	f, err := os.Create(r.file)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "  ")
-	return enc.Encode(r.data)
+	// Only the users section is replaced, banks and customers are kept as-is
+	return storage.SaveCollection(r.file, "users", r.users)
 }
 
 func (r *Repository) Create(user User) (User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	// check for duplicate
-	for _, u := range r.data.Users {
+	for _, u := range r.users {
 		if u.Username == user.Username {
 			return User{}, errors.New("username already exists")
 		}
 	}
-	r.nextID++
 	user.ID = r.nextID
-	r.data.Users = append(r.data.Users, user)
+	r.nextID++
+	r.users = append(r.users, user)
 
 	// Save the updated data to the file
 	if err := r.save(); err != nil {
@@ -100,11 +70,37 @@ func (r *Repository) Create(user User) (User, error) {
 	return user, nil
 }
 
+func (r *Repository) GetByID(id int64) (User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return User{}, errors.New("user not found")
+}
+
 func (r *Repository) GetByUsername(username string) (User, error) {
-	for _, user := range r.data.Users {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
 		if user.Username == username {
 			return user, nil
 		}
 	}
 	return User{}, errors.New("username not found")
 }
+
+func (r *Repository) GetAll() []User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Return a copy to avoid external modification
+	users := make([]User, len(r.users))
+	copy(users, r.users)
+
+	return users
+}
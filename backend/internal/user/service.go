@@ -1,16 +1,30 @@
 package user
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 type Service struct {
-	repo *Repository
+	repo   *Repository
+	policy PasswordPolicy
 }
 
-func NewService(r *Repository) *Service {
-	return &Service{repo: r}
+func NewService(r *Repository, policy PasswordPolicy) *Service {
+	return &Service{repo: r, policy: policy}
 }
 
 func (s *Service) Register(username, password string, role Role) (User, error) {
+	if strings.TrimSpace(username) == "" {
+		return User{}, errors.New("username cannot be empty")
+	}
+	if password == "" {
+		return User{}, errors.New("password cannot be empty")
+	}
+	if err := s.policy.Validate(password); err != nil {
+		return User{}, err
+	}
+
 	user := User{
 		Username: username,
 		Password: password,
@@ -31,3 +45,11 @@ func (s *Service) Login(username, password string) (User, error) {
 
 	return user, nil
 }
+
+func (s *Service) GetUser(id int64) (User, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *Service) GetAllUsers() []User {
+	return s.repo.GetAll()
+}
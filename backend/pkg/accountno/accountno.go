@@ -0,0 +1,92 @@
+package accountno
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An account number is the 4-digit bank code (the bank's ID), the 8-digit
+// account serial (the account's ID) and two check digits, 14 digits in all:
+//
+//	0001 0000 0042 20
+//
+// The check digits follow ISO 7064 MOD 97-10, the scheme IBANs use, so any
+// single mistyped digit and almost every swap of two digits is caught. This
+// leaves room for 9999 banks with 99,999,999 accounts between them.
+
+const (
+	bankDigits    = 4
+	accountDigits = 8
+	checkDigits   = 2
+
+	// Length is the number of digits in an account number.
+	Length = bankDigits + accountDigits + checkDigits
+
+	// MaxBankID and MaxAccountID are the largest IDs that fit.
+	MaxBankID    = 9999
+	MaxAccountID = 99_999_999
+)
+
+// New returns the account number of an account, without spaces. It fails
+// when either ID is out of range.
+func New(bankID, accountID int64) (string, error) {
+	if bankID < 1 || bankID > MaxBankID {
+		return "", fmt.Errorf("bank ID %d does not fit an account number (1 to %d)", bankID, MaxBankID)
+	}
+	if accountID < 1 || accountID > MaxAccountID {
+		return "", fmt.Errorf("account ID %d does not fit an account number (1 to %d)", accountID, MaxAccountID)
+	}
+	base := fmt.Sprintf("%0*d%0*d", bankDigits, bankID, accountDigits, accountID)
+	return base + fmt.Sprintf("%02d", 98-mod97(base+"00")), nil
+}
+
+// Parse checks an account number typed by a person, which may contain spaces
+// or dashes, and returns the bank and account it points to.
+func Parse(s string) (bankID, accountID int64, err error) {
+	digits := Normalize(s)
+	if len(digits) != Length {
+		return 0, 0, fmt.Errorf("invalid account number %q: it must have %d digits", s, Length)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, 0, fmt.Errorf("invalid account number %q: it can only contain digits", s)
+		}
+	}
+	if mod97(digits) != 1 {
+		return 0, 0, fmt.Errorf("invalid account number %q: the check digits do not match, please check for typos", s)
+	}
+
+	bankID, _ = strconv.ParseInt(digits[:bankDigits], 10, 64)
+	accountID, _ = strconv.ParseInt(digits[bankDigits:bankDigits+accountDigits], 10, 64)
+	return bankID, accountID, nil
+}
+
+// Normalize drops the spaces and dashes people use to group digits.
+func Normalize(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(s))
+}
+
+// Group formats an account number IBAN-style, in groups of four digits.
+func Group(number string) string {
+	number = Normalize(number)
+
+	var b strings.Builder
+	for i, r := range number {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// mod97 is the remainder of a string of digits divided by 97, worked out
+// piecewise so numbers longer than an int64 can be checked.
+func mod97(digits string) int {
+	rem := 0
+	for _, r := range digits {
+		rem = (rem*10 + int(r-'0')) % 97
+	}
+	return rem
+}
@@ -0,0 +1,22 @@
+package accountno_test
+
+import (
+	"banking-app/backend/pkg/accountno"
+	"fmt"
+)
+
+// The number of account 42 of bank 1, the one in the package comment.
+func ExampleNew() {
+	number, err := accountno.New(1, 42)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(accountno.Group(number))
+
+	bankID, accountID, err := accountno.Parse("0001 0000 0042 20")
+	fmt.Println(bankID, accountID, err)
+	// Output:
+	// 0001 0000 0042 20
+	// 1 42 <nil>
+}
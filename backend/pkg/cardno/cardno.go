@@ -0,0 +1,89 @@
+package cardno
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A card number (PAN) is 16 digits: the issuer number, which is 4 followed by
+// the 5-digit bank code (the bank's ID), the 9-digit card serial (the card's
+// ID) and a Luhn check digit:
+//
+//	4000 0100 0000 0423
+//
+// The Luhn check catches any single mistyped digit and most swaps of two
+// neighbouring digits.
+
+const (
+	bankDigits   = 5
+	serialDigits = 9
+
+	// Length is the number of digits in a card number.
+	Length = 1 + bankDigits + serialDigits + 1
+
+	// MaxBankID and MaxCardID are the largest IDs that fit.
+	MaxBankID = 99_999
+	MaxCardID = 999_999_999
+)
+
+// New returns the card number of a card, without spaces. It fails when
+// either ID is out of range.
+func New(bankID, cardID int64) (string, error) {
+	if bankID < 1 || bankID > MaxBankID {
+		return "", fmt.Errorf("bank ID %d does not fit a card number (1 to %d)", bankID, MaxBankID)
+	}
+	if cardID < 1 || cardID > MaxCardID {
+		return "", fmt.Errorf("card ID %d does not fit a card number (1 to %d)", cardID, MaxCardID)
+	}
+	base := fmt.Sprintf("4%0*d%0*d", bankDigits, bankID, serialDigits, cardID)
+	return base + strconv.Itoa(checkDigit(base)), nil
+}
+
+// Parse checks a card number typed by a person, which may contain spaces or
+// dashes, and returns it without them.
+func Parse(s string) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(s))
+	if len(digits) != Length {
+		return "", fmt.Errorf("invalid card number: it must have %d digits", Length)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("invalid card number: it can only contain digits")
+		}
+	}
+	if checkDigit(digits[:Length-1]) != int(digits[Length-1]-'0') {
+		return "", fmt.Errorf("invalid card number: the check digit does not match, please check for typos")
+	}
+	return digits, nil
+}
+
+// Mask hides all but the first six and last four digits, the most of a card
+// number that may be shown.
+func Mask(pan string) string {
+	if len(pan) != Length {
+		return pan
+	}
+	masked := pan[:6] + strings.Repeat("*", Length-10) + pan[Length-4:]
+	return masked[:4] + " " + masked[4:8] + " " + masked[8:12] + " " + masked[12:]
+}
+
+// checkDigit is the Luhn check digit for a string of digits: every second
+// digit from the right is doubled, and the check digit brings the sum of
+// all digits up to a multiple of ten.
+func checkDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
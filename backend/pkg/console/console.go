@@ -0,0 +1,197 @@
+package console
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// Console is the input and output shared by every menu handler. There must be
+// only one per process: a bufio.Reader buffers ahead, so two readers on the
+// same input would steal each other's lines.
+type Console struct {
+	in  *bufio.Reader
+	out io.Writer
+	tty *os.File // set when the input is an interactive terminal
+
+	// set on a scripted console, which collects the output in printed
+	// until the next prompt and hands it to record with the prompt
+	record  func(Step)
+	printed *strings.Builder
+	line    int
+}
+
+// Step is one prompt of a scripted console: what was printed since the
+// previous prompt, the prompt and the script line that answered it. The
+// answer to a secret prompt is left out.
+type Step struct {
+	Line   int    `json:"line,omitempty"`
+	Output string `json:"output,omitempty"`
+	Prompt string `json:"prompt,omitempty"`
+	Input  string `json:"input,omitempty"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+func New(in io.Reader, out io.Writer) *Console {
+	c := &Console{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+
+	if f, ok := in.(*os.File); ok && isTerminal(f.Fd()) {
+		c.tty = f
+	}
+
+	return c
+}
+
+// NewScripted returns a console that answers its prompts with the lines of
+// script, skipping lines that start with #, and hands each prompt to record
+// as a Step instead of printing anything. Flush records what was printed
+// after the last prompt.
+func NewScripted(script io.Reader, record func(Step)) *Console {
+	printed := &strings.Builder{}
+	return &Console{
+		in:      bufio.NewReader(script),
+		out:     printed,
+		record:  record,
+		printed: printed,
+	}
+}
+
+// Flush records the output of a scripted console since its last prompt, as
+// a Step without a prompt. It does nothing on other consoles.
+func (c *Console) Flush() {
+	if c.record == nil || c.printed.Len() == 0 {
+		return
+	}
+	c.record(Step{Output: c.printed.String()})
+	c.printed.Reset()
+}
+
+// Interactive reports whether a person is typing the input, as opposed to a
+// pipe or a file.
+func (c *Console) Interactive() bool {
+	return c.tty != nil
+}
+
+// Prompt prints label and returns the next input line without surrounding
+// whitespace. It returns io.EOF once the input is exhausted.
+func (c *Console) Prompt(label string) (string, error) {
+	if c.record != nil {
+		return c.answer(label, false)
+	}
+
+	fmt.Fprint(c.out, label)
+
+	line, err := c.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// PromptSecret is Prompt for passwords and PINs: when the input is a terminal,
+// echo is switched off while the line is typed, and it fails rather than
+// show the secret if echo cannot be switched off. Piped input is read as is.
+func (c *Console) PromptSecret(label string) (string, error) {
+	if c.record != nil {
+		return c.answer(label, true)
+	}
+	if c.tty == nil {
+		return c.Prompt(label)
+	}
+
+	restore, err := disableEcho(c.tty.Fd())
+	if err != nil {
+		return "", fmt.Errorf("failed to hide input: %w", err)
+	}
+
+	// Ctrl-C would otherwise leave the terminal without echo
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-interrupted:
+			restore()
+			fmt.Fprintln(c.out)
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	line, err := c.Prompt(label)
+
+	close(done)
+	signal.Stop(interrupted)
+	restore()
+
+	// the Enter key was not echoed either
+	fmt.Fprintln(c.out)
+
+	return line, err
+}
+
+// answer reads the script line answering a prompt of a scripted console and
+// records the step.
+func (c *Console) answer(label string, secret bool) (string, error) {
+	for {
+		line, err := c.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", err
+		}
+		c.line++
+
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		step := Step{Line: c.line, Output: c.printed.String(), Prompt: label, Input: line, Secret: secret}
+		if secret {
+			step.Input = ""
+		}
+		c.record(step)
+		c.printed.Reset()
+		return line, nil
+	}
+}
+
+// PromptNewSecret asks for a new secret and then for it again, so a typo
+// cannot go unnoticed when nothing is echoed.
+func (c *Console) PromptNewSecret(label, confirmLabel string) (string, error) {
+	secret, err := c.PromptSecret(label)
+	if err != nil {
+		return "", err
+	}
+
+	confirm, err := c.PromptSecret(confirmLabel)
+	if err != nil {
+		return "", err
+	}
+
+	if secret != confirm {
+		return "", errors.New("the two entries do not match")
+	}
+
+	return secret, nil
+}
+
+func (c *Console) Printf(format string, args ...any) {
+	fmt.Fprintf(c.out, format, args...)
+}
+
+func (c *Console) Println(args ...any) {
+	fmt.Fprintln(c.out, args...)
+}
+
+// Write lets the console be used wherever an io.Writer is expected.
+func (c *Console) Write(p []byte) (int, error) {
+	return c.out.Write(p)
+}
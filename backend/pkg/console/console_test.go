@@ -0,0 +1,55 @@
+package console
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestScriptedConsoleRecordsSteps(t *testing.T) {
+	var steps []Step
+	con := NewScripted(strings.NewReader("# log in\nalice\nS3cret-pass\n"), func(s Step) {
+		steps = append(steps, s)
+	})
+
+	con.Println("Welcome")
+	name, err := con.Prompt("Username: ")
+	if err != nil || name != "alice" {
+		t.Fatalf("Prompt = %q, %v, want alice", name, err)
+	}
+	password, err := con.PromptSecret("Password: ")
+	if err != nil || password != "S3cret-pass" {
+		t.Fatalf("PromptSecret = %q, %v, want S3cret-pass", password, err)
+	}
+	con.Println("Bye")
+	if _, err := con.Prompt("Choose: "); err != io.EOF {
+		t.Fatalf("Prompt after the script = %v, want io.EOF", err)
+	}
+	con.Flush()
+
+	want := []Step{
+		{Line: 2, Output: "Welcome\n", Prompt: "Username: ", Input: "alice"},
+		{Line: 3, Prompt: "Password: ", Secret: true},
+		{Output: "Bye\n"},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps, want %d: %+v", len(steps), len(want), steps)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, steps[i], want[i])
+		}
+	}
+}
+
+func TestPromptNewSecretMismatch(t *testing.T) {
+	var out strings.Builder
+	con := New(strings.NewReader("one\ntwo\n"), &out)
+
+	if _, err := con.PromptNewSecret("New: ", "Again: "); err == nil {
+		t.Fatal("PromptNewSecret accepted two different entries")
+	}
+	if got := out.String(); got != "New: Again: " {
+		t.Errorf("printed %q, want both prompts", got)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package console
+
+import "errors"
+
+// Terminals are not detected here, so input is always read as if piped.
+
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+func disableEcho(fd uintptr) (func(), error) {
+	return nil, errors.New("hiding input is not supported on this platform")
+}
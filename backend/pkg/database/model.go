@@ -1,16 +1,93 @@
 package database
 
 import (
+	"banking-app/backend/internal/account"
+	"banking-app/backend/internal/aml"
 	"banking-app/backend/internal/bank"
+	"banking-app/backend/internal/batch"
+	"banking-app/backend/internal/calendar"
+	"banking-app/backend/internal/card"
 	"banking-app/backend/internal/customer"
+	"banking-app/backend/internal/eod"
+	"banking-app/backend/internal/fee"
+	"banking-app/backend/internal/gl"
+	"banking-app/backend/internal/hold"
+	"banking-app/backend/internal/interest"
+	"banking-app/backend/internal/limit"
+	"banking-app/backend/internal/loan"
+	"banking-app/backend/internal/notification"
+	"banking-app/backend/internal/payee"
+	"banking-app/backend/internal/reconcile"
+	"banking-app/backend/internal/sanctions"
+	"banking-app/backend/internal/standingorder"
+	"banking-app/backend/internal/transactions"
+	"banking-app/backend/internal/user"
 )
 
 // Database represents the overall database structure with collections
 type Database struct {
-	Banks     []bank.Bank         `json:"banks"`
-	Customers []customer.Customer `json:"customers"`
+	Accounts           []account.Account           `json:"accounts"`
+	Accruals           []interest.Accrual          `json:"accruals"`
+	Alerts             []aml.Alert                 `json:"alerts"`
+	Authorisations     []card.Authorisation        `json:"authorisations"`
+	Banks              []bank.Bank                 `json:"banks"`
+	Batches            []batch.Batch               `json:"batches"`
+	Cards              []card.Card                 `json:"cards"`
+	ClosedDays         []eod.Day                   `json:"closedDays"`
+	Closings           []eod.Closing               `json:"closings"`
+	Customers          []customer.Customer         `json:"customers"`
+	Fees               []fee.Rule                  `json:"fees"`
+	GLAccounts         []gl.Account                `json:"glAccounts"`
+	Holds              []hold.Hold                 `json:"holds"`
+	Holidays           []calendar.Holiday          `json:"holidays"`
+	Journal            []gl.Entry                  `json:"journal"`
+	Limits             []limit.Rule                `json:"limits"`
+	Loans              []loan.Loan                 `json:"loans"`
+	MaintenanceCharges []fee.MaintenanceCharge     `json:"maintenanceCharges"`
+	Notifications      []notification.Notification `json:"notifications"`
+	Payees             []payee.Payee               `json:"payees"`
+	Products           []interest.Product          `json:"products"`
+	Reconciliations    []reconcile.Run             `json:"reconciliations"`
+	ScreeningCases     []sanctions.Case            `json:"screeningCases"`
+	StandingOrders     []standingorder.Order       `json:"standingOrders"`
+	Transactions       []transactions.Transaction  `json:"transactions"`
+	Users              []user.User                 `json:"users"`
 }
 
-// Note: Both banks and customers are stored in database.json
-// Banks can have multiple customers (stored as customer IDs)
+// Note: Every collection is stored in database.json
+// Banks can have multiple customers (customers store the bank ID)
 // Customers can only belong to one bank (stored as bank ID)
+// Accounts belong to one customer at one bank
+// Transactions reference the accounts they move money between and the
+// business day of the bank they were posted into
+// ClosedDays are the business days each bank has closed with its end-of-day
+// run, with the trial balance taken at the close; Closings mark the banks
+// whose end of day is running, frozen to postings from every process
+// GLAccounts are each bank's general ledger chart of accounts, Journal the
+// double-entry entries every transaction books into it
+// Reconciliations are the checks of stored balances and the general ledger
+// against the transactions, with the corrections a repair made
+// Products are a bank's savings products, accruals track each savings
+// account's uncapitalised interest
+// Fees are a bank's fee rules, banks without a rule for a type charge the
+// configured default; fees are posted to the bank's internal income account
+// Limits cap what customers can move out, per bank or per customer, and may
+// be temporary raises
+// Payees are the accounts a customer has saved to send money to
+// Batches are payment files a customer imported to pay many accounts at
+// once, each line with whether it was paid
+// Holidays are the days a bank does not execute standing orders
+// StandingOrders are customers' recurring transfers from one of their accounts
+// Loans belong to one customer and are paid into and repaid from one of
+// their accounts
+// Alerts are transactions that matched an anti-money-laundering rule, held
+// transactions wait in the bank's internal suspense account until reviewed
+// ScreeningCases are names that matched the sanctions watchlist, for the bank
+// to clear or confirm
+// Cards are virtual debit cards on a customer account, Authorisations are the
+// card payments merchants asked for
+// Holds set money aside on an account, such as an authorised card payment
+// not yet captured, it stays in the balance but cannot be spent
+// Notifications are messages for a customer, such as an account going
+// overdrawn
+// maintenanceCharges records which months each account has been billed
@@ -0,0 +1,62 @@
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Amounts are stored as int64 cents so balances never suffer from float
+// rounding. Parse and Format convert to and from the "1234.56" form used in
+// prompts, flags and reports.
+
+// Parse converts a decimal string such as "12", "12.3" or "12.34" into cents.
+func Parse(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("amount cannot be empty")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" && !hasFrac {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("invalid amount: %q has more than 2 decimal places", s)
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+
+	amount := units*100 + cents
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// Format renders cents as a decimal string with two places, e.g. "-12.05".
+func Format(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
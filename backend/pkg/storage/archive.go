@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// An archive is a gzipped tar file holding a manifest.json and one JSON Lines
+// file per collection, one record per line. The manifest lists every
+// collection file with its record count and SHA-256, so an archive can be
+// checked before anything is restored from it.
+const (
+	ArchiveFormat  = "banking-archive"
+	ArchiveVersion = 1
+	manifestName   = "manifest.json"
+)
+
+// ErrNotEmpty is returned by Restore when the database already has data and
+// overwriting was not asked for.
+var ErrNotEmpty = errors.New("database is not empty")
+
+type Manifest struct {
+	Format      string               `json:"format"`
+	Version     int                  `json:"version"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	Collections []ArchivedCollection `json:"collections"`
+}
+
+type ArchivedCollection struct {
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+	SHA256  string `json:"sha256"`
+}
+
+// Archive is a read and checked archive, the records of each collection as
+// they were exported.
+type Archive struct {
+	Manifest    Manifest
+	Collections map[string][]json.RawMessage
+}
+
+// Export writes every collection of the database to an archive. Collections
+// in the list that the file does not have yet are written empty.
+func Export(dbPath string, w io.Writer, collections []string) (*Manifest, error) {
+	mu.Lock()
+	db, err := readFile(dbPath)
+	mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range db {
+		names[name] = true
+	}
+	for _, name := range collections {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	manifest := &Manifest{Format: ArchiveFormat, Version: ArchiveVersion, CreatedAt: time.Now().UTC()}
+	files := map[string][]byte{}
+	for _, name := range sorted {
+		var records []json.RawMessage
+		if raw, ok := db[name]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &records); err != nil {
+				return nil, fmt.Errorf("collection %s is not a list: %w", name, err)
+			}
+		}
+
+		var buf bytes.Buffer
+		for _, record := range records {
+			if err := json.Compact(&buf, record); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", name, err)
+			}
+			buf.WriteByte('\n')
+		}
+
+		file := name + ".jsonl"
+		sum := sha256.Sum256(buf.Bytes())
+		manifest.Collections = append(manifest.Collections, ArchivedCollection{
+			Name:    name,
+			File:    file,
+			Records: len(records),
+			SHA256:  hex.EncodeToString(sum[:]),
+		})
+		files[file] = buf.Bytes()
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	write := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: manifest.CreatedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	// the manifest goes first so readers know what to expect
+	if err := write(manifestName, manifestData); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	for _, c := range manifest.Collections {
+		if err := write(c.File, files[c.File]); err != nil {
+			return nil, fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// ReadArchive reads an archive and checks it against its manifest: the
+// format and version, that every listed file is there with the right
+// checksum and record count, that every line is a JSON object, and that
+// there is nothing else.
+func ReadArchive(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not an archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("archive has %s twice", name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = data
+	}
+
+	data, ok := files[manifestName]
+	if !ok {
+		return nil, fmt.Errorf("archive has no %s", manifestName)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestName, err)
+	}
+	if manifest.Format != ArchiveFormat {
+		return nil, fmt.Errorf("archive format is %q, expected %q", manifest.Format, ArchiveFormat)
+	}
+	if manifest.Version < 1 || manifest.Version > ArchiveVersion {
+		return nil, fmt.Errorf("archive version %d is not supported (this build reads up to %d)", manifest.Version, ArchiveVersion)
+	}
+	delete(files, manifestName)
+
+	archive := &Archive{Manifest: manifest, Collections: map[string][]json.RawMessage{}}
+	for _, c := range manifest.Collections {
+		if _, ok := archive.Collections[c.Name]; ok {
+			return nil, fmt.Errorf("manifest lists %s twice", c.Name)
+		}
+		data, ok := files[path.Clean(c.File)]
+		if !ok {
+			return nil, fmt.Errorf("archive has no %s for collection %s", c.File, c.Name)
+		}
+		delete(files, path.Clean(c.File))
+
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != c.SHA256 {
+			return nil, fmt.Errorf("%s does not match its checksum, the archive is damaged", c.File)
+		}
+
+		records := []json.RawMessage{}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, len(data)+1)
+		for line := 1; scanner.Scan(); line++ {
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			if text[0] != '{' || !json.Valid(text) {
+				return nil, fmt.Errorf("%s line %d is not a JSON object", c.File, line)
+			}
+			records = append(records, json.RawMessage(append([]byte(nil), text...)))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", c.File, err)
+		}
+		if len(records) != c.Records {
+			return nil, fmt.Errorf("%s has %d records, the manifest says %d", c.File, len(records), c.Records)
+		}
+		archive.Collections[c.Name] = records
+	}
+
+	if len(files) > 0 {
+		extra := make([]string, 0, len(files))
+		for name := range files {
+			extra = append(extra, name)
+		}
+		sort.Strings(extra)
+		return nil, fmt.Errorf("archive has %s, which the manifest does not list", strings.Join(extra, ", "))
+	}
+
+	return archive, nil
+}
+
+// Restore writes the collections of an archive to the database. It refuses
+// a database that has any records unless overwrite is set, in which case the
+// database is replaced by the archive as a whole.
+func Restore(dbPath string, archive *Archive, overwrite bool) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	db, err := readFile(dbPath)
+	if err != nil {
+		return err
+	}
+
+	if !overwrite {
+		var filled []string
+		for name, raw := range db {
+			var records []json.RawMessage
+			if json.Unmarshal(raw, &records) != nil || len(records) > 0 {
+				filled = append(filled, name)
+			}
+		}
+		if len(filled) > 0 {
+			sort.Strings(filled)
+			return fmt.Errorf("%w, it has %s", ErrNotEmpty, strings.Join(filled, ", "))
+		}
+	}
+
+	restored := map[string]json.RawMessage{}
+	for name, records := range archive.Collections {
+		raw, err := json.Marshal(records)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		restored[name] = raw
+	}
+
+	return writeFile(dbPath, restored)
+}
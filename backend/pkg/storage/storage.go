@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// The database file is a single JSON object where every top-level key is a
+// collection, e.g. {"banks": [...], "users": [...]}. Each repository owns one
+// collection and must leave the others untouched when it saves, so all reads
+// and writes go through this package.
+
+var mu sync.Mutex
+
+// LoadCollection decodes the named collection into v. A missing file or a
+// missing collection leaves v unchanged.
+func LoadCollection(path, name string, v any) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	db, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	raw, ok := db[name]
+	if !ok || string(raw) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// SaveCollection replaces the named collection with v and writes the file back.
+func SaveCollection(path, name string, v any) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	db, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	db[name] = raw
+
+	return writeFile(path, db)
+}
+
+// Migrate upgrades the database file in place: a legacy file holding a bare
+// array of banks is wrapped into the unified layout, and every collection in
+// collections is created empty if it is missing. It returns the names of the
+// collections that were added.
+func Migrate(path string, collections []string) ([]string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	db := map[string]json.RawMessage{}
+	var added []string
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		// legacy format: the whole file is the banks collection
+		db["banks"] = json.RawMessage(data)
+		added = append(added, "banks")
+	} else if len(data) > 0 {
+		if err := json.Unmarshal(data, &db); err != nil {
+			return nil, fmt.Errorf("failed to parse database: %w", err)
+		}
+	}
+
+	for _, name := range collections {
+		if raw, ok := db[name]; !ok || string(raw) == "null" {
+			db[name] = json.RawMessage("[]")
+			added = append(added, name)
+		}
+	}
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	return added, writeFile(path, db)
+}
+
+func readFile(path string) (map[string]json.RawMessage, error) {
+	db := map[string]json.RawMessage{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return db, nil
+		}
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return db, nil
+	}
+
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse database: %w", err)
+	}
+
+	return db, nil
+}
+
+func writeFile(path string, db map[string]json.RawMessage) error {
+	data, err := json.MarshalIndent(db, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal database: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write database: %w", err)
+	}
+
+	return nil
+}